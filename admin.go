@@ -0,0 +1,49 @@
+package main
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+const grantAdminUsage = `usage: giron-service grant-admin <user name>
+
+gives the user a role granting users.admin, so they can manage users, roles
+and role assignments`
+
+// runGrantAdminCommand Handles the `grant-admin` subcommand and returns the
+// process exit code
+func runGrantAdminCommand(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, grantAdminUsage)
+		return 2
+	}
+
+	err := model.GrantAdministrator(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "grant-admin failed: "+err.Error())
+		return 1
+	}
+	fmt.Println("User '" + args[0] + "' can now manage users and roles")
+
+	return 0
+}
@@ -0,0 +1,156 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/globals"
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateApiToken Create an API token for the session user
+//
+//	@Summary		Create a new API token
+//	@Description	Create a named API token for the session user, optionally limited to some privileges and expiring after a number of days. The token is only returned by this call. Tokens cannot create further tokens
+//	@Tags			tokens
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	body	model.ProposedApiToken	true	"API token data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.NewApiToken
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		403	{object}	model.FailureMsg
+//	@Router			/token [post]
+func (g *GironService) CreateApiToken(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		// a token could otherwise hand out a token without its scopes or expiry
+		if _, viaToken := c.Get(globals.ApiTokenKey); viaToken {
+			c.IndentedJSON(http.StatusForbidden, gin.H{"error": "API tokens cannot be created with an API token"})
+			return
+		}
+
+		var json model.ProposedApiToken
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.TrimSpace(json.Name) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+			return
+		}
+		if json.ExpiresInDays < 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "expiresInDays cannot be negative"})
+			return
+		}
+
+		id, token, err := model.CreateApiToken(json, userObject.Id)
+		if err != nil {
+			var noSuchPrivilege *model.NoSuchPrivilege
+			if errors.As(err, &noSuchPrivilege) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		created, err := model.GetApiTokenById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: API token Id '" + strconv.Itoa(id) + "' created for user '" + userObject.UserName + "'")
+		c.IndentedJSON(http.StatusOK, model.NewApiToken{ApiToken: created, Token: token})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetApiTokens Retrieve the API tokens of the session user
+//
+//	@Summary		Retrieve the API tokens of the session user
+//	@Description	Retrieve the session user's API tokens with their scopes, expiry and when they were last used. Token values are never returned
+//	@Tags			tokens
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Security		BearerAuth
+//	@Success		200	{object}	model.ApiTokenList
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/tokens [get]
+func (g *GironService) GetApiTokens(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		tokens, err := model.GetApiTokensByUserId(userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of API tokens: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Returned list of API tokens")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": tokens})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteApiTokenById Revoke an API token by its Id
+//
+//	@Summary		Revoke an API token by Id
+//	@Description	Revoke one of the session user's API tokens. It stops working at once
+//	@Tags			tokens
+//	@Produce		json
+//	@Param			id	path	string	true	"API token Id"
+//	@Security		BasicAuth
+//	@Security		BearerAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/token/{id} [delete]
+func (g *GironService) DeleteApiTokenById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		status, err := model.DeleteApiTokenById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot revoke API token: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to revoke API token! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "API token Id '" + strconv.Itoa(id) + "' has been revoked"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with API token id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
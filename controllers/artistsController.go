@@ -0,0 +1,402 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateArtist Add an artist
+//
+//	@Summary		Create a new artist
+//	@Description	Create a new artist. New artists start out unapproved and not invoiced
+//	@Tags			artists
+//	@Accept			json
+//	@Produce		json
+//	@Param			artist	body	model.ProposedArtist	true	"Artist data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/artist [post]
+func (g *GironService) CreateArtist(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedArtist
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if json.Name == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+			return
+		}
+
+		s, err := model.CreateArtist(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Artist has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetArtists Retrieve list of all artists
+//
+//	@Summary		Retrieve list of all artists
+//	@Description	Retrieve list of all artists, approved or not
+//	@Tags			artists
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.ArtistList
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/artists [get]
+func (g *GironService) GetArtists(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		entries, err := model.GetArtists()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of artists: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Returned list of artists")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": entries})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetArtistById Retrieve artist by Id
+//
+//	@Summary		Retrieve artist by Id
+//	@Description	Retrieve artist by Id
+//	@Tags			artists
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Artist
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/artist/{id} [get]
+func (g *GironService) GetArtistById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		ent, err := model.GetArtistById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with artist id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateArtistById Update the details of an artist
+//
+//	@Summary		Update an artist
+//	@Description	Update the details of an artist
+//	@Tags			artists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Param			json	body	model.ProposedArtist	true	"Artist data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/artist/{id} [patch]
+func (g *GironService) UpdateArtistById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.ProposedArtist
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.Name == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+			return
+		}
+
+		status, err := model.UpdateArtistById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Artist Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with artist id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteArtistById Delete an artist by its Id
+//
+//	@Summary		Delete an artist by Id
+//	@Description	Delete an artist by Id. Its booth, if any, is freed
+//	@Tags			artists
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/artist/{id} [delete]
+func (g *GironService) DeleteArtistById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteArtistById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete artist: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove artist! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Artist Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with artist id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetApprovalStateArtistById Set the approval state of an artist
+//
+//	@Summary		Set artist approval state
+//	@Description	Approve or unapprove an artist
+//	@Tags			artists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Param			json	body	model.VendorHallApproval	true	"Approval state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/artist/{id}/approve [post]
+func (g *GironService) SetApprovalStateArtistById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.VendorHallApproval
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetApprovalStateArtistById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Artist approval state set to '" + strconv.FormatBool(json.State) + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with artist id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetArtistInvoiceState Move an artist along the invoicing workflow
+//
+//	@Summary		Set artist invoice state
+//	@Description	Mark an approved artist as "invoiced", then as "fulfilled" once paid. Skipping or repeating a step is refused
+//	@Tags			artists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Param			json	body	model.InvoiceStateChange	true	"Invoice state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/artist/{id}/invoice [post]
+func (g *GironService) SetArtistInvoiceState(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.InvoiceStateChange
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetArtistInvoiceState(id, json, userObject.Id)
+		if err != nil {
+			var badTransition *model.InvalidInvoiceTransition
+			if errors.As(err, &badTransition) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Artist invoice state set to '" + json.State + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with artist id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// setArtistBooth Shared body of the booth assignment handlers
+func (g *GironService) setArtistBooth(c *gin.Context, id int, json model.BoothAssignment) {
+	userObject, _ := g.GetUserId(c)
+	status, err := model.SetArtistBooth(id, json, userObject.Id)
+	if err != nil {
+		var noBooth *model.NoSuchBooth
+		if errors.As(err, &noBooth) {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var inUse *model.BoothInUse
+		if errors.As(err, &inUse) {
+			c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+			return
+		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	if status {
+		c.IndentedJSON(http.StatusOK, gin.H{"message": "Booth for artist Id '" + strconv.Itoa(id) + "' updated"})
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with artist id " + strconv.Itoa(id)})
+	}
+}
+
+// AssignArtistBooth Give an artist a booth
+//
+//	@Summary		Assign a booth to an artist
+//	@Description	Give an artist a booth, replacing the one it held before. A booth can only be held by one vendor or artist at a time
+//	@Tags			artists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Param			json	body	model.BoothAssignment	true	"Booth to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/artist/{id}/booth [post]
+func (g *GironService) AssignArtistBooth(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.BoothAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.BoothId == 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "boothId is required"})
+			return
+		}
+
+		g.setArtistBooth(c, id, json)
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignArtistBooth Take away the booth of an artist
+//
+//	@Summary		Unassign the booth of an artist
+//	@Description	Free the booth held by an artist
+//	@Tags			artists
+//	@Produce		json
+//	@Param			id	path	string	true	"Artist Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/artist/{id}/booth [delete]
+func (g *GironService) UnassignArtistBooth(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		g.setArtistBooth(c, id, model.BoothAssignment{})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,106 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+func queryInt(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// GetAuditEntries Retrieve the audit trail
+//
+//	@Summary		Retrieve the audit trail
+//	@Description	Retrieve audit entries, newest first, optionally filtered by table, user, change class, record and date range
+//	@Tags			audit
+//	@Produce		json
+//	@Param			table		query	string	false	"Table changed, e.g. Panels"
+//	@Param			changedById	query	int		false	"Id of the user who made the change"
+//	@Param			changeClass	query	string	false	"Change class"	Enums(create, update, delete)
+//	@Param			recordId	query	int		false	"Id of the changed record"
+//	@Param			since		query	string	false	"Earliest change date (inclusive), e.g. 2024-06-01 00:00:00"
+//	@Param			until		query	string	false	"Latest change date (exclusive)"
+//	@Param			limit		query	int		false	"Maximum number of entries to return (default 100, max 1000)"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.AuditList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/audit [get]
+func (g *GironService) GetAuditEntries(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		filter := model.AuditFilter{
+			TableChanged: c.Query("table"),
+			ChangeClass:  c.Query("changeClass"),
+			Since:        c.Query("since"),
+			Until:        c.Query("until"),
+		}
+
+		var err error
+		if filter.ChangedById, err = queryInt(c, "changedById"); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid changedById: " + string(err.Error())})
+			return
+		}
+		if filter.RecordId, err = queryInt(c, "recordId"); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid recordId: " + string(err.Error())})
+			return
+		}
+		if filter.Limit, err = queryInt(c, "limit"); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid limit: " + string(err.Error())})
+			return
+		}
+		if filter.Limit <= 0 {
+			filter.Limit = defaultAuditLimit
+		} else if filter.Limit > maxAuditLimit {
+			filter.Limit = maxAuditLimit
+		}
+		if filter.ChangeClass != "" && filter.ChangeClass != model.AuditCreate &&
+			filter.ChangeClass != model.AuditUpdate && filter.ChangeClass != model.AuditDelete {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "changeClass must be one of create, update or delete"})
+			return
+		}
+
+		entries, err := model.GetAuditEntries(filter)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve audit entries: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": entries})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,217 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBooth Add a booth to a location
+//
+//	@Summary		Create a new booth
+//	@Description	Create a new booth in a location of the vendor hall
+//	@Tags			booths
+//	@Accept			json
+//	@Produce		json
+//	@Param			booth	body	model.ProposedBooth	true	"Booth data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/booth [post]
+func (g *GironService) CreateBooth(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedBooth
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if json.Label == "" || json.LocationId == 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "label and locationId are required"})
+			return
+		}
+
+		s, err := model.CreateBooth(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Booth has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetBooths Retrieve list of all booths
+//
+//	@Summary		Retrieve list of all booths
+//	@Description	Retrieve list of all booths, with the vendor or artist holding each of them
+//	@Tags			booths
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.BoothList
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/booths [get]
+func (g *GironService) GetBooths(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		booths, err := model.GetBooths()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of booths: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Returned list of booths")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": booths})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetBoothById Retrieve booth by Id
+//
+//	@Summary		Retrieve booth by Id
+//	@Description	Retrieve booth by Id
+//	@Tags			booths
+//	@Produce		json
+//	@Param			id	path	string	true	"Booth Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Booth
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/booth/{id} [get]
+func (g *GironService) GetBoothById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetBoothById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with booth id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateBoothById Update the label and location of a booth
+//
+//	@Summary		Update a booth
+//	@Description	Update the label and location of a booth
+//	@Tags			booths
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Booth Id"
+//	@Param			json	body	model.ProposedBooth	true	"Booth data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/booth/{id} [patch]
+func (g *GironService) UpdateBoothById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.ProposedBooth
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.Label == "" || json.LocationId == 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "label and locationId are required"})
+			return
+		}
+
+		status, err := model.UpdateBoothById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Booth Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with booth id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteBoothById Delete a booth by its Id
+//
+//	@Summary		Delete a booth by Id
+//	@Description	Delete a booth by Id. A booth still held by a vendor or artist cannot be deleted
+//	@Tags			booths
+//	@Produce		json
+//	@Param			id	path	string	true	"Booth Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/booth/{id} [delete]
+func (g *GironService) DeleteBoothById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		status, err := model.DeleteBoothById(id, userObject.Id)
+		if err != nil {
+			var inUse *model.BoothInUse
+			if errors.As(err, &inUse) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			log.Println("ERROR: Cannot delete booth: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove booth! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Booth Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with booth id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -136,7 +136,7 @@ func (g *GironService) GetBuildingById(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/building/{id} [patch]
 func (g *GironService) UpdateBuildingById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
@@ -150,13 +150,13 @@ func (g *GironService) UpdateBuildingById(c *gin.Context) {
 		}
 
 		// we don't need the status, since the error speaks for itself
-		_, err = model.UpdateBuildingById(id, json)
+		_, err = model.UpdateBuildingById(id, json, userObject.Id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
 		}
 
-		c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel location updated"})
+		c.IndentedJSON(http.StatusOK, gin.H{"message": "Building updated"})
 	} else {
 		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
 	}
@@ -175,10 +175,10 @@ func (g *GironService) UpdateBuildingById(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/building/{id} [delete]
 func (g *GironService) DeleteBuildingById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, _ := strconv.Atoi(c.Param("id"))
-		status, err := model.DeleteBuildingById(id)
+		status, err := model.DeleteBuildingById(id, userObject.Id)
 		if err != nil {
 			log.Println("ERROR: Cannot delete building: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove building! " + string(err.Error())})
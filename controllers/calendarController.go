@@ -0,0 +1,71 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// GetScheduleCalendar Retrieve the schedule as an iCalendar feed
+//
+//	@Summary		Retrieve the schedule as an iCalendar feed
+//	@Description	Publish the scheduled approved panels and screenings as an RFC 5545 iCalendar feed that calendar apps can subscribe to. The feed can be narrowed down to one location, one building, or the events carrying every one of the given tags
+//	@Tags			calendar
+//	@Produce		text/calendar
+//	@Param			locationId	query	int			false	"Location Id"
+//	@Param			buildingId	query	int			false	"Building Id"
+//	@Param			tag			query	[]string	false	"Tag name to filter by"	collectionFormat(multi)
+//	@Success		200	{string}	string
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/schedule.ics [get]
+func (g *GironService) GetScheduleCalendar(c *gin.Context) {
+	filter := model.CalendarFilter{
+		TagNames: c.QueryArray("tag"),
+	}
+
+	var err error
+	if filter.LocationId, err = queryInt(c, "locationId"); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid locationId: " + string(err.Error())})
+		return
+	}
+	if filter.BuildingId, err = queryInt(c, "buildingId"); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid buildingId: " + string(err.Error())})
+		return
+	}
+
+	events, err := model.GetCalendarEvents(filter)
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve calendar events: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	loc := g.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	c.Header("Content-Disposition", `inline; filename="schedule.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(renderCalendar(events, loc)))
+}
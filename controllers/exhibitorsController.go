@@ -0,0 +1,310 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateExhibitor Add an exhibitor
+//
+//	@Summary		Create a new exhibitor
+//	@Description	Create a new exhibitor. New exhibitors start out unapproved and not invoiced. The live event it exhibits at must exist
+//	@Tags			exhibitors
+//	@Accept			json
+//	@Produce		json
+//	@Param			exhibitor	body	model.ProposedExhibitor	true	"Exhibitor data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/exhibitor [post]
+func (g *GironService) CreateExhibitor(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedExhibitor
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if json.Name == "" || json.EventId == 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "name and eventId are required"})
+			return
+		}
+
+		s, err := model.CreateExhibitor(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Exhibitor has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetExhibitors Retrieve list of all exhibitors
+//
+//	@Summary		Retrieve list of all exhibitors
+//	@Description	Retrieve list of all exhibitors, approved or not
+//	@Tags			exhibitors
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.ExhibitorList
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/exhibitors [get]
+func (g *GironService) GetExhibitors(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		entries, err := model.GetExhibitors()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of exhibitors: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Returned list of exhibitors")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": entries})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetExhibitorById Retrieve exhibitor by Id
+//
+//	@Summary		Retrieve exhibitor by Id
+//	@Description	Retrieve exhibitor by Id
+//	@Tags			exhibitors
+//	@Produce		json
+//	@Param			id	path	string	true	"Exhibitor Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Exhibitor
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/exhibitor/{id} [get]
+func (g *GironService) GetExhibitorById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		ent, err := model.GetExhibitorById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with exhibitor id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateExhibitorById Update the details of an exhibitor
+//
+//	@Summary		Update an exhibitor
+//	@Description	Update the details of an exhibitor
+//	@Tags			exhibitors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Exhibitor Id"
+//	@Param			json	body	model.ProposedExhibitor	true	"Exhibitor data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/exhibitor/{id} [patch]
+func (g *GironService) UpdateExhibitorById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.ProposedExhibitor
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.Name == "" || json.EventId == 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "name and eventId are required"})
+			return
+		}
+
+		status, err := model.UpdateExhibitorById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Exhibitor Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with exhibitor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteExhibitorById Delete an exhibitor by its Id
+//
+//	@Summary		Delete an exhibitor by Id
+//	@Description	Delete an exhibitor by Id
+//	@Tags			exhibitors
+//	@Produce		json
+//	@Param			id	path	string	true	"Exhibitor Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/exhibitor/{id} [delete]
+func (g *GironService) DeleteExhibitorById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteExhibitorById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete exhibitor: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove exhibitor! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Exhibitor Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with exhibitor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetApprovalStateExhibitorById Set the approval state of an exhibitor
+//
+//	@Summary		Set exhibitor approval state
+//	@Description	Approve or unapprove an exhibitor
+//	@Tags			exhibitors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Exhibitor Id"
+//	@Param			json	body	model.VendorHallApproval	true	"Approval state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/exhibitor/{id}/approve [post]
+func (g *GironService) SetApprovalStateExhibitorById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.VendorHallApproval
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetApprovalStateExhibitorById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Exhibitor approval state set to '" + strconv.FormatBool(json.State) + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with exhibitor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetExhibitorInvoiceState Move an exhibitor along the invoicing workflow
+//
+//	@Summary		Set exhibitor invoice state
+//	@Description	Mark an approved exhibitor as "invoiced", then as "fulfilled" once paid. Skipping or repeating a step is refused
+//	@Tags			exhibitors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Exhibitor Id"
+//	@Param			json	body	model.InvoiceStateChange	true	"Invoice state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/exhibitor/{id}/invoice [post]
+func (g *GironService) SetExhibitorInvoiceState(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.InvoiceStateChange
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetExhibitorInvoiceState(id, json, userObject.Id)
+		if err != nil {
+			var badTransition *model.InvalidInvoiceTransition
+			if errors.As(err, &badTransition) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Exhibitor invoice state set to '" + json.State + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with exhibitor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
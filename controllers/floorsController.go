@@ -72,10 +72,10 @@ func (g *GironService) CreateFloor(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/floor/{id} [delete]
 func (g *GironService) DeleteFloorById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, _ := strconv.Atoi(c.Param("id"))
-		status, err := model.DeleteFloorById(id)
+		status, err := model.DeleteFloorById(id, userObject.Id)
 		if err != nil {
 			log.Println("ERROR: Cannot delete floor: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove floor! " + string(err.Error())})
@@ -198,7 +198,7 @@ func (g *GironService) GetFloorById(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/floor/{id} [patch]
 func (g *GironService) UpdateFloorById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
@@ -212,7 +212,7 @@ func (g *GironService) UpdateFloorById(c *gin.Context) {
 		}
 
 		// we don't need the status, since the error speaks for itself
-		_, err = model.UpdateFloorById(id, json)
+		_, err = model.UpdateFloorById(id, json, userObject.Id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
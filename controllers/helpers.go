@@ -6,12 +6,21 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/JAFAX/giron-service/globals"
 	"github.com/JAFAX/giron-service/model"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
 func (g *GironService) GetUserId(c *gin.Context) (model.User, bool) {
+	// API tokens only reach routes that let them in; see middleware.AllowApiTokens
+	if _, viaToken := c.Get(globals.ApiTokenKey); viaToken {
+		if _, allowed := c.Get(globals.ApiTokenAllowedKey); !allowed {
+			log.Println("WARN: API token refused on " + c.Request.Method + " " + c.FullPath())
+			return model.User{}, false
+		}
+	}
+
 	// need to get our current user context to get the CreatorId
 	session := sessions.Default(c)
 	user := session.Get("user")
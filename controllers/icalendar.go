@@ -0,0 +1,219 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+const (
+	icalDateTime = "20060102T150405"
+	icalProdId   = "-//JAFAX//Giron-Service//EN"
+	// UIDs have to stay the same for the life of an event, so they are built
+	// from the event kind and Id rather than from anything that can change
+	icalUidDomain = "giron-service"
+)
+
+// icalWriter Builds an RFC 5545 document, one content line at a time
+type icalWriter struct {
+	b strings.Builder
+}
+
+// line Writes a content line, folding it so no physical line is longer than
+// 75 octets and never splitting a UTF-8 sequence
+func (w *icalWriter) line(name string, value string) {
+	l := name + ":" + value
+	for len(l) > 75 {
+		cut := 75
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		w.b.WriteString(l[:cut] + "\r\n")
+		l = " " + l[cut:]
+	}
+	w.b.WriteString(l + "\r\n")
+}
+
+func (w *icalWriter) String() string {
+	return w.b.String()
+}
+
+// icalText Escapes a TEXT property value
+func icalText(value string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return r.Replace(value)
+}
+
+// icalOffset Formats a UTC offset in seconds as +HHMM, or +HHMMSS when needed
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
+
+// writeTimezone Writes a VTIMEZONE covering every offset change from the one
+// in effect at the first event through the last event
+func writeTimezone(w *icalWriter, loc *time.Location, first time.Time, last time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+
+	start, _ := first.In(loc).ZoneBounds()
+	if start.IsZero() {
+		// the zone has always had the same offset
+		name, offset := first.In(loc).Zone()
+		w.line("BEGIN", "STANDARD")
+		w.line("DTSTART", "19700101T000000")
+		w.line("TZOFFSETFROM", icalOffset(offset))
+		w.line("TZOFFSETTO", icalOffset(offset))
+		w.line("TZNAME", icalText(name))
+		w.line("END", "STANDARD")
+		w.line("END", "VTIMEZONE")
+		return
+	}
+
+	for transition := start; !transition.IsZero() && !transition.After(last); {
+		_, offsetFrom := transition.Add(-time.Second).In(loc).Zone()
+		name, offsetTo := transition.In(loc).Zone()
+		component := "STANDARD"
+		if transition.In(loc).IsDST() {
+			component = "DAYLIGHT"
+		}
+
+		w.line("BEGIN", component)
+		// the onset is given in the local time that was in effect before it
+		w.line("DTSTART", transition.In(time.FixedZone("", offsetFrom)).Format(icalDateTime))
+		w.line("TZOFFSETFROM", icalOffset(offsetFrom))
+		w.line("TZOFFSETTO", icalOffset(offsetTo))
+		w.line("TZNAME", icalText(name))
+		w.line("END", component)
+
+		_, transition = transition.In(loc).ZoneBounds()
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// icalEventTime Formats a property carrying a date-time in the schedule's zone
+func icalEventTime(w *icalWriter, name string, t time.Time, loc *time.Location) {
+	if loc == time.UTC {
+		w.line(name, t.UTC().Format(icalDateTime)+"Z")
+	} else {
+		w.line(name+";TZID="+loc.String(), t.In(loc).Format(icalDateTime))
+	}
+}
+
+// renderCalendar Renders the given events as an iCalendar document. Scheduled
+// times are stored as wall clock times of the convention's time zone.
+func renderCalendar(events []model.CalendarEvent, loc *time.Location) string {
+	type scheduled struct {
+		event model.CalendarEvent
+		start time.Time
+	}
+
+	slots := make([]scheduled, 0, len(events))
+	for _, event := range events {
+		parsed, err := model.ParseScheduledTime(event.ScheduledTime)
+		if err != nil {
+			log.Println("WARN: Cannot parse scheduled time of " + event.EventType + " Id '" + strconv.Itoa(event.Id) + "': " + string(err.Error()))
+			continue
+		}
+		start := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, loc)
+		slots = append(slots, scheduled{event: event, start: start})
+	}
+
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", icalProdId)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+
+	if len(slots) > 0 && loc != time.UTC {
+		first, last := slots[0].start, slots[0].start
+		for _, slot := range slots {
+			end := slot.start.Add(time.Duration(slot.event.DurationInMinutes) * time.Minute)
+			if slot.start.Before(first) {
+				first = slot.start
+			}
+			if end.After(last) {
+				last = end
+			}
+		}
+		writeTimezone(w, loc, first, last)
+	}
+
+	for _, slot := range slots {
+		event := slot.event
+		end := slot.start.Add(time.Duration(event.DurationInMinutes) * time.Minute)
+
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", event.EventType+"-"+strconv.Itoa(event.Id)+"@"+icalUidDomain)
+		// stamped with the last change rather than the time of the request,
+		// so an unchanged feed renders the same and keeps its ETag
+		w.line("DTSTAMP", event.LastChanged.UTC().Format(icalDateTime)+"Z")
+		icalEventTime(w, "DTSTART", slot.start, loc)
+		icalEventTime(w, "DTEND", end, loc)
+		w.line("SEQUENCE", strconv.Itoa(event.Sequence))
+		w.line("SUMMARY", icalText(event.Title))
+		if event.Description != "" {
+			w.line("DESCRIPTION", icalText(event.Description))
+		}
+		place := make([]string, 0, 3)
+		for _, part := range []string{event.RoomName, event.FloorName, event.BuildingName} {
+			if part != "" {
+				place = append(place, icalText(part))
+			}
+		}
+		if len(place) > 0 {
+			w.line("LOCATION", strings.Join(place, `\, `))
+		}
+		if len(event.Tags) > 0 {
+			categories := make([]string, 0, len(event.Tags))
+			for _, tag := range event.Tags {
+				categories = append(categories, icalText(tag))
+			}
+			w.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.String()
+}
@@ -0,0 +1,714 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// liveEventFromSQL Flatten the nullable columns of a live event record and
+// nest the location it is held in, if any
+func liveEventFromSQL(event model.LiveEventSQL) model.LiveEvent {
+	eventEnt := model.LiveEvent{}
+	eventEnt.Id = event.Id
+	eventEnt.Topic = event.Topic
+	eventEnt.Description = event.Description
+	if event.LocationId.Valid {
+		eventEnt.LocationId = int(event.LocationId.Int64)
+		eventEnt.Location = &model.LocationDetail{
+			Id:           int(event.LocationId.Int64),
+			RoomName:     event.RoomName.String,
+			FloorId:      int(event.FloorId.Int64),
+			FloorName:    event.FloorName.String,
+			BuildingId:   int(event.BuildingId.Int64),
+			BuildingName: event.BuildingName.String,
+		}
+	}
+	eventEnt.ScheduledTime = event.ScheduledTime.String
+	eventEnt.DurationInMinutes = event.DurationInMinutes
+	eventEnt.Rating = event.Rating.Float64
+	eventEnt.AgeRestricted = event.AgeRestricted
+	eventEnt.CreatorId = event.CreatorId
+	eventEnt.CreationDateTime = event.CreationDateTime
+	eventEnt.ApprovalStatus = event.ApprovalStatus
+	eventEnt.ApprovedById = int(event.ApprovedById.Int64)
+	eventEnt.ApprovalDateTime = event.ApprovalDateTime.String
+
+	return eventEnt
+}
+
+// CreateLiveEvent Add a live event
+//
+//	@Summary		Create a new live event
+//	@Description	Create a new live event. New live events start out unapproved
+//	@Tags			liveEvents
+//	@Accept			json
+//	@Produce		json
+//	@Param			liveEvent	body	model.ProposedLiveEvent	true	"Live event data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/liveEvent [post]
+func (g *GironService) CreateLiveEvent(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedLiveEvent
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if json.Topic == "" || json.Description == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "topic and description are required"})
+			return
+		}
+
+		s, err := model.CreateLiveEvent(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateLiveEventById Update the topic and description of a live event
+//
+//	@Summary		Update a live event
+//	@Description	Update the topic and description of a live event
+//	@Tags			liveEvents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.LiveEventUpdate	true	"Live event data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id} [patch]
+func (g *GironService) UpdateLiveEventById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.LiveEventUpdate
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.Topic == "" || json.Description == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "topic and description are required"})
+			return
+		}
+
+		status, err := model.UpdateLiveEventById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteLiveEventById Delete a live event by its Id
+//
+//	@Summary		Delete a live event by Id
+//	@Description	Delete a live event by Id, along with its tag assignments and ratings
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id} [delete]
+func (g *GironService) DeleteLiveEventById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteLiveEventById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete live event: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove live event! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetLiveEvents Retrieve list of all live events
+//
+//	@Summary		Retrieve list of all live events
+//	@Description	Retrieve list of all live events, approved or not
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.LiveEventList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/liveEvents/all [get]
+func (g *GironService) GetLiveEvents(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		events, err := model.GetLiveEvents()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of live events: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		eventSlice := make([]model.LiveEvent, 0)
+		for _, event := range events {
+			eventSlice = append(eventSlice, liveEventFromSQL(event))
+		}
+
+		log.Println("INFO: Returned list of live events")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": eventSlice})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetApprovedLiveEvents Retrieve list of all approved live events
+//
+//	@Summary		Retrieve list of all approved live events
+//	@Description	Retrieve list of all approved live events. When one or more tag names are given, only live events carrying every one of those tags are returned
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			tag	query	[]string	false	"Tag name to filter by"	collectionFormat(multi)
+//	@Success		200	{object}	model.LiveEventList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/liveEvents [get]
+func (g *GironService) GetApprovedLiveEvents(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		events, err := model.GetApprovedLiveEvents()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of live events: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var taggedEvents map[int]bool
+		tagNames := c.QueryArray("tag")
+		if len(tagNames) > 0 {
+			taggedEvents, err = model.GetLiveEventIdsByTagNames(tagNames)
+			if err != nil {
+				log.Println("ERROR: Cannot retrieve live events by tag: " + string(err.Error()))
+				c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+				return
+			}
+		}
+
+		eventSlice := make([]model.LiveEvent, 0)
+		for _, event := range events {
+			if taggedEvents != nil && !taggedEvents[event.Id] {
+				continue
+			}
+			eventSlice = append(eventSlice, liveEventFromSQL(event))
+		}
+
+		log.Println("INFO: Returned approved list of live events")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": eventSlice})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetLiveEventById Retrieve live event by Id
+//
+//	@Summary		Retrieve live event by Id
+//	@Description	Retrieve live event by Id
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Success		200	{object}	model.LiveEvent
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id} [get]
+func (g *GironService) GetLiveEventById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		ent, err := model.GetLiveEventById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, liveEventFromSQL(ent))
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetLiveEventLocation Set live event location
+//
+//	@Summary		Set live event location
+//	@Description	Move a live event to another location. The move is refused if the live event is already scheduled and its slot conflicts with an event in the new location
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.LiveEventLocation	true	"Location data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
+//	@Router			/liveEvent/{id}/location [post]
+func (g *GironService) SetLiveEventLocation(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.LiveEventLocation
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.SetLiveEventLocation(id, json, userObject.Id)
+		if err != nil {
+			var noSuchLiveEvent *model.NoSuchLiveEvent
+			if errors.As(err, &noSuchLiveEvent) {
+				c.IndentedJSON(http.StatusNotFound, gin.H{"error": string(err.Error())})
+				return
+			}
+			var noLocation *model.NoSuchLocation
+			if errors.As(err, &noLocation) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error()), "conflicts": conflict.Conflicts})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event location updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetLiveEventScheduledTimeById Set the live event's scheduled time
+//
+//	@Summary		Set the scheduled time for a live event
+//	@Description	Set the location, scheduled time and, optionally, duration of a live event. The request is refused with the list of conflicting events if the slot overlaps, including the configured changeover buffer, with any panel, screening or live event in the same location
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.LiveEventScheduledTime	true	"Scheduled Time"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
+//	@Router			/liveEvent/{id}/schedule [post]
+func (g *GironService) SetLiveEventScheduledTimeById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.LiveEventScheduledTime
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, msg, err := model.SetLiveEventScheduledTimeById(id, json, userObject.Id)
+		if err != nil {
+			var noSuchLiveEvent *model.NoSuchLiveEvent
+			if errors.As(err, &noSuchLiveEvent) {
+				c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Live event cannot be scheduled. Reason: " + msg})
+				return
+			}
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": "Live event cannot be scheduled. Reason: " + msg, "conflicts": conflict.Conflicts})
+				return
+			}
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event scheduled for " + msg})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Live event cannot be scheduled. Reason: " + msg})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetLiveEventAgeRestrictionById Set the age restriction status of a live event
+//
+//	@Summary		Set live event age restriction
+//	@Description	Set live event age restriction
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.LiveEventAgeRestrictionState	true	"Age restriction state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/restricted [post]
+func (g *GironService) SetLiveEventAgeRestrictionById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.LiveEventAgeRestrictionState
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetLiveEventAgeRestrictionById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if !status {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		} else if json.RestrictionState {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event is age restricted"})
+		} else {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event is not age restricted"})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetApprovalStatusLiveEventById Set the approval status of a live event
+//
+//	@Summary		Set live event approval status
+//	@Description	Approve or unapprove a live event, recording who made the decision and when
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.LiveEventApproval	true	"Approval data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/approve [post]
+func (g *GironService) SetApprovalStatusLiveEventById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.LiveEventApproval
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetApprovalStatusLiveEventById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if !status {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		} else if json.State {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event approved"})
+		} else {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Live event unapproved"})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetLiveEventTagsByLiveEventId Retrieve the tags assigned to a live event
+//
+//	@Summary		Retrieve the tags assigned to a live event
+//	@Description	Retrieve the tags assigned to a live event
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.TagList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/tags [get]
+func (g *GironService) GetLiveEventTagsByLiveEventId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		tags, err := model.GetTagsByLiveEventId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": tags})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AssignTagToLiveEvent Assign a tag to a live event
+//
+//	@Summary		Assign a tag to a live event
+//	@Description	Assign a tag to a live event. Assigning a tag the live event already carries is a no-op
+//	@Tags			liveEvents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.TagAssignment	true	"Tag to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/assignTag [post]
+func (g *GironService) AssignTagToLiveEvent(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.TagAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.AssignTagToLiveEvent(id, json, userObject.Id)
+		if err != nil {
+			var noTag *model.NoSuchTag
+			if errors.As(err, &noTag) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag assigned to live event"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignTagFromLiveEvent Remove a tag from a live event
+//
+//	@Summary		Remove a tag from a live event
+//	@Description	Remove a tag from a live event
+//	@Tags			liveEvents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.TagAssignment	true	"Tag to unassign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/unassignTag [patch]
+func (g *GironService) UnassignTagFromLiveEvent(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.TagAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.UnassignTagFromLiveEvent(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag unassigned from live event"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "tag id " + strconv.Itoa(json.TagId) + " is not assigned to live event id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetLiveEventRating Retrieve the average rating of a live event
+//
+//	@Summary		Retrieve the rating of a live event
+//	@Description	Retrieve the average rating of a live event and how many ratings it is based on
+//	@Tags			liveEvents
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.EventRating
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/rating [get]
+func (g *GironService) GetLiveEventRating(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		rating, err := model.GetLiveEventRating(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if rating.EventId == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, rating)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// RateLiveEvent Submit a rating for a live event
+//
+//	@Summary		Rate a live event
+//	@Description	Rate a live event from 1 to 5. Each user has one rating per live event; rating again replaces the earlier rating
+//	@Tags			liveEvents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Live event Id"
+//	@Param			json	body	model.RatingSubmission	true	"Rating"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/liveEvent/{id}/rating [post]
+func (g *GironService) RateLiveEvent(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.RatingSubmission
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.RateLiveEvent(id, userObject.Id, json)
+		if err != nil {
+			var invalid *model.InvalidRating
+			if errors.As(err, &invalid) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Rating recorded for live event"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with live event id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
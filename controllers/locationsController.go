@@ -72,10 +72,10 @@ func (g *GironService) CreateLocation(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/location/{id} [delete]
 func (g *GironService) DeleteLocationById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, _ := strconv.Atoi(c.Param("id"))
-		status, err := model.DeleteLocationById(id)
+		status, err := model.DeleteLocationById(id, userObject.Id)
 		if err != nil {
 			log.Println("ERROR: Cannot delete location: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove Location! " + string(err.Error())})
@@ -226,7 +226,7 @@ func (g *GironService) GetLocationsByBuildingId(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/location/{id} [patch]
 func (g *GironService) UpdateLocationById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
@@ -240,7 +240,7 @@ func (g *GironService) UpdateLocationById(c *gin.Context) {
 		}
 
 		// we don't need the status, since the error speaks for itself
-		_, err = model.UpdateLocationById(id, json)
+		_, err = model.UpdateLocationById(id, json, userObject.Id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
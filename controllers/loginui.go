@@ -19,15 +19,25 @@ package controllers
 */
 
 import (
+	"errors"
+	"fmt"
+	"html/template"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/JAFAX/giron-service/globals"
 	"github.com/JAFAX/giron-service/helpers"
+	"github.com/JAFAX/giron-service/middleware"
+	"github.com/JAFAX/giron-service/model"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
+// pendingLoginTimeout How long after giving their password a user has to
+// give their second factor
+const pendingLoginTimeout = 5 * time.Minute
+
 func (g *GironService) LoginUI(c *gin.Context) {
 	log.Println("INFO: Displaying the login UI")
 	session := sessions.Default(c)
@@ -64,12 +74,53 @@ func (g *GironService) LoginUIPost(c *gin.Context) {
 		return
 	}
 
-	if !helpers.CheckUserPass(username, password) {
+	authStatus, retryAfter := helpers.CheckLogin(username, password, c.ClientIP())
+	if retryAfter > 0 {
+		wait := helpers.RetryAfterSeconds(retryAfter)
+		log.Println("ERROR: Too many failed logins! Please wait")
+		c.Header("Retry-After", wait)
+		c.HTML(http.StatusTooManyRequests, "login.html", gin.H{"content": "Too many failed logins. Try again in " + wait + " seconds"})
+		return
+	}
+	if !authStatus {
 		log.Println("ERROR: Invalid username or password! Please login")
 		c.HTML(http.StatusUnauthorized, "login.html", gin.H{"content": "Incorrect username or password"})
 		return
 	}
 
+	userObject, err := model.GetUserByUserName(username)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to load user"})
+		return
+	}
+	status, err := model.GetTwoFactorStatus(userObject.Id)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to check two-factor authentication"})
+		return
+	}
+	if status.Enabled || status.Required {
+		middleware.RenewSession(session)
+		session.Set(globals.PendingUserKey, username)
+		session.Set(globals.PendingSinceKey, time.Now().Unix())
+		if err := session.Save(); err != nil {
+			log.Println("ERROR: Cannot save session: error: " + string(err.Error()))
+			c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to save session"})
+			return
+		}
+		log.Println("INFO: Password accepted. Asking for a second factor")
+		secondFactorUI(c, http.StatusOK, userObject, status, "")
+		return
+	}
+
+	completeLogin(c, username)
+}
+
+// completeLogin Signs the user in and sends them on to the admin panel
+func completeLogin(c *gin.Context, username string) {
+	session := sessions.Default(c)
+	middleware.RenewSession(session)
+	session.Delete(globals.PendingUserKey)
+	session.Delete(globals.PendingSinceKey)
 	session.Set(globals.UserKey, username)
 	if err := session.Save(); err != nil {
 		log.Println("ERROR: Cannot save session: error: " + string(err.Error()))
@@ -80,3 +131,109 @@ func (g *GironService) LoginUIPost(c *gin.Context) {
 	log.Println("INFO: Login succeeded. Redirecting to /admin")
 	c.Redirect(http.StatusMovedPermanently, "/admin")
 }
+
+// secondFactorUI Asks for a code, or, for a user who has to set up two-factor
+// authentication, shows the secret to add to their app first
+func secondFactorUI(c *gin.Context, code int, user model.User, status model.TwoFactorStatus, message string) {
+	if status.Enabled {
+		if message == "" {
+			message = "Enter the code from your authenticator app, or one of your recovery codes"
+		}
+		c.HTML(code, "twofactor.html", gin.H{"content": message, "verify": true})
+		return
+	}
+
+	enrollment, err := model.BeginTwoFactorEnrollment(user.Id, user.UserName)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to set up two-factor authentication"})
+		return
+	}
+	if message == "" {
+		message = "Your account requires two-factor authentication. Add this account to your authenticator app, then enter the code it shows"
+	}
+	c.HTML(code, "twofactor.html", gin.H{
+		"content": message,
+		"enroll":  true,
+		"secret":  enrollment.Secret,
+		"uri":     template.URL(enrollment.Uri),
+	})
+}
+
+// LoginTwoFactorPost Finishes a login with the second factor, or with the
+// first code of a new enrollment
+func (g *GironService) LoginTwoFactorPost(c *gin.Context) {
+	log.Println("INFO: Requesting Login second factor action POST")
+	session := sessions.Default(c)
+	pending := session.Get(globals.PendingUserKey)
+	since, _ := session.Get(globals.PendingSinceKey).(int64)
+	if pending == nil || time.Since(time.Unix(since, 0)) > pendingLoginTimeout {
+		log.Println("ERROR: No pending login, or it has expired")
+		session.Delete(globals.PendingUserKey)
+		session.Delete(globals.PendingSinceKey)
+		session.Save()
+		c.HTML(http.StatusUnauthorized, "login.html", gin.H{"content": "Your login has expired, please sign in again"})
+		return
+	}
+
+	username := fmt.Sprintf("%v", pending)
+	userObject, err := model.GetUserByUserName(username)
+	if err != nil || userObject.UserName == "" {
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to load user"})
+		return
+	}
+	status, err := model.GetTwoFactorStatus(userObject.Id)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to check two-factor authentication"})
+		return
+	}
+	code := c.PostForm("code")
+
+	if status.Enabled {
+		valid, retryAfter := helpers.CheckSecondFactor(userObject, code, c.ClientIP())
+		if retryAfter > 0 {
+			wait := helpers.RetryAfterSeconds(retryAfter)
+			c.Header("Retry-After", wait)
+			secondFactorUI(c, http.StatusTooManyRequests, userObject, status, "Too many failed logins. Try again in "+wait+" seconds")
+			return
+		}
+		if !valid {
+			log.Println("ERROR: Invalid two-factor code! Please try again")
+			secondFactorUI(c, http.StatusUnauthorized, userObject, status, "Incorrect code")
+			return
+		}
+		completeLogin(c, username)
+		return
+	}
+
+	codes, retryAfter, err := helpers.ConfirmTwoFactorEnrollment(userObject, code, c.ClientIP())
+	if retryAfter > 0 {
+		wait := helpers.RetryAfterSeconds(retryAfter)
+		c.Header("Retry-After", wait)
+		secondFactorUI(c, http.StatusTooManyRequests, userObject, status, "Too many failed codes. Try again in "+wait+" seconds")
+		return
+	}
+	if err != nil {
+		var invalidCode *model.InvalidTwoFactorCode
+		if errors.As(err, &invalidCode) {
+			secondFactorUI(c, http.StatusBadRequest, userObject, status, "Incorrect code. Make sure the clock of your device is correct")
+			return
+		}
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to set up two-factor authentication"})
+		return
+	}
+
+	middleware.RenewSession(session)
+	session.Delete(globals.PendingUserKey)
+	session.Delete(globals.PendingSinceKey)
+	session.Set(globals.UserKey, username)
+	if err := session.Save(); err != nil {
+		log.Println("ERROR: Cannot save session: error: " + string(err.Error()))
+		c.HTML(http.StatusInternalServerError, "login.html", gin.H{"content": "Failed to save session"})
+		return
+	}
+	log.Println("INFO: Two-factor authentication set up. Showing recovery codes")
+	c.HTML(http.StatusOK, "twofactor.html", gin.H{
+		"content": "Two-factor authentication is set up. Keep these recovery codes somewhere safe. Each one can be used once in place of a code if you lose your device",
+		"codes":   codes,
+	})
+}
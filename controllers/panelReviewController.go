@@ -0,0 +1,175 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// SetPanelStateById Move a panel along its review lifecycle
+//
+//	@Summary		Set the review state of a panel
+//	@Description	Move a panel to another review state, optionally with a comment. Panels go from submitted, through under_review, to accepted, rejected or waitlisted, then from accepted to scheduled, and may be cancelled. A panel can only be marked scheduled once it has a slot
+//	@Tags			panels
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.PanelStateChange	true	"New state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/panel/{id}/state [post]
+func (g *GironService) SetPanelStateById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.PanelStateChange
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if !model.IsPanelState(json.State) {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unknown panel state '" + json.State + "'"})
+			return
+		}
+
+		status, err := model.SetPanelStateById(id, json, userObject.Id)
+		if err != nil {
+			var badTransition *model.InvalidPanelStateTransition
+			if errors.As(err, &badTransition) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel state set to '" + json.State + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AddPanelReviewComment Comment on a panel under review
+//
+//	@Summary		Add a reviewer comment to a panel
+//	@Description	Add a comment to the review log of a panel without changing its state
+//	@Tags			panels
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.PanelReviewComment	true	"Comment"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/comment [post]
+func (g *GironService) AddPanelReviewComment(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.PanelReviewComment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if strings.TrimSpace(json.Comment) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "comment is required"})
+			return
+		}
+
+		status, err := model.AddPanelReviewComment(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Comment added to panel Id '" + strconv.Itoa(id) + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetPanelReviewLog Retrieve the review history of a panel
+//
+//	@Summary		Retrieve the review log of a panel
+//	@Description	Retrieve the state changes and reviewer comments of a panel, oldest first, each with who made it and when
+//	@Tags			panels
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.PanelReviewLog
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/review [get]
+func (g *GironService) GetPanelReviewLog(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		panel, err := model.GetPanelById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+		if panel.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+			return
+		}
+
+		entries, err := model.GetPanelReviewLog(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": entries})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,198 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPanelistsByPanelId Retrieve the panelists of a panel
+//
+//	@Summary		Retrieve the panelists of a panel
+//	@Description	Retrieve the panelists of a panel
+//	@Tags			panels
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.PanelistList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/panel/{id}/panelists [get]
+func (g *GironService) GetPanelistsByPanelId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		panelists, err := model.GetPanelistsByPanelId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": panelists})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AddPanelistToPanel Add a panelist to a panel
+//
+//	@Summary		Add a panelist to a panel
+//	@Description	Add a panelist to a panel. Panelists are identified across panels by email address, and cannot be added to a scheduled panel if they are on another panel at the same time
+//	@Tags			panels
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.ProposedPanelist	true	"Panelist data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
+//	@Router			/panel/{id}/panelist [post]
+func (g *GironService) AddPanelistToPanel(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.ProposedPanelist
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if strings.TrimSpace(json.Name) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "panelist name is required"})
+			return
+		}
+		if _, err := mail.ParseAddress(json.EmailAddress); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid email address: " + string(err.Error())})
+			return
+		}
+
+		status, err := model.AddPanelistToPanel(id, json, userObject.Id)
+		if err != nil {
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": "Panelist is booked on another panel at the same time", "conflicts": conflict.Conflicts})
+				return
+			}
+			log.Println("ERROR: Cannot add panelist: " + string(err.Error()))
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Panelist added to panel"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// RemovePanelistFromPanel Remove a panelist from a panel
+//
+//	@Summary		Remove a panelist from a panel
+//	@Description	Remove a panelist from a panel
+//	@Tags			panels
+//	@Produce		json
+//	@Param			id			path	string	true	"Panel Id"
+//	@Param			panelistId	path	string	true	"Panelist Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/panelist/{panelistId} [delete]
+func (g *GironService) RemovePanelistFromPanel(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		panelistId, err := strconv.Atoi(c.Param("panelistId"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.RemovePanelistFromPanel(id, panelistId, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Panelist removed from panel"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "panelist id " + strconv.Itoa(panelistId) + " is not on panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetPanelsByPanelistEmail Retrieve the panels a panelist is on
+//
+//	@Summary		Retrieve the panels a panelist is on
+//	@Description	Retrieve every panel, approved or not, that the panelist with the given email address is on
+//	@Tags			panels
+//	@Produce		json
+//	@Param			email	path	string	true	"Panelist email address"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.PanelList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/panels/ByPanelistEmail/{email} [get]
+func (g *GironService) GetPanelsByPanelistEmail(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		panels, err := model.GetPanelsByPanelistEmail(c.Param("email"))
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of panels: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		panelSlice := make([]model.Panel, 0)
+		for _, panel := range panels {
+			panelSlice = append(panelSlice, panelFromSQL(panel))
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": panelSlice})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
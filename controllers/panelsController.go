@@ -19,6 +19,7 @@ package controllers
 */
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -27,6 +28,51 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// panelFromSQL Flatten the nullable columns of a panel record and nest the
+// location it is held in, if any
+func panelFromSQL(panel model.PanelSQL) model.Panel {
+	panelEnt := model.Panel{}
+	panelEnt.Id = panel.Id
+	panelEnt.Topic = panel.Topic
+	panelEnt.Description = panel.Description
+	panelEnt.PanelRequestorEmail = panel.PanelRequestorEmail
+	if panel.LocationId.Valid {
+		panelEnt.LocationId = int(panel.LocationId.Int64)
+		panelEnt.Location = &model.LocationDetail{
+			Id:           int(panel.LocationId.Int64),
+			RoomName:     panel.RoomName.String,
+			FloorId:      int(panel.FloorId.Int64),
+			FloorName:    panel.FloorName.String,
+			BuildingId:   int(panel.BuildingId.Int64),
+			BuildingName: panel.BuildingName.String,
+		}
+	}
+	if panel.ScheduledTime.Valid {
+		panelEnt.ScheduledTime = panel.ScheduledTime.String
+	} else {
+		panelEnt.ScheduledTime = ""
+	}
+	panelEnt.DurationInMinutes = panel.DurationInMinutes
+	panelEnt.Rating = panel.Rating
+	panelEnt.AgeRestricted = panel.AgeRestricted
+	panelEnt.CreatorId = panel.CreatorId
+	panelEnt.CreationDateTime = panel.CreationDateTime
+	panelEnt.ApprovalStatus = panel.ApprovalStatus
+	if panel.ApprovedById.Valid {
+		panelEnt.ApprovedById = int(panel.ApprovedById.Int64)
+	} else {
+		panelEnt.ApprovedById = 0
+	}
+	if panel.ApprovalDateTime.Valid {
+		panelEnt.ApprovalDateTime = panel.ApprovalDateTime.String
+	} else {
+		panelEnt.ApprovalDateTime = ""
+	}
+	panelEnt.State = panel.State
+
+	return panelEnt
+}
+
 // CreatePanel Add a panel event
 //
 //	@Summary		Create a new panel event
@@ -72,11 +118,11 @@ func (g *GironService) CreatePanel(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/panel/{id} [delete]
 func (g *GironService) DeletePanelById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, _ := strconv.Atoi(c.Param("id"))
 
-		status, err := model.DeletePanelById(id)
+		status, err := model.DeletePanelById(id, userObject.Id)
 		if err != nil {
 			log.Println("ERROR: Cannot delete panel: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove Panel! " + string(err.Error())})
@@ -98,9 +144,10 @@ func (g *GironService) DeletePanelById(c *gin.Context) {
 // GetPanels Retrieve list of all panels
 //
 //	@Summary		Retrieve list of all panels
-//	@Description	Retrieve list of all panels
+//	@Description	Retrieve list of all panels. When one or more states are given, only panels currently in one of those states are returned
 //	@Tags			panels
 //	@Produce		json
+//	@Param			state	query	[]string	false	"Review state to filter by"	collectionFormat(multi)	Enums(submitted, under_review, accepted, rejected, waitlisted, scheduled, cancelled)
 //	@Security		BasicAuth
 //	@Success		200	{object}	model.PanelList
 //	@Failure		400	{object}	model.FailureMsg
@@ -108,7 +155,20 @@ func (g *GironService) DeletePanelById(c *gin.Context) {
 func (g *GironService) GetPanels(c *gin.Context) {
 	_, authed := g.GetUserId(c)
 	if authed {
-		panels, err := model.GetPanels()
+		var panels []model.PanelSQL
+		var err error
+		states := c.QueryArray("state")
+		if len(states) > 0 {
+			for _, state := range states {
+				if !model.IsPanelState(state) {
+					c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unknown panel state '" + state + "'"})
+					return
+				}
+			}
+			panels, err = model.GetPanelsByState(states)
+		} else {
+			panels, err = model.GetPanels()
+		}
 		if err != nil {
 			log.Println("ERROR: Cannot retrieve list of panels: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err})
@@ -117,34 +177,7 @@ func (g *GironService) GetPanels(c *gin.Context) {
 
 		panelSlice := make([]model.Panel, 0)
 		for _, panel := range panels {
-			panelEnt := model.Panel{}
-			panelEnt.Id = panel.Id
-			panelEnt.Topic = panel.Topic
-			panelEnt.Description = panel.Description
-			panelEnt.PanelRequestorEmail = panel.PanelRequestorEmail
-			panelEnt.Location = panel.Location
-			if panel.ScheduledTime.Valid {
-				panelEnt.ScheduledTime = panel.ScheduledTime.String
-			} else {
-				panelEnt.ScheduledTime = ""
-			}
-			panelEnt.DurationInMinutes = panel.DurationInMinutes
-			panelEnt.AgeRestricted = panel.AgeRestricted
-			panelEnt.CreatorId = panel.CreatorId
-			panelEnt.CreationDateTime = panel.CreationDateTime
-			panelEnt.ApprovalStatus = panel.ApprovalStatus
-			if panel.ApprovedById.Valid {
-				panelEnt.ApprovedById = int(panel.ApprovedById.Int64)
-			} else {
-				panelEnt.ApprovedById = 0
-			}
-			if panel.ApprovalDateTime.Valid {
-				panelEnt.ApprovalDateTime = panel.ApprovalDateTime.String
-			} else {
-				panelEnt.ApprovalDateTime = ""
-			}
-
-			panelSlice = append(panelSlice, panelEnt)
+			panelSlice = append(panelSlice, panelFromSQL(panel))
 		}
 
 		if panels == nil {
@@ -162,53 +195,41 @@ func (g *GironService) GetPanels(c *gin.Context) {
 // GetApprovedPanels Retrieve list of all approved panels
 //
 //	@Summary		Retrieve list of all approved panels
-//	@Description	Retrieve list of all approved panels
+//	@Description	Retrieve list of all approved panels, that is those accepted or scheduled. When one or more tag names are given, only panels carrying every one of those tags are returned
 //	@Tags			panels
 //	@Produce		json
+//	@Param			tag	query	[]string	false	"Tag name to filter by"	collectionFormat(multi)
 //	@Success		200	{object}	model.PanelList
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/panels [get]
 func (g *GironService) GetApprovedPanels(c *gin.Context) {
 	_, authed := g.GetUserId(c)
 	if authed {
-		panels, err := model.GetPanels()
+		panels, err := model.GetPanelsByState([]string{model.PanelAccepted, model.PanelScheduled})
 		if err != nil {
 			log.Println("ERROR: Cannot retrieve list of panels: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err})
 			return
 		}
 
+		// optional track filtering, e.g. ?tag=cosplay&tag=18plus
+		var taggedPanels map[int]bool
+		tagNames := c.QueryArray("tag")
+		if len(tagNames) > 0 {
+			taggedPanels, err = model.GetPanelIdsByTagNames(tagNames)
+			if err != nil {
+				log.Println("ERROR: Cannot retrieve panels by tag: " + string(err.Error()))
+				c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+				return
+			}
+		}
+
 		panelSlice := make([]model.Panel, 0)
 		for _, panel := range panels {
-			if panel.ApprovalStatus {
-				panelEnt := model.Panel{}
-				panelEnt.Id = panel.Id
-				panelEnt.Topic = panel.Topic
-				panelEnt.Description = panel.Description
-				panelEnt.PanelRequestorEmail = panel.PanelRequestorEmail
-				panelEnt.Location = panel.Location
-				if panel.ScheduledTime.Valid {
-					panelEnt.ScheduledTime = panel.ScheduledTime.String
-				} else {
-					panelEnt.ScheduledTime = ""
-				}
-				panelEnt.DurationInMinutes = panel.DurationInMinutes
-				panelEnt.CreatorId = panel.CreatorId
-				panelEnt.CreationDateTime = panel.CreationDateTime
-				panelEnt.ApprovalStatus = panel.ApprovalStatus
-				if panel.ApprovedById.Valid {
-					panelEnt.ApprovedById = int(panel.ApprovedById.Int64)
-				} else {
-					panelEnt.ApprovedById = 0
-				}
-				if panel.ApprovalDateTime.Valid {
-					panelEnt.ApprovalDateTime = panel.ApprovalDateTime.String
-				} else {
-					panelEnt.ApprovalDateTime = ""
-				}
-
-				panelSlice = append(panelSlice, panelEnt)
+			if taggedPanels != nil && !taggedPanels[panel.Id] {
+				continue
 			}
+			panelSlice = append(panelSlice, panelFromSQL(panel))
 		}
 
 		if panels == nil {
@@ -250,34 +271,7 @@ func (g *GironService) GetPanelsByLocationId(c *gin.Context) {
 		panelSlice := make([]model.Panel, 0)
 		for _, panel := range panels {
 			if panel.ApprovalStatus {
-				panelEnt := model.Panel{}
-				panelEnt.Id = panel.Id
-				panelEnt.Topic = panel.Topic
-				panelEnt.Description = panel.Description
-				panelEnt.PanelRequestorEmail = panel.PanelRequestorEmail
-				panelEnt.Location = panel.Location
-				if panel.ScheduledTime.Valid {
-					panelEnt.ScheduledTime = panel.ScheduledTime.String
-				} else {
-					panelEnt.ScheduledTime = ""
-				}
-				panelEnt.DurationInMinutes = panel.DurationInMinutes
-				panelEnt.AgeRestricted = panel.AgeRestricted
-				panelEnt.CreatorId = panel.CreatorId
-				panelEnt.CreationDateTime = panel.CreationDateTime
-				panelEnt.ApprovalStatus = panel.ApprovalStatus
-				if panel.ApprovedById.Valid {
-					panelEnt.ApprovedById = int(panel.ApprovedById.Int64)
-				} else {
-					panelEnt.ApprovedById = 0
-				}
-				if panel.ApprovalDateTime.Valid {
-					panelEnt.ApprovalDateTime = panel.ApprovalDateTime.String
-				} else {
-					panelEnt.ApprovalDateTime = ""
-				}
-
-				panelSlice = append(panelSlice, panelEnt)
+				panelSlice = append(panelSlice, panelFromSQL(panel))
 			}
 		}
 
@@ -317,7 +311,7 @@ func (g *GironService) GetPanelById(c *gin.Context) {
 			strId := strconv.Itoa(id)
 			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "no records found with panel id " + strId})
 		} else {
-			c.IndentedJSON(http.StatusOK, ent)
+			c.IndentedJSON(http.StatusOK, panelFromSQL(ent))
 		}
 	} else {
 		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
@@ -331,20 +325,28 @@ func (g *GironService) GetPanelById(c *gin.Context) {
 //	@Tags			panels
 //	@Produce		json
 //	@Param			id	path	string	true	"Panel Id"
-//	@Success		200	{object}	model.Location
+//	@Success		200	{object}	model.LocationDetail
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/panel/{id}/location [get]
 func (g *GironService) GetPanelLocationByPanelId(c *gin.Context) {
 	_, authed := g.GetUserId(c)
 	if authed {
-		id, _ := strconv.Atoi(c.Param("id"))
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
 		ent, err := model.GetPanelLocationByPanelId(id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
 		}
 
-		c.IndentedJSON(http.StatusOK, ent)
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no location assigned to panel id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
 	} else {
 		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
 	}
@@ -382,33 +384,54 @@ func (g *GironService) GetPanelScheduleByPanelId(c *gin.Context) {
 //	@Description	Set panel location
 //	@Tags			panels
 //	@Produce		json
-//	@Param			id	path	string	true	"Building Id"
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.PanelLocation	true	"Location data"
 //	@Security		BasicAuth
 //	@Success		200	{object}	model.SuccessMsg
 //	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
 //	@Router			/panel/{id}/location [post]
 func (g *GironService) SetPanelLocation(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
 			return
 		}
-		var json model.Location
+		var json model.PanelLocation
 		if err := c.ShouldBindJSON(&json); err != nil {
 			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		// we don't need the status, since the error speaks for itself
-		_, err = model.SetPanelLocation(id, json)
+		status, err := model.SetPanelLocation(id, json, userObject.Id)
 		if err != nil {
+			var noSuchPanel *model.NoSuchPanel
+			if errors.As(err, &noSuchPanel) {
+				c.IndentedJSON(http.StatusNotFound, gin.H{"error": string(err.Error())})
+				return
+			}
+			var noLocation *model.NoSuchLocation
+			if errors.As(err, &noLocation) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error()), "conflicts": conflict.Conflicts})
+				return
+			}
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
 		}
 
-		c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel location updated"})
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel location updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		}
 	} else {
 		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
 	}
@@ -417,17 +440,19 @@ func (g *GironService) SetPanelLocation(c *gin.Context) {
 // SetPanelScheduledTimeById Set the panel's scheduled time
 //
 //	@Summary		Set the scheduled time for a panel
-//	@Description	Set the scheduled time for a panel
+//	@Description	Set the location, scheduled time and, optionally, duration of a panel. Only accepted panels can be given a slot, and doing so marks them scheduled. The request is refused with the list of conflicting events if the slot overlaps, including the configured changeover buffer, with any panel, screening or live event in the same location
 //	@Tags			panels
 //	@Produce		json
 //	@Param			id	path	string	true	"Panel Id"
 //	@Param			json	body	model.PanelScheduledTime	true	"Scheduled Time"
 //	@Security		BasicAuth
 //	@Success		200	{object}	model.SuccessMsg
-//	@Failure		400 {object}	model.FailureMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
 //	@Router			/panel/{id}/schedule [post]
 func (g *GironService) SetPanelScheduledTimeById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
@@ -441,8 +466,23 @@ func (g *GironService) SetPanelScheduledTimeById(c *gin.Context) {
 			return
 		}
 
-		status, msg, err := model.SetPanelScheduledTimeById(id, json)
+		status, msg, err := model.SetPanelScheduledTimeById(id, json, userObject.Id)
 		if err != nil {
+			var noSuchPanel *model.NoSuchPanel
+			if errors.As(err, &noSuchPanel) {
+				c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Panel cannot be scheduled. Reason: " + msg})
+				return
+			}
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": "Panel cannot be scheduled. Reason: " + msg, "conflicts": conflict.Conflicts})
+				return
+			}
+			var badTransition *model.InvalidPanelStateTransition
+			if errors.As(err, &badTransition) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": "Panel cannot be scheduled. Reason: " + msg})
+				return
+			}
 			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
 			return
 		}
@@ -450,7 +490,7 @@ func (g *GironService) SetPanelScheduledTimeById(c *gin.Context) {
 		if status {
 			c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel scheduled for " + msg})
 		} else {
-			c.IndentedJSON(http.StatusConflict, gin.H{"message": "Panel cannot be scheduled. Reason " + msg})
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Panel cannot be scheduled. Reason: " + msg})
 		}
 	} else {
 		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
@@ -470,7 +510,7 @@ func (g *GironService) SetPanelScheduledTimeById(c *gin.Context) {
 //		@Failure		400	{object}	model.FailureMsg
 //		@Router			/panel/{id}/restricted [post]
 func (g *GironService) SetPanelAgeRestrictionById(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
@@ -484,7 +524,7 @@ func (g *GironService) SetPanelAgeRestrictionById(c *gin.Context) {
 			return
 		}
 
-		status, err := model.SetPanelAgeRestrictionById(id, json)
+		status, err := model.SetPanelAgeRestrictionById(id, json, userObject.Id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
@@ -506,8 +546,8 @@ func (g *GironService) SetPanelAgeRestrictionById(c *gin.Context) {
 
 // SetApprovalStatusPanelById Set the approval status of a panel
 //
-//	@Summary		Set panel location
-//	@Description	Set panel location
+//	@Summary		Approve or reject a panel
+//	@Description	Accept a panel, or reject it when state is false. Prefer /panel/{id}/state, which covers the whole review lifecycle
 //	@Tags			panels
 //	@Produce		json
 //	@Param			id	path	string	true	"Panel Id"
@@ -515,6 +555,8 @@ func (g *GironService) SetPanelAgeRestrictionById(c *gin.Context) {
 //	@Security		BasicAuth
 //	@Success		200	{object}	model.SuccessMsg
 //	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
 //	@Router			/panel/{id}/approve [post]
 func (g *GironService) SetApprovalStatusPanelById(c *gin.Context) {
 	userObject, authed := g.GetUserId(c)
@@ -533,6 +575,11 @@ func (g *GironService) SetApprovalStatusPanelById(c *gin.Context) {
 
 		status, err := model.SetApprovalStatusPanelById(id, json, userObject.Id)
 		if err != nil {
+			var badTransition *model.InvalidPanelStateTransition
+			if errors.As(err, &badTransition) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
 		}
@@ -541,10 +588,223 @@ func (g *GironService) SetApprovalStatusPanelById(c *gin.Context) {
 			if json.State {
 				c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel approved"})
 			} else {
-				c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel unapproved"})
+				c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel rejected"})
+			}
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetPanelTagsByPanelId Retrieve the tags assigned to a panel
+//
+//	@Summary		Retrieve the tags assigned to a panel
+//	@Description	Retrieve the tags assigned to a panel
+//	@Tags			panels
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.TagList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/panel/{id}/tags [get]
+func (g *GironService) GetPanelTagsByPanelId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		tags, err := model.GetTagsByPanelId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": tags})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AssignTagToPanel Assign a tag to a panel
+//
+//	@Summary		Assign a tag to a panel
+//	@Description	Assign a tag to a panel. Assigning a tag the panel already carries is a no-op
+//	@Tags			panels
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.TagAssignment	true	"Tag to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/assignTag [post]
+func (g *GironService) AssignTagToPanel(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.TagAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.AssignTagToPanel(id, json, userObject.Id)
+		if err != nil {
+			var noTag *model.NoSuchTag
+			if errors.As(err, &noTag) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
 			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag assigned to panel"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignTagFromPanel Remove a tag from a panel
+//
+//	@Summary		Remove a tag from a panel
+//	@Description	Remove a tag from a panel
+//	@Tags			panels
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.TagAssignment	true	"Tag to unassign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/unassignTag [patch]
+func (g *GironService) UnassignTagFromPanel(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.TagAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.UnassignTagFromPanel(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag unassigned from panel"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "tag id " + strconv.Itoa(json.TagId) + " is not assigned to panel id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetPanelRating Retrieve the average rating of a panel
+//
+//	@Summary		Retrieve the rating of a panel
+//	@Description	Retrieve the average rating of a panel and how many ratings it is based on
+//	@Tags			panels
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.EventRating
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/rating [get]
+func (g *GironService) GetPanelRating(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		rating, err := model.GetPanelRating(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if rating.EventId == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, rating)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// RatePanel Submit a rating for a panel
+//
+//	@Summary		Rate a panel
+//	@Description	Rate a panel from 1 to 5. Each user has one rating per panel; rating again replaces the earlier rating
+//	@Tags			panels
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Param			json	body	model.RatingSubmission	true	"Rating"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/rating [post]
+func (g *GironService) RatePanel(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.RatingSubmission
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.RatePanel(id, userObject.Id, json)
+		if err != nil {
+			var invalid *model.InvalidRating
+			if errors.As(err, &invalid) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Rating recorded for panel"})
 		} else {
-			c.IndentedJSON(http.StatusOK, gin.H{"message": "Panel not approved"})
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
 		}
 	} else {
 		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
@@ -0,0 +1,188 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+const proposalReceivedMsg = "Thank you! Check your email for a link to confirm your proposal."
+
+const proposalsUnavailableMsg = "Panel proposals are not open at the moment."
+
+// proposalsEnabled Reports whether proposals are taken. The emailed links need
+// publicUrl, as they must never be built from the request's Host header,
+// which whoever sends the request controls.
+func (g *GironService) proposalsEnabled() bool {
+	return g.ConfStruct.PublicUrl != ""
+}
+
+// proposalVerifyUrl The start of the link a proposer follows to verify their
+// email address; the token goes at the end
+func (g *GironService) proposalVerifyUrl() string {
+	return strings.TrimRight(g.ConfStruct.PublicUrl, "/") + "/propose/verify?token="
+}
+
+// submitProposal Checks and stores a public panel proposal, returning the
+// status and message to answer with. Submissions that fill in the honeypot
+// field get the usual answer but are dropped.
+func (g *GironService) submitProposal(c *gin.Context, p model.PanelProposalSubmission) (int, string) {
+	if !g.proposalsEnabled() {
+		return http.StatusServiceUnavailable, proposalsUnavailableMsg
+	}
+	if p.Website != "" {
+		log.Println("WARN: Dropping panel proposal from " + c.ClientIP() + ": honeypot field filled in")
+		return http.StatusAccepted, proposalReceivedMsg
+	}
+	if strings.TrimSpace(p.Topic) == "" || strings.TrimSpace(p.Description) == "" || strings.TrimSpace(p.Name) == "" {
+		return http.StatusBadRequest, "topic, description and name are required"
+	}
+	if _, err := mail.ParseAddress(p.EmailAddress); err != nil {
+		return http.StatusBadRequest, "invalid email address: " + string(err.Error())
+	}
+
+	_, err := model.CreatePanelProposal(p, c.ClientIP(), g.proposalVerifyUrl())
+	if err != nil {
+		var tooMany *model.TooManyRequests
+		if errors.As(err, &tooMany) {
+			return http.StatusTooManyRequests, string(err.Error())
+		}
+		return http.StatusInternalServerError, "Unable to store proposal"
+	}
+
+	return http.StatusAccepted, proposalReceivedMsg
+}
+
+// SubmitPanelProposal Propose a panel without an account
+//
+//	@Summary		Propose a panel
+//	@Description	Propose a panel without an account. The proposer is emailed a link to confirm their address, valid for 48 hours; once they follow it the panel is created in the submitted state. Proposals are limited per hour per client address and per email address
+//	@Tags			public
+//	@Accept			json
+//	@Produce		json
+//	@Param			proposal	body	model.PanelProposalSubmission	true	"Proposal"
+//	@Success		202	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		429	{object}	model.FailureMsg
+//	@Failure		503	{object}	model.FailureMsg
+//	@Router			/public/proposal [post]
+func (g *GironService) SubmitPanelProposal(c *gin.Context) {
+	var json model.PanelProposalSubmission
+	if err := c.ShouldBindJSON(&json); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	status, msg := g.submitProposal(c, json)
+	if status >= http.StatusBadRequest {
+		c.IndentedJSON(status, gin.H{"error": msg})
+	} else {
+		c.IndentedJSON(status, gin.H{"message": msg})
+	}
+}
+
+func (g *GironService) ProposalUI(c *gin.Context) {
+	log.Println("INFO: Displaying the panel proposal form")
+	if !g.proposalsEnabled() {
+		c.HTML(http.StatusServiceUnavailable, "propose.html", gin.H{"content": proposalsUnavailableMsg})
+		return
+	}
+	c.HTML(http.StatusOK, "propose.html", gin.H{
+		"content":  "Tell us about the panel you would like to run",
+		"showForm": true,
+	})
+}
+
+func (g *GironService) ProposalUIPost(c *gin.Context) {
+	log.Println("INFO: Panel proposal form submitted")
+	var form model.PanelProposalSubmission
+	if err := c.ShouldBind(&form); err != nil {
+		c.HTML(http.StatusBadRequest, "propose.html", gin.H{"content": string(err.Error()), "showForm": true})
+		return
+	}
+
+	status, msg := g.submitProposal(c, form)
+	c.HTML(status, "propose.html", gin.H{
+		"content":  msg,
+		"showForm": status >= http.StatusBadRequest,
+		"proposal": form,
+	})
+}
+
+func (g *GironService) VerifyProposalUI(c *gin.Context) {
+	log.Println("INFO: Panel proposal verification link followed")
+	panelId, err := model.VerifyPanelProposal(c.Query("token"))
+	if err != nil {
+		var noProposal *model.NoSuchProposal
+		if errors.As(err, &noProposal) {
+			c.HTML(http.StatusNotFound, "propose.html", gin.H{"content": "This link is invalid or has expired. Please send your proposal again."})
+			return
+		}
+		c.HTML(http.StatusInternalServerError, "propose.html", gin.H{"content": "Unable to confirm your proposal. Please try again later."})
+		return
+	}
+
+	log.Println("INFO: Panel proposal confirmed as panel Id '" + strconv.Itoa(panelId) + "'")
+	c.HTML(http.StatusOK, "propose.html", gin.H{
+		"content": "Your proposal has been confirmed. Our programming team will be in touch.",
+	})
+}
+
+// GetPanelProposalByPanelId Retrieve the proposer of a panel
+//
+//	@Summary		Retrieve the proposer of a panel
+//	@Description	Retrieve the contact details given by whoever proposed a panel through the public form
+//	@Tags			panels
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.PanelProposal
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/panel/{id}/proposal [get]
+func (g *GironService) GetPanelProposalByPanelId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		proposal, err := model.GetPanelProposalByPanelId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if proposal.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "panel id " + strconv.Itoa(id) + " was not proposed through the public form"})
+		} else {
+			c.IndentedJSON(http.StatusOK, proposal)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,351 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// publicPanelFromSQL Strip a panel record down to what attendees may see
+func publicPanelFromSQL(panel model.PanelSQL, tags map[int][]string) model.PublicPanel {
+	full := panelFromSQL(panel)
+	publicPanel := model.PublicPanel{
+		Id:                full.Id,
+		Topic:             full.Topic,
+		Description:       full.Description,
+		LocationId:        full.LocationId,
+		Location:          full.Location,
+		ScheduledTime:     full.ScheduledTime,
+		DurationInMinutes: full.DurationInMinutes,
+		AgeRestricted:     full.AgeRestricted,
+		Tags:              tags[panel.Id],
+	}
+	if publicPanel.Tags == nil {
+		publicPanel.Tags = make([]string, 0)
+	}
+
+	return publicPanel
+}
+
+// publicScreeningFromSQL Strip a screening record down to what attendees may
+// see and nest the location it is held in
+func publicScreeningFromSQL(screening model.ScreeningSQL, locations map[int]model.LocationDetail, tags map[int][]string) model.PublicScreening {
+	full := screeningFromSQL(screening)
+	publicScreening := model.PublicScreening{
+		Id:                full.Id,
+		Title:             full.Title,
+		Synopsis:          full.Synopsis,
+		LocationId:        full.LocationId,
+		ScheduledTime:     full.ScheduledTime,
+		DurationInMinutes: full.DurationInMinutes,
+		AgeRestricted:     full.AgeRestricted,
+		Tags:              tags[screening.Id],
+	}
+	if location, ok := locations[full.LocationId]; ok {
+		publicScreening.Location = &location
+	}
+	if publicScreening.Tags == nil {
+		publicScreening.Tags = make([]string, 0)
+	}
+
+	return publicScreening
+}
+
+func locationDetailsById() (map[int]model.LocationDetail, error) {
+	locations, err := model.GetLocationDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[int]model.LocationDetail)
+	for _, location := range locations {
+		byId[location.Id] = location
+	}
+	return byId, nil
+}
+
+// GetPublicPanels Retrieve the published panels
+//
+//	@Summary		Retrieve the published panels
+//	@Description	Retrieve approved, scheduled panels for attendees. No authentication needed. When one or more tag names are given, only panels carrying every one of those tags are returned
+//	@Tags			public
+//	@Produce		json
+//	@Param			locationId	query	int			false	"Location Id"
+//	@Param			tag			query	[]string	false	"Tag name to filter by"	collectionFormat(multi)
+//	@Success		200	{object}	model.PublicPanelList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/public/panels [get]
+func (g *GironService) GetPublicPanels(c *gin.Context) {
+	locationId, err := queryInt(c, "locationId")
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid locationId: " + string(err.Error())})
+		return
+	}
+
+	panels, err := model.GetPublishedPanels()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve list of panels: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	tags, err := model.GetPublishedPanelTagNames()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve panel tags: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	var taggedPanels map[int]bool
+	tagNames := c.QueryArray("tag")
+	if len(tagNames) > 0 {
+		taggedPanels, err = model.GetPanelIdsByTagNames(tagNames)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve panels by tag: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+	}
+
+	panelSlice := make([]model.PublicPanel, 0)
+	for _, panel := range panels {
+		if taggedPanels != nil && !taggedPanels[panel.Id] {
+			continue
+		}
+		if locationId != 0 && int(panel.LocationId.Int64) != locationId {
+			continue
+		}
+		panelSlice = append(panelSlice, publicPanelFromSQL(panel, tags))
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"data": panelSlice})
+}
+
+// GetPublicPanelById Retrieve a published panel by Id
+//
+//	@Summary		Retrieve a published panel by Id
+//	@Description	Retrieve an approved, scheduled panel for attendees. No authentication needed
+//	@Tags			public
+//	@Produce		json
+//	@Param			id	path	string	true	"Panel Id"
+//	@Success		200	{object}	model.PublicPanel
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/public/panel/{id} [get]
+func (g *GironService) GetPublicPanelById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	panel, err := model.GetPublishedPanelById(id)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	if panel.Id == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with panel id " + strconv.Itoa(id)})
+		return
+	}
+	tags, err := model.GetPanelTagNamesById(panel.Id)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, publicPanelFromSQL(panel, tags))
+}
+
+// GetPublicScreenings Retrieve the published screenings
+//
+//	@Summary		Retrieve the published screenings
+//	@Description	Retrieve scheduled video screenings for attendees. No authentication needed. When one or more tag names are given, only screenings carrying every one of those tags are returned
+//	@Tags			public
+//	@Produce		json
+//	@Param			locationId	query	int			false	"Location Id"
+//	@Param			tag			query	[]string	false	"Tag name to filter by"	collectionFormat(multi)
+//	@Success		200	{object}	model.PublicScreeningList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/public/screenings [get]
+func (g *GironService) GetPublicScreenings(c *gin.Context) {
+	locationId, err := queryInt(c, "locationId")
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid locationId: " + string(err.Error())})
+		return
+	}
+
+	screenings, err := model.GetPublishedScreenings()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve list of screenings: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	locations, err := locationDetailsById()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve locations: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	tags, err := model.GetPublishedScreeningTagNames()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve screening tags: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	var taggedScreenings map[int]bool
+	tagNames := c.QueryArray("tag")
+	if len(tagNames) > 0 {
+		taggedScreenings, err = model.GetScreeningIdsByTagNames(tagNames)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve screenings by tag: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+	}
+
+	screeningSlice := make([]model.PublicScreening, 0)
+	for _, screening := range screenings {
+		if taggedScreenings != nil && !taggedScreenings[screening.Id] {
+			continue
+		}
+		if locationId != 0 && int(screening.LocationId.Int64) != locationId {
+			continue
+		}
+		screeningSlice = append(screeningSlice, publicScreeningFromSQL(screening, locations, tags))
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"data": screeningSlice})
+}
+
+// GetPublicScreeningById Retrieve a published screening by Id
+//
+//	@Summary		Retrieve a published screening by Id
+//	@Description	Retrieve a scheduled video screening for attendees. No authentication needed
+//	@Tags			public
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Success		200	{object}	model.PublicScreening
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/public/screening/{id} [get]
+func (g *GironService) GetPublicScreeningById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	screening, err := model.GetPublishedScreeningById(id)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	if screening.Id == 0 {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strconv.Itoa(id)})
+		return
+	}
+	locations, err := locationDetailsById()
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	tags, err := model.GetScreeningTagNamesById(screening.Id)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, publicScreeningFromSQL(screening, locations, tags))
+}
+
+// GetPublicLocations Retrieve all locations
+//
+//	@Summary		Retrieve all locations
+//	@Description	Retrieve every location with the floor and building it is in. No authentication needed
+//	@Tags			public
+//	@Produce		json
+//	@Success		200	{object}	model.LocationDetailList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/public/locations [get]
+func (g *GironService) GetPublicLocations(c *gin.Context) {
+	locations, err := model.GetLocationDetails()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve list of locations: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"data": locations})
+}
+
+// GetPublicBuildings Retrieve all buildings
+//
+//	@Summary		Retrieve all buildings
+//	@Description	Retrieve every building used by the convention. No authentication needed
+//	@Tags			public
+//	@Produce		json
+//	@Success		200	{object}	model.PublicBuildingList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/public/buildings [get]
+func (g *GironService) GetPublicBuildings(c *gin.Context) {
+	buildings, err := model.GetBuildings()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve list of buildings: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	buildingSlice := make([]model.PublicBuilding, 0)
+	for _, building := range buildings {
+		buildingSlice = append(buildingSlice, model.PublicBuilding{
+			Id:     building.Id,
+			Name:   building.Name,
+			City:   building.City,
+			Region: building.Region,
+		})
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"data": buildingSlice})
+}
+
+// GetPublicTags Retrieve all tags
+//
+//	@Summary		Retrieve all tags
+//	@Description	Retrieve every tag events can be filtered by. No authentication needed
+//	@Tags			public
+//	@Produce		json
+//	@Success		200	{object}	model.TagList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/public/tags [get]
+func (g *GironService) GetPublicTags(c *gin.Context) {
+	tags, err := model.GetTags()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve list of tags: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"data": tags})
+}
@@ -0,0 +1,71 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultTopRatedLimit = 10
+
+// GetTopRatedEvents Report the top-rated events per room or track
+//
+//	@Summary		Report the top-rated events per room or track
+//	@Description	List the best rated panels, screenings and live events of every room or every track (tag), best first. Events nobody has rated are left out
+//	@Tags			reports
+//	@Produce		json
+//	@Param			groupBy	query	string	false	"Group events by room or by track (default room)"	Enums(room, track)
+//	@Param			limit	query	int		false	"Maximum number of events per group (default 10)"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.RatingReport
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/reports/topRated [get]
+func (g *GironService) GetTopRatedEvents(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		groupBy := c.DefaultQuery("groupBy", model.RatingsByRoom)
+		if groupBy != model.RatingsByRoom && groupBy != model.RatingsByTrack {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "groupBy must be either room or track"})
+			return
+		}
+		limit, err := queryInt(c, "limit")
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid limit: " + string(err.Error())})
+			return
+		}
+		if limit <= 0 {
+			limit = defaultTopRatedLimit
+		}
+
+		groups, err := model.GetTopRatedEvents(groupBy, limit)
+		if err != nil {
+			log.Println("ERROR: Cannot build the top rated report: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, model.RatingReport{GroupBy: groupBy, Data: groups})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,473 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRole Add a role
+//
+//	@Summary		Create a new role
+//	@Description	Create a new role with an optional list of privileges
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			role	body	model.ProposedRole	true	"Role data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/role [post]
+func (g *GironService) CreateRole(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedRole
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.TrimSpace(json.RoleName) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Role name cannot be empty"})
+			return
+		}
+
+		s, err := model.CreateRole(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Role has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteRoleById Delete a role by its Id
+//
+//	@Summary		Delete a role by Id
+//	@Description	Delete a role by Id. The role is removed from any users holding it
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Role Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/role/{id} [delete]
+func (g *GironService) DeleteRoleById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteRoleById(id, userObject.Id)
+		if err != nil {
+			var lastAdministrator *model.LastAdministrator
+			if errors.As(err, &lastAdministrator) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			log.Println("ERROR: Cannot delete role: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove role! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Role Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with role id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetRoles Retrieve list of all roles
+//
+//	@Summary		Retrieve list of all roles
+//	@Description	Retrieve list of all roles and the privileges they grant
+//	@Tags			roles
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.RoleList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/roles [get]
+func (g *GironService) GetRoles(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		roles, err := model.GetRoles()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of roles: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if roles == nil {
+			log.Println("WARN: No roles returned")
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found!"})
+		} else {
+			log.Println("INFO: Returned list of roles")
+			c.IndentedJSON(http.StatusOK, gin.H{"data": roles})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetRoleById Retrieve role by Id
+//
+//	@Summary		Retrieve role by Id
+//	@Description	Retrieve role by Id
+//	@Tags			roles
+//	@Produce		json
+//	@Param			id	path	string	true	"Role Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Role
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/role/{id} [get]
+func (g *GironService) GetRoleById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetRoleById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.RoleName == "" {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with role id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateRoleById Update role by Id
+//
+//	@Summary		Update role information
+//	@Description	Update the name and description of a role
+//	@Tags			roles
+//	@Produce		json
+//	@Param			id		path	string			true	"Role Id"
+//	@Param			role	body	model.RoleUpdate	true	"Role data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/role/{id} [patch]
+func (g *GironService) UpdateRoleById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.RoleUpdate
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.TrimSpace(json.RoleName) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Role name cannot be empty"})
+			return
+		}
+
+		status, err := model.UpdateRoleById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Role updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with role id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AssignPrivilegeToRole Grant a privilege to a role
+//
+//	@Summary		Assign a privilege to a role
+//	@Description	Assign a privilege to a role
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string						true	"Role Id"
+//	@Param			privilege	body	model.PrivilegeAssignment	true	"Privilege to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/role/{id}/assignPrivilege [post]
+func (g *GironService) AssignPrivilegeToRole(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.PrivilegeAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.AssignPrivilegeToRole(id, json, userObject.Id)
+		if err != nil {
+			var noSuchPrivilege *model.NoSuchPrivilege
+			if errors.As(err, &noSuchPrivilege) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Privilege '" + json.Privilege + "' assigned to role"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with role id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignPrivilegeFromRole Revoke a privilege from a role
+//
+//	@Summary		Unassign a privilege from a role
+//	@Description	Unassign a privilege from a role
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	string						true	"Role Id"
+//	@Param			privilege	body	model.PrivilegeAssignment	true	"Privilege to unassign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/role/{id}/unassignPrivilege [patch]
+func (g *GironService) UnassignPrivilegeFromRole(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.PrivilegeAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.UnassignPrivilegeFromRole(id, json, userObject.Id)
+		if err != nil {
+			var noSuchPrivilege *model.NoSuchPrivilege
+			if errors.As(err, &noSuchPrivilege) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			var lastAdministrator *model.LastAdministrator
+			if errors.As(err, &lastAdministrator) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Privilege '" + json.Privilege + "' unassigned from role"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Privilege was not assigned to role id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetPrivileges Retrieve list of all privileges
+//
+//	@Summary		Retrieve list of all privileges
+//	@Description	Retrieve list of all privileges that can be granted to roles
+//	@Tags			roles
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.PrivilegeList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/privileges [get]
+func (g *GironService) GetPrivileges(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		privileges, err := model.GetPrivileges()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of privileges: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if privileges == nil {
+			log.Println("WARN: No privileges returned")
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found!"})
+		} else {
+			log.Println("INFO: Returned list of privileges")
+			c.IndentedJSON(http.StatusOK, gin.H{"data": privileges})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetRolesByUserName Retrieve the roles held by a user
+//
+//	@Summary		Retrieve the roles held by a user
+//	@Description	Retrieve the roles held by a user
+//	@Tags			user
+//	@Produce		json
+//	@Param			name	path	string	true	"User name"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.RoleList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/user/{name}/roles [get]
+func (g *GironService) GetRolesByUserName(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		username := c.Param("name")
+		roles, err := model.GetRolesByUserName(username)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": roles})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AssignRoleToUser Grant a role to a user
+//
+//	@Summary		Assign a role to a user
+//	@Description	Assign a role to a user
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path	string					true	"User name"
+//	@Param			role	body	model.RoleAssignment	true	"Role to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/user/{name}/assignRole [post]
+func (g *GironService) AssignRoleToUser(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		username := c.Param("name")
+		var json model.RoleAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.AssignRoleToUser(username, json, userObject.Id)
+		if err != nil {
+			var noSuchRole *model.NoSuchRole
+			if errors.As(err, &noSuchRole) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Role assigned to user '" + username + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found for user " + username})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignRoleFromUser Revoke a role from a user
+//
+//	@Summary		Unassign a role from a user
+//	@Description	Unassign a role from a user
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path	string					true	"User name"
+//	@Param			role	body	model.RoleAssignment	true	"Role to unassign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/user/{name}/unassignRole [patch]
+func (g *GironService) UnassignRoleFromUser(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		username := c.Param("name")
+		var json model.RoleAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.UnassignRoleFromUser(username, json, userObject.Id)
+		if err != nil {
+			var lastAdministrator *model.LastAdministrator
+			if errors.As(err, &lastAdministrator) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Role unassigned from user '" + username + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Role was not assigned to user " + username})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
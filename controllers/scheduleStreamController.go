@@ -0,0 +1,139 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JAFAX/giron-service/helpers"
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// how often the change log is checked for new changes
+	scheduleStreamPoll = time.Second
+	// comments sent on a quiet stream so proxies do not close it
+	scheduleStreamHeartbeat = 15 * time.Second
+	scheduleStreamBatchSize = 100
+	// milliseconds a client waits before reconnecting after a drop
+	scheduleStreamRetry = 3000
+)
+
+// streamUserActive Reports whether the user a stream was opened for may still
+// receive it: they still exist and are not locked. Streams outlive the
+// request that authenticated them, so this is checked again as they run.
+func streamUserActive(userId int) bool {
+	user, err := model.GetUserById(userId)
+	if err != nil || user.UserName == "" {
+		return false
+	}
+	return helpers.CheckIsNotLocked(user)
+}
+
+// GetScheduleStream Stream schedule changes as Server-Sent Events
+//
+//	@Summary		Stream schedule changes
+//	@Description	Stream panel and screening changes (created, scheduled, rescheduled, relocated, approved, rejected, cancelled, deleted) as Server-Sent Events named <eventType>.<change>, e.g. panel.rescheduled. Each event's id is its position in the change log; a client reconnecting with Last-Event-ID, or the lastEventId parameter, gets every change it missed. Without either, the stream starts with the next change. The first event, "ready", carries the position the stream starts from
+//	@Tags			schedule
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header	int	false	"Id of the last change seen"
+//	@Param			lastEventId		query	int	false	"Id of the last change seen, for clients that cannot set headers"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.ScheduleChange
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/schedule/stream [get]
+func (g *GironService) GetScheduleStream(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		lastEventId := c.GetHeader("Last-Event-ID")
+		if lastEventId == "" {
+			lastEventId = c.Query("lastEventId")
+		}
+
+		var cursor int
+		var err error
+		if lastEventId != "" {
+			cursor, err = strconv.Atoi(lastEventId)
+			if err != nil || cursor < 0 {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID must be a change Id"})
+				return
+			}
+		} else {
+			cursor, err = model.LatestScheduleChangeId()
+			if err != nil {
+				c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+				return
+			}
+		}
+
+		log.Println("INFO: Schedule stream opened after change Id '" + strconv.Itoa(cursor) + "'")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		c.Render(-1, sse.Event{
+			Id:    strconv.Itoa(cursor),
+			Event: "ready",
+			Retry: scheduleStreamRetry,
+			Data:  gin.H{"lastEventId": cursor},
+		})
+		c.Writer.Flush()
+
+		poll := time.NewTicker(scheduleStreamPoll)
+		defer poll.Stop()
+		heartbeat := time.NewTicker(scheduleStreamHeartbeat)
+		defer heartbeat.Stop()
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case <-heartbeat.C:
+				if !streamUserActive(userObject.Id) {
+					log.Println("WARN: Closing schedule stream of user '" + userObject.UserName + "', who was locked or deleted")
+					return false
+				}
+				_, err := io.WriteString(w, ": keep-alive\n\n")
+				return err == nil
+			case <-poll.C:
+				changes, err := model.GetScheduleChangesSince(cursor, scheduleStreamBatchSize)
+				if err != nil {
+					log.Println("ERROR: Cannot read schedule changes: " + string(err.Error()))
+					return false
+				}
+				for _, change := range changes {
+					c.Render(-1, sse.Event{
+						Id:    strconv.Itoa(change.Id),
+						Event: change.EventType + "." + change.Change,
+						Data:  change,
+					})
+					cursor = change.Id
+				}
+				return true
+			}
+		})
+		log.Println("INFO: Schedule stream closed at change Id '" + strconv.Itoa(cursor) + "'")
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
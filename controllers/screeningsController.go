@@ -0,0 +1,674 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// screeningFromSQL Flatten the nullable columns of a screening record
+func screeningFromSQL(screening model.ScreeningSQL) model.Screening {
+	screeningEnt := model.Screening{}
+	screeningEnt.Id = screening.Id
+	screeningEnt.Title = screening.Title
+	screeningEnt.Synopsis = screening.Synopsis
+	if screening.LocationId.Valid {
+		screeningEnt.LocationId = int(screening.LocationId.Int64)
+	} else {
+		screeningEnt.LocationId = 0
+	}
+	if screening.ScheduledTime.Valid {
+		screeningEnt.ScheduledTime = screening.ScheduledTime.String
+	} else {
+		screeningEnt.ScheduledTime = ""
+	}
+	screeningEnt.DurationInMinutes = screening.DurationInMinutes
+	screeningEnt.AgeRestricted = screening.AgeRestricted
+	screeningEnt.Rating = screening.Rating
+	screeningEnt.CreatorId = screening.CreatorId
+	screeningEnt.CreationDateTime = screening.CreationDateTime
+
+	return screeningEnt
+}
+
+// CreateScreening Add a video screening event
+//
+//	@Summary		Create a new video screening event
+//	@Description	Create a new video screening event
+//	@Tags			screenings
+//	@Accept			json
+//	@Produce		json
+//	@Param			screening	body	model.ProposedScreening	true	"Screening data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening [post]
+func (g *GironService) CreateScreening(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedScreening
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		s, err := model.CreateScreening(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Screening has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteScreeningById Delete a screening by its Id
+//
+//	@Summary		Delete a screening by Id
+//	@Description	Delete a screening by Id
+//	@Tags			screenings
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening/{id} [delete]
+func (g *GironService) DeleteScreeningById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteScreeningById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete screening: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove screening! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			idString := strconv.Itoa(id)
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Screening Id '" + idString + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove screening!"})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreenings Retrieve list of all screenings
+//
+//	@Summary		Retrieve list of all screenings
+//	@Description	Retrieve list of all screenings
+//	@Tags			screenings
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.ScreeningList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screenings [get]
+func (g *GironService) GetScreenings(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		screenings, err := model.GetScreenings()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of screenings: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		screeningSlice := make([]model.Screening, 0)
+		for _, screening := range screenings {
+			screeningSlice = append(screeningSlice, screeningFromSQL(screening))
+		}
+
+		if screenings == nil {
+			log.Println("WARN: No screenings returned")
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found!"})
+		} else {
+			log.Println("INFO: Returned list of screenings")
+			c.IndentedJSON(http.StatusOK, gin.H{"data": screeningSlice})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreeningsByLocationId Retrieve list of all screenings by location Id
+//
+//	@Summary		Retrieve list of all screenings by location Id
+//	@Description	Retrieve list of all screenings by location Id
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Location Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.ScreeningList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screenings/ByLocationId/{id} [get]
+func (g *GironService) GetScreeningsByLocationId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		screenings, err := model.GetScreeningsByLocationId(id)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of screenings: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		screeningSlice := make([]model.Screening, 0)
+		for _, screening := range screenings {
+			screeningSlice = append(screeningSlice, screeningFromSQL(screening))
+		}
+
+		log.Println("INFO: Returned list of screenings for location Id '" + strconv.Itoa(id) + "'")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": screeningSlice})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreeningById Retrieve screening by Id
+//
+//	@Summary		Retrieve screening by Id
+//	@Description	Retrieve screening by Id
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Screening
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening/{id} [get]
+func (g *GironService) GetScreeningById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetScreeningById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Title == "" {
+			strId := strconv.Itoa(id)
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strId})
+		} else {
+			c.IndentedJSON(http.StatusOK, screeningFromSQL(ent))
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreeningLocationByScreeningId Retrieve screening location by the screening Id
+//
+//	@Summary		Retrieve screening location by the screening Id
+//	@Description	Retrieve screening location by the screening Id
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Location
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening/{id}/location [get]
+func (g *GironService) GetScreeningLocationByScreeningId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetScreeningLocationByScreeningId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Location == "" {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no location assigned to screening id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreeningScheduleByScreeningId Retrieve screening schedule by the screening Id
+//
+//	@Summary		Retrieve screening schedule by the screening Id
+//	@Description	Retrieve screening schedule by the screening Id
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Schedule
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening/{id}/schedule [get]
+func (g *GironService) GetScreeningScheduleByScreeningId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetScreeningScheduleByScreeningId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, ent)
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetScreeningLocation Set screening location
+//
+//	@Summary		Set screening location
+//	@Description	Set screening location
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Param			json	body	model.ScreeningLocation	true	"Location data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
+//	@Router			/screening/{id}/location [post]
+func (g *GironService) SetScreeningLocation(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.ScreeningLocation
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.SetScreeningLocation(id, json, userObject.Id)
+		if err != nil {
+			var noSuchScreening *model.NoSuchScreening
+			if errors.As(err, &noSuchScreening) {
+				c.IndentedJSON(http.StatusNotFound, gin.H{"error": string(err.Error())})
+				return
+			}
+			var noLocation *model.NoSuchLocation
+			if errors.As(err, &noLocation) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error()), "conflicts": conflict.Conflicts})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Screening location updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetScreeningScheduledTimeById Set the screening's scheduled time
+//
+//	@Summary		Set the scheduled time for a screening
+//	@Description	Set the location, scheduled time and, optionally, duration of a screening. The request is refused with the list of conflicting events if the slot overlaps, including the configured changeover buffer, with any panel, screening or live event in the same location
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Param			json	body	model.ScreeningScheduledTime	true	"Scheduled Time"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.ScheduleConflictMsg
+//	@Router			/screening/{id}/schedule [post]
+func (g *GironService) SetScreeningScheduledTimeById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.ScreeningScheduledTime
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, msg, err := model.SetScreeningScheduledTimeById(id, json, userObject.Id)
+		if err != nil {
+			var noSuchScreening *model.NoSuchScreening
+			if errors.As(err, &noSuchScreening) {
+				c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Screening cannot be scheduled. Reason: " + msg})
+				return
+			}
+			var conflict *model.SchedulingConflict
+			if errors.As(err, &conflict) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": "Screening cannot be scheduled. Reason: " + msg, "conflicts": conflict.Conflicts})
+				return
+			}
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Screening scheduled for " + msg})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "Screening cannot be scheduled. Reason: " + msg})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetScreeningAgeRestrictionById Set the age restriction status of a screening
+//
+//	@Summary		Set screening age restriction
+//	@Description	Set screening age restriction
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Param			json	body	model.ScreeningAgeRestrictionState	true	"Age restriction state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening/{id}/restricted [post]
+func (g *GironService) SetScreeningAgeRestrictionById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.ScreeningAgeRestrictionState
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetScreeningAgeRestrictionById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if !status {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strconv.Itoa(id)})
+		} else if json.RestrictionState {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Screening is age restricted"})
+		} else {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Screening is not age restricted"})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreeningTagsByScreeningId Retrieve the tags assigned to a screening
+//
+//	@Summary		Retrieve the tags assigned to a screening
+//	@Description	Retrieve the tags assigned to a screening
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.TagList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/screening/{id}/tags [get]
+func (g *GironService) GetScreeningTagsByScreeningId(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		tags, err := model.GetTagsByScreeningId(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": tags})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// AssignTagToScreening Assign a tag to a screening
+//
+//	@Summary		Assign a tag to a screening
+//	@Description	Assign a tag to a screening. Assigning a tag the screening already carries is a no-op
+//	@Tags			screenings
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Param			json	body	model.TagAssignment	true	"Tag to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/screening/{id}/assignTag [post]
+func (g *GironService) AssignTagToScreening(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.TagAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.AssignTagToScreening(id, json, userObject.Id)
+		if err != nil {
+			var noTag *model.NoSuchTag
+			if errors.As(err, &noTag) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag assigned to screening"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignTagFromScreening Remove a tag from a screening
+//
+//	@Summary		Remove a tag from a screening
+//	@Description	Remove a tag from a screening
+//	@Tags			screenings
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Param			json	body	model.TagAssignment	true	"Tag to unassign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/screening/{id}/unassignTag [patch]
+func (g *GironService) UnassignTagFromScreening(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.TagAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.UnassignTagFromScreening(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag unassigned from screening"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "tag id " + strconv.Itoa(json.TagId) + " is not assigned to screening id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetScreeningRating Retrieve the average rating of a screening
+//
+//	@Summary		Retrieve the rating of a screening
+//	@Description	Retrieve the average rating of a screening and how many ratings it is based on
+//	@Tags			screenings
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.EventRating
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/screening/{id}/rating [get]
+func (g *GironService) GetScreeningRating(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		rating, err := model.GetScreeningRating(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if rating.EventId == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, rating)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// RateScreening Submit a rating for a screening
+//
+//	@Summary		Rate a screening
+//	@Description	Rate a screening from 1 to 5. Each user has one rating per screening; rating again replaces the earlier rating
+//	@Tags			screenings
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Screening Id"
+//	@Param			json	body	model.RatingSubmission	true	"Rating"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/screening/{id}/rating [post]
+func (g *GironService) RateScreening(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.RatingSubmission
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.RateScreening(id, userObject.Id, json)
+		if err != nil {
+			var invalid *model.InvalidRating
+			if errors.As(err, &invalid) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Rating recorded for screening"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with screening id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
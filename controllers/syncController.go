@@ -0,0 +1,225 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"encoding/base64"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSyncLimit = 500
+	maxSyncLimit     = 5000
+	// sync cursors are versioned so their format can change later
+	syncCursorPrefix = "v1:"
+)
+
+// encodeSyncCursor Turns a sync log position into the opaque cursor clients
+// hand back
+func encodeSyncCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncCursorPrefix + strconv.Itoa(id)))
+}
+
+func decodeSyncCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(decoded) <= len(syncCursorPrefix) || string(decoded[:len(syncCursorPrefix)]) != syncCursorPrefix {
+		return 0, errors.New("invalid cursor")
+	}
+	id, err := strconv.Atoi(string(decoded[len(syncCursorPrefix):]))
+	if err != nil || id < 0 {
+		return 0, errors.New("invalid cursor")
+	}
+	return id, nil
+}
+
+// syncChangesFromSet Shapes the changed rows the way the rest of the API
+// returns them
+func syncChangesFromSet(set model.SyncChangeSet) model.SyncChanges {
+	changes := model.SyncChanges{
+		Cursor:     encodeSyncCursor(set.LastChangeId),
+		HasMore:    set.HasMore,
+		Buildings:  set.Buildings,
+		Floors:     set.Floors,
+		Locations:  set.Locations,
+		Panels:     make([]model.SyncedPanel, 0, len(set.Panels)),
+		Screenings: make([]model.SyncedScreening, 0, len(set.Screenings)),
+		Tags:       set.Tags,
+		Deleted:    set.Deleted,
+	}
+	for _, panel := range set.Panels {
+		tags := set.PanelTags[panel.Id]
+		if tags == nil {
+			tags = make([]string, 0)
+		}
+		changes.Panels = append(changes.Panels, model.SyncedPanel{Panel: panelFromSQL(panel), Tags: tags})
+	}
+	for _, screening := range set.Screenings {
+		tags := set.ScreeningTags[screening.Id]
+		if tags == nil {
+			tags = make([]string, 0)
+		}
+		changes.Screenings = append(changes.Screenings, model.SyncedScreening{Screening: screeningFromSQL(screening), Tags: tags})
+	}
+
+	return changes
+}
+
+// publicSyncChangesFromSet Strips the changed rows down to what attendees may
+// see
+func publicSyncChangesFromSet(set model.SyncChangeSet, locations map[int]model.LocationDetail) model.PublicSyncChanges {
+	changes := model.PublicSyncChanges{
+		Cursor:     encodeSyncCursor(set.LastChangeId),
+		HasMore:    set.HasMore,
+		Buildings:  make([]model.PublicBuilding, 0, len(set.Buildings)),
+		Floors:     make([]model.PublicFloor, 0, len(set.Floors)),
+		Locations:  make([]model.PublicLocation, 0, len(set.Locations)),
+		Panels:     make([]model.PublicPanel, 0, len(set.Panels)),
+		Screenings: make([]model.PublicScreening, 0, len(set.Screenings)),
+		Tags:       set.Tags,
+		Deleted:    set.Deleted,
+	}
+	for _, building := range set.Buildings {
+		changes.Buildings = append(changes.Buildings, model.PublicBuilding{
+			Id:     building.Id,
+			Name:   building.Name,
+			City:   building.City,
+			Region: building.Region,
+		})
+	}
+	for _, floor := range set.Floors {
+		changes.Floors = append(changes.Floors, model.PublicFloor{
+			Id:         floor.Id,
+			FloorName:  floor.FloorName,
+			BuildingId: floor.BuildingId,
+		})
+	}
+	for _, location := range set.Locations {
+		changes.Locations = append(changes.Locations, model.PublicLocation{
+			Id:         location.Id,
+			RoomName:   location.Location,
+			FloorId:    location.FloorId,
+			BuildingId: location.BuildingId,
+		})
+	}
+	for _, panel := range set.Panels {
+		changes.Panels = append(changes.Panels, publicPanelFromSQL(panel, set.PanelTags))
+	}
+	for _, screening := range set.Screenings {
+		changes.Screenings = append(changes.Screenings, publicScreeningFromSQL(screening, locations, set.ScreeningTags))
+	}
+
+	return changes
+}
+
+// syncWindowFromQuery Reads the since cursor and limit of a sync request,
+// answering the request itself when they are invalid
+func syncWindowFromQuery(c *gin.Context) (int, int, bool) {
+	since, err := decodeSyncCursor(c.Query("since"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+		return 0, 0, false
+	}
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid limit: " + string(err.Error())})
+		return 0, 0, false
+	}
+	if limit <= 0 {
+		limit = defaultSyncLimit
+	} else if limit > maxSyncLimit {
+		limit = maxSyncLimit
+	}
+	return since, limit, true
+}
+
+// GetSyncChanges Retrieve the changes since a sync cursor
+//
+//	@Summary		Retrieve the changes since a sync cursor
+//	@Description	Retrieve the buildings, floors, locations, panels, screenings and tags created or updated since the cursor, and tombstones for the ones deleted. Without a cursor, everything is returned. Pass the returned cursor as since on the next call; while hasMore is true, call again straight away
+//	@Tags			sync
+//	@Produce		json
+//	@Param			since	query	string	false	"Cursor returned by the previous sync"
+//	@Param			limit	query	int		false	"Maximum number of changes to return (default 500, max 5000)"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SyncChanges
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/sync [get]
+func (g *GironService) GetSyncChanges(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		since, limit, ok := syncWindowFromQuery(c)
+		if !ok {
+			return
+		}
+
+		set, err := model.GetSyncChanges(since, limit)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve sync changes: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, syncChangesFromSet(set))
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetPublicSyncChanges Retrieve the changes to the published schedule since a sync cursor
+//
+//	@Summary		Retrieve the changes to the published schedule since a sync cursor
+//	@Description	Like /sync, but for attendee apps: no authentication needed, only approved, scheduled panels and scheduled screenings are sent, without staff or requestor details. Panels and screenings that were unpublished since the cursor are listed as deleted
+//	@Tags			public
+//	@Produce		json
+//	@Param			since	query	string	false	"Cursor returned by the previous sync"
+//	@Param			limit	query	int		false	"Maximum number of changes to return (default 500, max 5000)"
+//	@Success		200	{object}	model.PublicSyncChanges
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/public/sync [get]
+func (g *GironService) GetPublicSyncChanges(c *gin.Context) {
+	since, limit, ok := syncWindowFromQuery(c)
+	if !ok {
+		return
+	}
+
+	set, err := model.GetPublishedSyncChanges(since, limit)
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve published sync changes: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+	locations, err := locationDetailsById()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve locations: " + string(err.Error()))
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, publicSyncChangesFromSet(set, locations))
+}
@@ -0,0 +1,217 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTag Add a tag
+//
+//	@Summary		Create a new tag
+//	@Description	Create a new tag
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag	body	model.ProposedTag	true	"Tag data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/tag [post]
+func (g *GironService) CreateTag(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedTag
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.TrimSpace(json.TagName) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Tag name cannot be empty"})
+			return
+		}
+
+		s, err := model.CreateTag(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteTagById Delete a tag by its Id
+//
+//	@Summary		Delete a tag by Id
+//	@Description	Delete a tag by Id. Any assignments of the tag to events are removed as well
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Tag Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/tag/{id} [delete]
+func (g *GironService) DeleteTagById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteTagById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete tag: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove tag! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			idString := strconv.Itoa(id)
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag Id '" + idString + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with tag id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetTags Retrieve list of all tags
+//
+//	@Summary		Retrieve list of all tags
+//	@Description	Retrieve list of all tags
+//	@Tags			tags
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.TagList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/tags [get]
+func (g *GironService) GetTags(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		tags, err := model.GetTags()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of tags: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if tags == nil {
+			log.Println("WARN: No tags returned")
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found!"})
+		} else {
+			log.Println("INFO: Returned list of tags")
+			c.IndentedJSON(http.StatusOK, gin.H{"data": tags})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetTagById Retrieve tag by Id
+//
+//	@Summary		Retrieve tag by Id
+//	@Description	Retrieve tag by Id
+//	@Tags			tags
+//	@Produce		json
+//	@Param			id	path	string	true	"Tag Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Tag
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/tag/{id} [get]
+func (g *GironService) GetTagById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetTagById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.TagName == "" {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with tag id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateTagById Update tag by Id
+//
+//	@Summary		Update tag information
+//	@Description	Update tag information
+//	@Tags			tags
+//	@Produce		json
+//	@Param			id	path	string	true	"Tag Id"
+//	@Param			tag	body	model.ProposedTag	true	"Tag data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/tag/{id} [patch]
+func (g *GironService) UpdateTagById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.ProposedTag
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.TrimSpace(json.TagName) == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "Tag name cannot be empty"})
+			return
+		}
+
+		status, err := model.UpdateTagById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Tag updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with tag id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,369 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JAFAX/giron-service/globals"
+	"github.com/JAFAX/giron-service/helpers"
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// refuseApiToken Answers with a 403 when the request was made with an API
+// token. Tokens bypass the second factor, so they must not be able to change
+// it.
+func refuseApiToken(c *gin.Context) bool {
+	if _, viaToken := c.Get(globals.ApiTokenKey); viaToken {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication cannot be changed with an API token"})
+		return true
+	}
+	return false
+}
+
+// tooManyTwoFactorCodes Refuses a code sent while backing off after wrong
+// ones
+func tooManyTwoFactorCodes(c *gin.Context, retryAfter time.Duration) {
+	wait := helpers.RetryAfterSeconds(retryAfter)
+	c.Header("Retry-After", wait)
+	c.IndentedJSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed codes, try again in " + wait + " seconds"})
+}
+
+// checkTwoFactorCode Verifies a code from the request body against the session
+// user's second factor, answering the request itself when it does not match.
+// Wrong codes count as failed logins, so guessing backs off like signing in.
+func checkTwoFactorCode(c *gin.Context, userObject model.User) bool {
+	var json model.TwoFactorCode
+	if err := c.ShouldBindJSON(&json); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	valid, retryAfter := helpers.CheckSecondFactor(userObject, json.Code, c.ClientIP())
+	if retryAfter > 0 {
+		tooManyTwoFactorCodes(c, retryAfter)
+		return false
+	}
+	if !valid {
+		c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": "Invalid two-factor code"})
+		return false
+	}
+	return true
+}
+
+// GetTwoFactorStatus Retrieve the two-factor authentication status of the session user
+//
+//	@Summary		Retrieve the two-factor authentication status of the session user
+//	@Description	Retrieve whether the session user has two-factor authentication enabled or pending, whether one of their roles requires it, and how many unused recovery codes they have
+//	@Tags			twoFactor
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Security		BearerAuth
+//	@Success		200	{object}	model.TwoFactorStatus
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/twoFactor [get]
+func (g *GironService) GetTwoFactorStatus(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		status, err := model.GetTwoFactorStatus(userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, status)
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// BeginTwoFactorEnrollment Start two-factor enrollment for the session user
+//
+//	@Summary		Start two-factor enrollment
+//	@Description	Generate a TOTP secret for the session user and return it with its otpauth URI. Asking again before confirming returns the same secret
+//	@Tags			twoFactor
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.TwoFactorEnrollment
+//	@Failure		403	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/twoFactor [post]
+func (g *GironService) BeginTwoFactorEnrollment(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		if refuseApiToken(c) {
+			return
+		}
+
+		enrollment, err := model.BeginTwoFactorEnrollment(userObject.Id, userObject.UserName)
+		if err != nil {
+			var state *model.TwoFactorState
+			if errors.As(err, &state) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, enrollment)
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// ConfirmTwoFactorEnrollment Finish two-factor enrollment for the session user
+//
+//	@Summary		Finish two-factor enrollment
+//	@Description	Turn on two-factor authentication with a code from the newly set up app. Returns the recovery codes, which are not shown again
+//	@Tags			twoFactor
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body	model.TwoFactorCode	true	"Code from the authenticator app"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.RecoveryCodeList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Failure		429	{object}	model.FailureMsg
+//	@Router			/twoFactor/confirm [post]
+func (g *GironService) ConfirmTwoFactorEnrollment(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		if refuseApiToken(c) {
+			return
+		}
+
+		var json model.TwoFactorCode
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		codes, retryAfter, err := helpers.ConfirmTwoFactorEnrollment(userObject, json.Code, c.ClientIP())
+		if retryAfter > 0 {
+			tooManyTwoFactorCodes(c, retryAfter)
+			return
+		}
+		if err != nil {
+			var invalidCode *model.InvalidTwoFactorCode
+			var state *model.TwoFactorState
+			if errors.As(err, &invalidCode) {
+				c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+				return
+			}
+			if errors.As(err, &state) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Two-factor authentication enabled for user '" + userObject.UserName + "'")
+		c.IndentedJSON(http.StatusOK, model.RecoveryCodeList{Data: codes})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// RegenerateRecoveryCodes Replace the recovery codes of the session user
+//
+//	@Summary		Replace the session user's recovery codes
+//	@Description	Replace the session user's recovery codes with new ones, given a current code. The old codes stop working
+//	@Tags			twoFactor
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body	model.TwoFactorCode	true	"Current two-factor code"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.RecoveryCodeList
+//	@Failure		401	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Failure		429	{object}	model.FailureMsg
+//	@Router			/twoFactor/recoveryCodes [post]
+func (g *GironService) RegenerateRecoveryCodes(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		if refuseApiToken(c) || !checkTwoFactorCode(c, userObject) {
+			return
+		}
+
+		codes, err := model.RegenerateRecoveryCodes(userObject.Id)
+		if err != nil {
+			var state *model.TwoFactorState
+			if errors.As(err, &state) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, model.RecoveryCodeList{Data: codes})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DisableTwoFactor Turn off two-factor authentication for the session user
+//
+//	@Summary		Turn off two-factor authentication
+//	@Description	Turn off the session user's two-factor authentication, given a current code. Refused while one of their roles requires it
+//	@Tags			twoFactor
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body	model.TwoFactorCode	true	"Current two-factor code"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		401	{object}	model.FailureMsg
+//	@Failure		403	{object}	model.FailureMsg
+//	@Failure		429	{object}	model.FailureMsg
+//	@Router			/twoFactor/disable [post]
+func (g *GironService) DisableTwoFactor(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		if refuseApiToken(c) {
+			return
+		}
+
+		status, err := model.GetTwoFactorStatus(userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+		if status.Required {
+			c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication is required for one of your roles"})
+			return
+		}
+		if !status.Enabled {
+			c.IndentedJSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is not enabled"})
+			return
+		}
+		if !checkTwoFactorCode(c, userObject) {
+			return
+		}
+
+		_, err = model.DisableTwoFactor(userObject.Id, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"message": "Two-factor authentication has been turned off"})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// ResetUserTwoFactor Remove the two-factor authentication of a user
+//
+//	@Summary		Remove a user's two-factor authentication
+//	@Description	Remove a user's TOTP secret and recovery codes, for a user who lost their device. If one of their roles requires two-factor authentication, they set it up again at their next login
+//	@Tags			user
+//	@Produce		json
+//	@Param			name	path	string	true	"User name"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/user/{name}/twoFactor [delete]
+func (g *GironService) ResetUserTwoFactor(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		if refuseApiToken(c) {
+			return
+		}
+
+		username := c.Param("name")
+		target, err := model.GetUserByUserName(username)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+		if target.UserName == "" {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with user name " + username})
+			return
+		}
+
+		status, err := model.DisableTwoFactor(target.Id, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			log.Println("INFO: Two-factor authentication of user '" + username + "' reset by '" + userObject.UserName + "'")
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Two-factor authentication of user '" + username + "' has been removed"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "User '" + username + "' has no two-factor authentication"})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetRoleTwoFactorPolicy Set whether a role requires two-factor authentication
+//
+//	@Summary		Set whether a role requires two-factor authentication
+//	@Description	Set whether holders of a role must sign in with a second factor. Holders without one are asked to set it up at their next login, and cannot sign in with Basic auth until they have
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string						true	"Role Id"
+//	@Param			policy	body	model.RoleTwoFactorPolicy	true	"Two-factor policy"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/role/{id}/twoFactor [patch]
+func (g *GironService) SetRoleTwoFactorPolicy(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		if refuseApiToken(c) {
+			return
+		}
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.RoleTwoFactorPolicy
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		status, err := model.SetRoleTwoFactorPolicy(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Two-factor policy of role Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with role id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -18,12 +18,17 @@ package controllers
 
 */
 
-import "github.com/JAFAX/giron-service/globals"
+import (
+	"time"
+
+	"github.com/JAFAX/giron-service/globals"
+)
 
 type GironService struct {
 	AppPath    string
 	ConfigPath string
 	ConfStruct globals.Config
+	TimeZone   *time.Location
 }
 
 type SafeUser struct {
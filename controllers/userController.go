@@ -19,10 +19,12 @@ package controllers
 */
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
+	"github.com/JAFAX/giron-service/middleware"
 	"github.com/JAFAX/giron-service/model"
 	"github.com/gin-gonic/gin"
 )
@@ -40,7 +42,7 @@ import (
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/user [post]
 func (g *GironService) CreateUser(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		var json model.ProposedUser
 		if err := c.ShouldBindJSON(&json); err != nil {
@@ -48,7 +50,7 @@ func (g *GironService) CreateUser(c *gin.Context) {
 			return
 		}
 
-		s, err := model.CreateUser(json)
+		s, err := model.CreateUser(json, userObject.Id)
 		if s {
 			c.IndentedJSON(http.StatusOK, gin.H{"message": "User has been added to system"})
 		} else {
@@ -73,16 +75,28 @@ func (g *GironService) CreateUser(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/user/{name} [patch]
 func (g *GironService) ChangeAccountPassword(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		username := c.Param("name")
+		// users may change their own password; anyone else's needs users.admin
+		if userObject.UserName != username {
+			allowed, err := middleware.HasPrivilege(c, userObject.Id, "users.admin")
+			if err != nil {
+				c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+				return
+			}
+			if !allowed {
+				c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+				return
+			}
+		}
 		var json model.PasswordChange
 		if err := c.ShouldBindJSON(&json); err != nil {
 			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		status, err := model.ChangeAccountPassword(username, json.OldPassword, json.NewPassword)
+		status, err := model.ChangeAccountPassword(username, json.OldPassword, json.NewPassword, userObject.Id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
@@ -109,13 +123,19 @@ func (g *GironService) ChangeAccountPassword(c *gin.Context) {
 //	@Security		BasicAuth
 //	@Success		200	{object}	model.SuccessMsg
 //	@Failure		400	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
 //	@Router			/user/{name} [delete]
 func (g *GironService) DeleteUser(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		username := c.Param("name")
-		status, err := model.DeleteUser(username)
+		status, err := model.DeleteUser(username, userObject.Id)
 		if err != nil {
+			var lastAdministrator *model.LastAdministrator
+			if errors.As(err, &lastAdministrator) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
 			log.Println("ERROR: Cannot delete user: " + string(err.Error()))
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove user! " + string(err.Error())})
 			return
@@ -177,7 +197,7 @@ func (g *GironService) GetUserStatus(c *gin.Context) {
 //	@Failure		400	{object}	model.FailureMsg
 //	@Router			/user/{name}/status [patch]
 func (g *GironService) SetUserStatus(c *gin.Context) {
-	_, authed := g.GetUserId(c)
+	userObject, authed := g.GetUserId(c)
 	if authed {
 		username := c.Param("name")
 		var json model.UserStatus
@@ -186,7 +206,7 @@ func (g *GironService) SetUserStatus(c *gin.Context) {
 			return
 		}
 
-		status, err := model.SetUserStatus(username, json)
+		status, err := model.SetUserStatus(username, json, userObject.Id)
 		if err != nil {
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
 			return
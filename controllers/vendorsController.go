@@ -0,0 +1,402 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateVendor Add a vendor
+//
+//	@Summary		Create a new vendor
+//	@Description	Create a new vendor. New vendors start out unapproved and not invoiced
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			vendor	body	model.ProposedVendor	true	"Vendor data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/vendor [post]
+func (g *GironService) CreateVendor(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedVendor
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if json.CompanyName == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "companyName is required"})
+			return
+		}
+
+		s, err := model.CreateVendor(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Vendor has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetVendors Retrieve list of all vendors
+//
+//	@Summary		Retrieve list of all vendors
+//	@Description	Retrieve list of all vendors, approved or not
+//	@Tags			vendors
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.VendorList
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/vendors [get]
+func (g *GironService) GetVendors(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		entries, err := model.GetVendors()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of vendors: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Returned list of vendors")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": entries})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetVendorById Retrieve vendor by Id
+//
+//	@Summary		Retrieve vendor by Id
+//	@Description	Retrieve vendor by Id
+//	@Tags			vendors
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Vendor
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/vendor/{id} [get]
+func (g *GironService) GetVendorById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		ent, err := model.GetVendorById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with vendor id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateVendorById Update the details of a vendor
+//
+//	@Summary		Update a vendor
+//	@Description	Update the details of a vendor
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Param			json	body	model.ProposedVendor	true	"Vendor data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/vendor/{id} [patch]
+func (g *GironService) UpdateVendorById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.ProposedVendor
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.CompanyName == "" {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "companyName is required"})
+			return
+		}
+
+		status, err := model.UpdateVendorById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Vendor Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with vendor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteVendorById Delete a vendor by its Id
+//
+//	@Summary		Delete a vendor by Id
+//	@Description	Delete a vendor by Id. Its booth, if any, is freed
+//	@Tags			vendors
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/vendor/{id} [delete]
+func (g *GironService) DeleteVendorById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.DeleteVendorById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete vendor: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove vendor! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Vendor Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with vendor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetApprovalStateVendorById Set the approval state of a vendor
+//
+//	@Summary		Set vendor approval state
+//	@Description	Approve or unapprove a vendor
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Param			json	body	model.VendorHallApproval	true	"Approval state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/vendor/{id}/approve [post]
+func (g *GironService) SetApprovalStateVendorById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.VendorHallApproval
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetApprovalStateVendorById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Vendor approval state set to '" + strconv.FormatBool(json.State) + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with vendor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// SetVendorInvoiceState Move a vendor along the invoicing workflow
+//
+//	@Summary		Set vendor invoice state
+//	@Description	Mark an approved vendor as "invoiced", then as "fulfilled" once paid. Skipping or repeating a step is refused
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Param			json	body	model.InvoiceStateChange	true	"Invoice state"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/vendor/{id}/invoice [post]
+func (g *GironService) SetVendorInvoiceState(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.InvoiceStateChange
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		status, err := model.SetVendorInvoiceState(id, json, userObject.Id)
+		if err != nil {
+			var badTransition *model.InvalidInvoiceTransition
+			if errors.As(err, &badTransition) {
+				c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+				return
+			}
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Vendor invoice state set to '" + json.State + "'"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with vendor id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// setVendorBooth Shared body of the booth assignment handlers
+func (g *GironService) setVendorBooth(c *gin.Context, id int, json model.BoothAssignment) {
+	userObject, _ := g.GetUserId(c)
+	status, err := model.SetVendorBooth(id, json, userObject.Id)
+	if err != nil {
+		var noBooth *model.NoSuchBooth
+		if errors.As(err, &noBooth) {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var inUse *model.BoothInUse
+		if errors.As(err, &inUse) {
+			c.IndentedJSON(http.StatusConflict, gin.H{"error": string(err.Error())})
+			return
+		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+		return
+	}
+
+	if status {
+		c.IndentedJSON(http.StatusOK, gin.H{"message": "Booth for vendor Id '" + strconv.Itoa(id) + "' updated"})
+	} else {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with vendor id " + strconv.Itoa(id)})
+	}
+}
+
+// AssignVendorBooth Give a vendor a booth
+//
+//	@Summary		Assign a booth to a vendor
+//	@Description	Give a vendor a booth, replacing the one it held before. A booth can only be held by one vendor or artist at a time
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Param			json	body	model.BoothAssignment	true	"Booth to assign"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Failure		409	{object}	model.FailureMsg
+//	@Router			/vendor/{id}/booth [post]
+func (g *GironService) AssignVendorBooth(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		var json model.BoothAssignment
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.BoothId == 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "boothId is required"})
+			return
+		}
+
+		g.setVendorBooth(c, id, json)
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UnassignVendorBooth Take away the booth of a vendor
+//
+//	@Summary		Unassign the booth of a vendor
+//	@Description	Free the booth held by a vendor
+//	@Tags			vendors
+//	@Produce		json
+//	@Param			id	path	string	true	"Vendor Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/vendor/{id}/booth [delete]
+func (g *GironService) UnassignVendorBooth(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		g.setVendorBooth(c, id, model.BoothAssignment{})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
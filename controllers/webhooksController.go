@@ -0,0 +1,306 @@
+package controllers
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// a secret shorter than this is too easy to guess from signed payloads
+	minWebhookSecretLength = 16
+	defaultDeliveryLimit   = 50
+	maxDeliveryLimit       = 500
+)
+
+// validateWebhook Checks the URL and event types of a webhook
+func validateWebhook(rawUrl string, eventTypes []string) error {
+	u, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if len(eventTypes) == 0 {
+		return errors.New("at least one event type is required, one of: " + strings.Join(model.WebhookEventTypes, ", "))
+	}
+	for _, eventType := range eventTypes {
+		if !model.IsWebhookEventType(eventType) {
+			return errors.New("unknown event type '" + eventType + "', must be one of: " + strings.Join(model.WebhookEventTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// CreateWebhook Subscribe a URL to events
+//
+//	@Summary		Create a new webhook
+//	@Description	Subscribe a URL to events. Deliveries are signed with the secret as an HMAC-SHA256 of the body in the X-Giron-Signature header
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body	model.ProposedWebhook	true	"Webhook data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Router			/webhook [post]
+func (g *GironService) CreateWebhook(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		var json model.ProposedWebhook
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := validateWebhook(json.Url, json.EventTypes); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if len(json.Secret) < minWebhookSecretLength {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "secret must be at least " + strconv.Itoa(minWebhookSecretLength) + " characters"})
+			return
+		}
+
+		s, err := model.CreateWebhook(json, userObject.Id)
+		if s {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Webhook has been added to system"})
+		} else {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetWebhooks Retrieve list of all webhooks
+//
+//	@Summary		Retrieve list of all webhooks
+//	@Description	Retrieve list of all webhooks and the events they are subscribed to. Secrets are never returned
+//	@Tags			webhooks
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.WebhookList
+//	@Failure		500	{object}	model.FailureMsg
+//	@Router			/webhooks [get]
+func (g *GironService) GetWebhooks(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		webhooks, err := model.GetWebhooks()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve list of webhooks: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		log.Println("INFO: Returned list of webhooks")
+		c.IndentedJSON(http.StatusOK, gin.H{"data": webhooks})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetWebhookById Retrieve webhook by Id
+//
+//	@Summary		Retrieve webhook by Id
+//	@Description	Retrieve webhook by Id
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id	path	string	true	"Webhook Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.Webhook
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/webhook/{id} [get]
+func (g *GironService) GetWebhookById(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		ent, err := model.GetWebhookById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with webhook id " + strconv.Itoa(id)})
+		} else {
+			c.IndentedJSON(http.StatusOK, ent)
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// UpdateWebhookById Update a webhook
+//
+//	@Summary		Update a webhook
+//	@Description	Update the URL, event types and active flag of a webhook. The secret is only changed if one is given
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"Webhook Id"
+//	@Param			json	body	model.WebhookUpdate	true	"Webhook data"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/webhook/{id} [patch]
+func (g *GironService) UpdateWebhookById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		var json model.WebhookUpdate
+		if err := c.ShouldBindJSON(&json); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if err := validateWebhook(json.Url, json.EventTypes); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		if json.Secret != "" && len(json.Secret) < minWebhookSecretLength {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "secret must be at least " + strconv.Itoa(minWebhookSecretLength) + " characters"})
+			return
+		}
+
+		status, err := model.UpdateWebhookById(id, json, userObject.Id)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Webhook Id '" + strconv.Itoa(id) + "' updated"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with webhook id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// DeleteWebhookById Delete a webhook by its Id
+//
+//	@Summary		Delete a webhook by Id
+//	@Description	Delete a webhook by Id, together with its delivery log
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id	path	string	true	"Webhook Id"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.SuccessMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/webhook/{id} [delete]
+func (g *GironService) DeleteWebhookById(c *gin.Context) {
+	userObject, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		status, err := model.DeleteWebhookById(id, userObject.Id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete webhook: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Unable to remove webhook! " + string(err.Error())})
+			return
+		}
+
+		if status {
+			c.IndentedJSON(http.StatusOK, gin.H{"message": "Webhook Id '" + strconv.Itoa(id) + "' has been removed from system"})
+		} else {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with webhook id " + strconv.Itoa(id)})
+		}
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
+
+// GetWebhookDeliveries Retrieve the delivery log of a webhook
+//
+//	@Summary		Retrieve the delivery log of a webhook
+//	@Description	Retrieve the deliveries of a webhook, newest first, with their attempts, last response status and error
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id		path	string	true	"Webhook Id"
+//	@Param			limit	query	int		false	"Maximum number of deliveries to return (default 50, max 500)"
+//	@Param			offset	query	int		false	"Number of deliveries to skip"
+//	@Security		BasicAuth
+//	@Success		200	{object}	model.WebhookDeliveryList
+//	@Failure		400	{object}	model.FailureMsg
+//	@Failure		404	{object}	model.FailureMsg
+//	@Router			/webhook/{id}/deliveries [get]
+func (g *GironService) GetWebhookDeliveries(c *gin.Context) {
+	_, authed := g.GetUserId(c)
+	if authed {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": string(err.Error())})
+			return
+		}
+		limit, err := queryInt(c, "limit")
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid limit: " + string(err.Error())})
+			return
+		}
+		if limit <= 0 {
+			limit = defaultDeliveryLimit
+		} else if limit > maxDeliveryLimit {
+			limit = maxDeliveryLimit
+		}
+		offset, err := queryInt(c, "offset")
+		if err != nil || offset < 0 {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "offset must be a positive number"})
+			return
+		}
+
+		ent, err := model.GetWebhookById(id)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+		if ent.Id == 0 {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "no records found with webhook id " + strconv.Itoa(id)})
+			return
+		}
+
+		deliveries, err := model.GetWebhookDeliveries(id, limit, offset)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve webhook deliveries: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": string(err.Error())})
+			return
+		}
+
+		c.IndentedJSON(http.StatusOK, gin.H{"data": deliveries})
+	} else {
+		c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+	}
+}
@@ -0,0 +1,28 @@
+package db
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import "embed"
+
+// Migrations holds the versioned schema migrations compiled into the binary.
+// Files are named NNNN_description.up.sql and NNNN_description.down.sql, where
+// NNNN is the schema version the migration brings the database to.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
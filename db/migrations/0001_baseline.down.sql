@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS VideoScreeningTagAssignments;
+DROP TABLE IF EXISTS VideoScreenings;
+DROP TABLE IF EXISTS VideoScreeningRatings;
+DROP TABLE IF EXISTS Vendors;
+DROP TABLE IF EXISTS Users;
+DROP TABLE IF EXISTS Tags;
+DROP TABLE IF EXISTS Roles;
+DROP TABLE IF EXISTS Privileges;
+DROP TABLE IF EXISTS PrivilegeAssignments;
+DROP TABLE IF EXISTS PanelTagAssignments;
+DROP TABLE IF EXISTS Panels;
+DROP TABLE IF EXISTS PanelRatings;
+DROP TABLE IF EXISTS Panelists;
+DROP TABLE IF EXISTS Locations;
+DROP TABLE IF EXISTS LiveEventTagAssignments;
+DROP TABLE IF EXISTS LiveEvents;
+DROP TABLE IF EXISTS LiveEventRatings;
+DROP TABLE IF EXISTS Exhibitors;
+DROP TABLE IF EXISTS Buildings;
+DROP TABLE IF EXISTS BuildingFloors;
+DROP TABLE IF EXISTS Booths;
+DROP TABLE IF EXISTS Audit;
+DROP TABLE IF EXISTS Artists;
@@ -1,14 +1,8 @@
---
--- File generated with SQLiteStudio v3.4.4 on Sat Apr 12 11:31:33 2025
---
--- Text encoding used: UTF-8
---
-PRAGMA foreign_keys = off;
-BEGIN TRANSACTION;
+-- Baseline schema, as shipped in db/schema.sql before versioned migrations.
+-- Databases created from that dump are stamped with this version on first
+-- start instead of having it applied.
 
 -- Table: Artists
-DROP TABLE IF EXISTS Artists;
-
 CREATE TABLE IF NOT EXISTS Artists (
     Id               INTEGER  PRIMARY KEY AUTOINCREMENT
                               UNIQUE
@@ -37,8 +31,6 @@ CREATE TABLE IF NOT EXISTS Artists (
 
 
 -- Table: Audit
-DROP TABLE IF EXISTS Audit;
-
 CREATE TABLE IF NOT EXISTS Audit (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           UNIQUE
@@ -53,8 +45,6 @@ CREATE TABLE IF NOT EXISTS Audit (
 
 
 -- Table: Booths
-DROP TABLE IF EXISTS Booths;
-
 CREATE TABLE IF NOT EXISTS Booths (
     Id           INTEGER  NOT NULL
                           UNIQUE
@@ -69,8 +59,6 @@ CREATE TABLE IF NOT EXISTS Booths (
 
 
 -- Table: BuildingFloors
-DROP TABLE IF EXISTS BuildingFloors;
-
 CREATE TABLE IF NOT EXISTS BuildingFloors (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           UNIQUE
@@ -87,8 +75,6 @@ CREATE TABLE IF NOT EXISTS BuildingFloors (
 
 
 -- Table: Buildings
-DROP TABLE IF EXISTS Buildings;
-
 CREATE TABLE IF NOT EXISTS Buildings (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           UNIQUE
@@ -105,8 +91,6 @@ CREATE TABLE IF NOT EXISTS Buildings (
 
 
 -- Table: Exhibitors
-DROP TABLE IF EXISTS Exhibitors;
-
 CREATE TABLE IF NOT EXISTS Exhibitors (
     Id               INTEGER  PRIMARY KEY AUTOINCREMENT
                               UNIQUE
@@ -133,8 +117,6 @@ CREATE TABLE IF NOT EXISTS Exhibitors (
 
 
 -- Table: LiveEventRatings
-DROP TABLE IF EXISTS LiveEventRatings;
-
 CREATE TABLE IF NOT EXISTS LiveEventRatings (
     Id          INTEGER PRIMARY KEY AUTOINCREMENT
                         UNIQUE
@@ -149,8 +131,6 @@ CREATE TABLE IF NOT EXISTS LiveEventRatings (
 
 
 -- Table: LiveEvents
-DROP TABLE IF EXISTS LiveEvents;
-
 CREATE TABLE IF NOT EXISTS LiveEvents (
     Id                INTEGER  PRIMARY KEY AUTOINCREMENT
                                NOT NULL
@@ -176,8 +156,6 @@ CREATE TABLE IF NOT EXISTS LiveEvents (
 
 
 -- Table: LiveEventTagAssignments
-DROP TABLE IF EXISTS LiveEventTagAssignments;
-
 CREATE TABLE IF NOT EXISTS LiveEventTagAssignments (
     Id          INTEGER PRIMARY KEY AUTOINCREMENT
                         UNIQUE
@@ -190,8 +168,6 @@ CREATE TABLE IF NOT EXISTS LiveEventTagAssignments (
 
 
 -- Table: Locations
-DROP TABLE IF EXISTS Locations;
-
 CREATE TABLE IF NOT EXISTS Locations (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           NOT NULL
@@ -210,8 +186,6 @@ CREATE TABLE IF NOT EXISTS Locations (
 
 
 -- Table: Panelists
-DROP TABLE IF EXISTS Panelists;
-
 CREATE TABLE IF NOT EXISTS Panelists (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           UNIQUE
@@ -229,8 +203,6 @@ CREATE TABLE IF NOT EXISTS Panelists (
 
 
 -- Table: PanelRatings
-DROP TABLE IF EXISTS PanelRatings;
-
 CREATE TABLE IF NOT EXISTS PanelRatings (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           UNIQUE
@@ -246,8 +218,6 @@ CREATE TABLE IF NOT EXISTS PanelRatings (
 
 
 -- Table: Panels
-DROP TABLE IF EXISTS Panels;
-
 CREATE TABLE IF NOT EXISTS Panels (
     Id                  INTEGER  PRIMARY KEY AUTOINCREMENT
                                  NOT NULL
@@ -275,8 +245,6 @@ CREATE TABLE IF NOT EXISTS Panels (
 
 
 -- Table: PanelTagAssignments
-DROP TABLE IF EXISTS PanelTagAssignments;
-
 CREATE TABLE IF NOT EXISTS PanelTagAssignments (
     Id      INTEGER PRIMARY KEY AUTOINCREMENT
                     UNIQUE
@@ -289,8 +257,6 @@ CREATE TABLE IF NOT EXISTS PanelTagAssignments (
 
 
 -- Table: PrivilegeAssignments
-DROP TABLE IF EXISTS PrivilegeAssignments;
-
 CREATE TABLE IF NOT EXISTS PrivilegeAssignments (
     Id     INTEGER PRIMARY KEY AUTOINCREMENT
                    UNIQUE
@@ -303,8 +269,6 @@ CREATE TABLE IF NOT EXISTS PrivilegeAssignments (
 
 
 -- Table: Privileges
-DROP TABLE IF EXISTS Privileges;
-
 CREATE TABLE IF NOT EXISTS Privileges (
     Id              INTEGER PRIMARY KEY AUTOINCREMENT
                             UNIQUE
@@ -316,8 +280,6 @@ CREATE TABLE IF NOT EXISTS Privileges (
 
 
 -- Table: Roles
-DROP TABLE IF EXISTS Roles;
-
 CREATE TABLE IF NOT EXISTS Roles (
     Id           INTEGER  PRIMARY KEY AUTOINCREMENT
                           UNIQUE
@@ -330,8 +292,6 @@ CREATE TABLE IF NOT EXISTS Roles (
 
 
 -- Table: Tags
-DROP TABLE IF EXISTS Tags;
-
 CREATE TABLE IF NOT EXISTS Tags (
     Id      INTEGER PRIMARY KEY AUTOINCREMENT,
     TagName STRING  UNIQUE
@@ -340,8 +300,6 @@ CREATE TABLE IF NOT EXISTS Tags (
 
 
 -- Table: Users
-DROP TABLE IF EXISTS Users;
-
 CREATE TABLE IF NOT EXISTS Users (
     Id              INTEGER  PRIMARY KEY AUTOINCREMENT
                              NOT NULL
@@ -359,8 +317,6 @@ CREATE TABLE IF NOT EXISTS Users (
 
 
 -- Table: Vendors
-DROP TABLE IF EXISTS Vendors;
-
 CREATE TABLE IF NOT EXISTS Vendors (
     Id               INTEGER  PRIMARY KEY AUTOINCREMENT
                               UNIQUE
@@ -388,8 +344,6 @@ CREATE TABLE IF NOT EXISTS Vendors (
 
 
 -- Table: VideoScreeningRatings
-DROP TABLE IF EXISTS VideoScreeningRatings;
-
 CREATE TABLE IF NOT EXISTS VideoScreeningRatings (
     Id               INTEGER  PRIMARY KEY AUTOINCREMENT
                               NOT NULL
@@ -406,8 +360,6 @@ CREATE TABLE IF NOT EXISTS VideoScreeningRatings (
 
 
 -- Table: VideoScreenings
-DROP TABLE IF EXISTS VideoScreenings;
-
 CREATE TABLE IF NOT EXISTS VideoScreenings (
     Id                INTEGER  PRIMARY KEY AUTOINCREMENT
                                NOT NULL,
@@ -428,8 +380,6 @@ CREATE TABLE IF NOT EXISTS VideoScreenings (
 
 
 -- Table: VideoScreeningTagAssignments
-DROP TABLE IF EXISTS VideoScreeningTagAssignments;
-
 CREATE TABLE IF NOT EXISTS VideoScreeningTagAssignments (
     Id               INTEGER PRIMARY KEY AUTOINCREMENT
                              UNIQUE
@@ -439,7 +389,3 @@ CREATE TABLE IF NOT EXISTS VideoScreeningTagAssignments (
     VideoScreeningId INTEGER REFERENCES VideoScreenings (Id) 
                              NOT NULL
 );
-
-
-COMMIT TRANSACTION;
-PRAGMA foreign_keys = on;
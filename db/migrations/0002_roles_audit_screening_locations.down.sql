@@ -0,0 +1,45 @@
+CREATE TABLE VideoScreenings_old (
+    Id                INTEGER  PRIMARY KEY AUTOINCREMENT
+                               NOT NULL,
+    Title             STRING   NOT NULL,
+    Synopsis          TEXT     NOT NULL,
+    Location          STRING,
+    ScheduledTime     DATETIME,
+    DurationInMinutes INTEGER  NOT NULL,
+    AgeRestricted     BOOL     NOT NULL
+                               DEFAULT (FALSE),
+    Rating            INTEGER  NOT NULL
+                               DEFAULT (0),
+    CreatorId         INTEGER  REFERENCES Users (Id) 
+                               NOT NULL,
+    CreationDateTime  DATETIME NOT NULL
+                               DEFAULT (CURRENT_TIMESTAMP) 
+);
+
+INSERT INTO VideoScreenings_old (Id, Title, Synopsis, Location, ScheduledTime, DurationInMinutes, AgeRestricted, Rating, CreatorId, CreationDateTime)
+    SELECT s.Id, s.Title, s.Synopsis, l.RoomName, s.ScheduledTime, s.DurationInMinutes, s.AgeRestricted, s.Rating, s.CreatorId, s.CreationDateTime
+    FROM VideoScreenings s LEFT JOIN Locations l ON l.Id = s.LocationId;
+DROP TABLE VideoScreenings;
+ALTER TABLE VideoScreenings_old RENAME TO VideoScreenings;
+
+CREATE TABLE Audit_old (
+    Id           INTEGER  PRIMARY KEY AUTOINCREMENT
+                          UNIQUE
+                          NOT NULL,
+    ChangedById  INTEGER  REFERENCES Users (Id) 
+                          NOT NULL,
+    TableChanged STRING   NOT NULL,
+    ChangeClass  STRING   NOT NULL,
+    ChangeDate   DATETIME NOT NULL
+                          DEFAULT (CURRENT_TIMESTAMP) 
+);
+
+INSERT INTO Audit_old (Id, ChangedById, TableChanged, ChangeClass, ChangeDate)
+    SELECT Id, ChangedById, TableChanged, ChangeClass, ChangeDate FROM Audit;
+DROP TABLE Audit;
+ALTER TABLE Audit_old RENAME TO Audit;
+
+DELETE FROM PrivilegeAssignments;
+DELETE FROM Roles;
+DELETE FROM Privileges;
+DROP TABLE UserRoleAssignments;
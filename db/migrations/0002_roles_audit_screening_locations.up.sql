@@ -0,0 +1,65 @@
+-- Role assignments and seed privileges/roles for access control. The first
+-- administrator has to be granted by hand, e.g.:
+--   INSERT INTO UserRoleAssignments (UserId, RoleId) VALUES (1, 1);
+CREATE TABLE UserRoleAssignments (
+    Id     INTEGER PRIMARY KEY AUTOINCREMENT
+                   UNIQUE
+                   NOT NULL,
+    UserId INTEGER REFERENCES Users (Id) 
+                   NOT NULL,
+    RoleId INTEGER REFERENCES Roles (Id) 
+                   NOT NULL
+);
+
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (1, 'venue.manage', 'Create, update and delete buildings, floors and locations');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (2, 'panels.manage', 'Create, schedule, relocate, tag and delete panels');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (3, 'panels.approve', 'Approve or unapprove panels');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (4, 'screenings.manage', 'Create, schedule, relocate, tag and delete video screenings');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (5, 'tags.manage', 'Create, update and delete tags');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (6, 'users.admin', 'Manage user accounts, roles and role assignments');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (7, 'audit.read', 'Read the audit trail of changes');
+
+INSERT INTO Roles (Id, RoleName, Description, CreationDate) VALUES (1, 'admin', 'Full administrative access', CURRENT_TIMESTAMP);
+INSERT INTO Roles (Id, RoleName, Description, CreationDate) VALUES (2, 'programming', 'Manages and approves programming content', CURRENT_TIMESTAMP);
+
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 1);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 2);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 3);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 4);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 5);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 6);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 7);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 2);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 3);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 4);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 5);
+
+-- before and after values for the audit trail
+ALTER TABLE Audit ADD COLUMN RecordId INTEGER;
+ALTER TABLE Audit ADD COLUMN BeforeValue TEXT;
+ALTER TABLE Audit ADD COLUMN AfterValue TEXT;
+
+-- screenings reference a location by Id instead of a free-form name. The old
+-- column was never written by the service, so it is not carried over.
+CREATE TABLE VideoScreenings_new (
+    Id                INTEGER  PRIMARY KEY AUTOINCREMENT
+                               NOT NULL,
+    Title             STRING   NOT NULL,
+    Synopsis          TEXT     NOT NULL,
+    LocationId        INTEGER  REFERENCES Locations (Id),
+    ScheduledTime     DATETIME,
+    DurationInMinutes INTEGER  NOT NULL,
+    AgeRestricted     BOOL     NOT NULL
+                               DEFAULT (FALSE),
+    Rating            INTEGER  NOT NULL
+                               DEFAULT (0),
+    CreatorId         INTEGER  REFERENCES Users (Id) 
+                               NOT NULL,
+    CreationDateTime  DATETIME NOT NULL
+                               DEFAULT (CURRENT_TIMESTAMP) 
+);
+
+INSERT INTO VideoScreenings_new (Id, Title, Synopsis, ScheduledTime, DurationInMinutes, AgeRestricted, Rating, CreatorId, CreationDateTime)
+    SELECT Id, Title, Synopsis, ScheduledTime, DurationInMinutes, AgeRestricted, Rating, CreatorId, CreationDateTime FROM VideoScreenings;
+DROP TABLE VideoScreenings;
+ALTER TABLE VideoScreenings_new RENAME TO VideoScreenings;
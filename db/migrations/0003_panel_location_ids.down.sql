@@ -0,0 +1,3 @@
+-- Resolved location Ids are valid under the previous schema as well and the
+-- original room names are not kept, so there is nothing to revert.
+SELECT 1;
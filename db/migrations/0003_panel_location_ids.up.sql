@@ -0,0 +1,12 @@
+-- Panels were historically placed by room name rather than by location Id.
+-- Resolve any room names left in LocationId to the matching location, then
+-- clear references that don't point at a location at all so every panel
+-- either has a valid location or none.
+UPDATE Panels
+   SET LocationId = (SELECT l.Id FROM Locations l WHERE l.RoomName = Panels.LocationId)
+ WHERE typeof(LocationId) = 'text';
+
+UPDATE Panels
+   SET LocationId = NULL
+ WHERE LocationId IS NOT NULL
+   AND LocationId NOT IN (SELECT Id FROM Locations);
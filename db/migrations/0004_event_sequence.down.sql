@@ -0,0 +1,53 @@
+-- SQLite cannot drop columns, so rebuild both tables without Sequence
+CREATE TABLE Panels_old (
+    Id                  INTEGER  PRIMARY KEY AUTOINCREMENT
+                                 NOT NULL
+                                 UNIQUE,
+    Topic               STRING   NOT NULL,
+    Description         TEXT     NOT NULL,
+    PanelRequestorEmail STRING   NOT NULL,
+    LocationId          INTEGER  REFERENCES Locations (Id),
+    ScheduledTime       DATETIME,
+    DurationInMinutes   INTEGER  NOT NULL
+                                 DEFAULT (30),
+    Rating              REAL     NOT NULL
+                                 DEFAULT (0),
+    AgeRestricted       BOOL     NOT NULL
+                                 DEFAULT (FALSE),
+    CreatorId           INTEGER  NOT NULL
+                                 REFERENCES Users (Id),
+    CreationDateTime    DATETIME NOT NULL
+                                 DEFAULT (CURRENT_TIMESTAMP),
+    ApprovalStatus      BOOL     NOT NULL
+                                 DEFAULT (FALSE),
+    ApprovedById        INTEGER  REFERENCES Users (Id),
+    ApprovalDateTime    DATETIME DEFAULT ""
+);
+
+INSERT INTO Panels_old (Id, Topic, Description, PanelRequestorEmail, LocationId, ScheduledTime, DurationInMinutes, Rating, AgeRestricted, CreatorId, CreationDateTime, ApprovalStatus, ApprovedById, ApprovalDateTime)
+    SELECT Id, Topic, Description, PanelRequestorEmail, LocationId, ScheduledTime, DurationInMinutes, Rating, AgeRestricted, CreatorId, CreationDateTime, ApprovalStatus, ApprovedById, ApprovalDateTime FROM Panels;
+DROP TABLE Panels;
+ALTER TABLE Panels_old RENAME TO Panels;
+
+CREATE TABLE VideoScreenings_old (
+    Id                INTEGER  PRIMARY KEY AUTOINCREMENT
+                               NOT NULL,
+    Title             STRING   NOT NULL,
+    Synopsis          TEXT     NOT NULL,
+    LocationId        INTEGER  REFERENCES Locations (Id),
+    ScheduledTime     DATETIME,
+    DurationInMinutes INTEGER  NOT NULL,
+    AgeRestricted     BOOL     NOT NULL
+                               DEFAULT (FALSE),
+    Rating            INTEGER  NOT NULL
+                               DEFAULT (0),
+    CreatorId         INTEGER  REFERENCES Users (Id) 
+                               NOT NULL,
+    CreationDateTime  DATETIME NOT NULL
+                               DEFAULT (CURRENT_TIMESTAMP) 
+);
+
+INSERT INTO VideoScreenings_old (Id, Title, Synopsis, LocationId, ScheduledTime, DurationInMinutes, AgeRestricted, Rating, CreatorId, CreationDateTime)
+    SELECT Id, Title, Synopsis, LocationId, ScheduledTime, DurationInMinutes, AgeRestricted, Rating, CreatorId, CreationDateTime FROM VideoScreenings;
+DROP TABLE VideoScreenings;
+ALTER TABLE VideoScreenings_old RENAME TO VideoScreenings;
@@ -0,0 +1,4 @@
+-- iCalendar revision number, bumped whenever an event moves in time or space
+-- so subscribed calendars pick up the change
+ALTER TABLE Panels ADD COLUMN Sequence INTEGER NOT NULL DEFAULT (0);
+ALTER TABLE VideoScreenings ADD COLUMN Sequence INTEGER NOT NULL DEFAULT (0);
@@ -0,0 +1,4 @@
+DROP INDEX LiveEventRatingsByUser;
+
+DELETE FROM PrivilegeAssignments WHERE PrivId IN (8, 9);
+DELETE FROM Privileges WHERE Id IN (8, 9);
@@ -0,0 +1,12 @@
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (8, 'liveevents.manage', 'Create, edit, schedule, relocate, tag and delete live events');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (9, 'liveevents.approve', 'Approve or unapprove live events');
+
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 8);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 9);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 8);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 9);
+
+-- one rating per user per live event; keep the latest if there are several
+DELETE FROM LiveEventRatings
+ WHERE Id NOT IN (SELECT MAX(Id) FROM LiveEventRatings GROUP BY UserId, LiveEventId);
+CREATE UNIQUE INDEX LiveEventRatingsByUser ON LiveEventRatings (UserId, LiveEventId);
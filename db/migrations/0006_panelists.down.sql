@@ -0,0 +1,2 @@
+DROP INDEX PanelistsByEmail;
+DROP INDEX PanelistsByPanel;
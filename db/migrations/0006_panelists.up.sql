@@ -0,0 +1,6 @@
+-- panelists are matched across panels by email address
+UPDATE Panelists SET EmailAddress = LOWER(TRIM(EmailAddress));
+DELETE FROM Panelists
+ WHERE Id NOT IN (SELECT MIN(Id) FROM Panelists GROUP BY PanelId, EmailAddress);
+CREATE UNIQUE INDEX PanelistsByPanel ON Panelists (PanelId, EmailAddress);
+CREATE INDEX PanelistsByEmail ON Panelists (EmailAddress);
@@ -0,0 +1,5 @@
+DROP INDEX VideoScreeningRatingsByUser;
+DROP INDEX PanelRatingsByUser;
+
+DELETE FROM PrivilegeAssignments WHERE PrivId = 10;
+DELETE FROM Privileges WHERE Id = 10;
@@ -0,0 +1,19 @@
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (10, 'reports.read', 'Read post-convention reports such as top-rated events');
+
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 10);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (2, 10);
+
+-- one rating per user per event; keep the latest if there are several
+DELETE FROM PanelRatings
+ WHERE Id NOT IN (SELECT MAX(Id) FROM PanelRatings GROUP BY UserId, PanelId);
+CREATE UNIQUE INDEX PanelRatingsByUser ON PanelRatings (UserId, PanelId);
+
+DELETE FROM VideoScreeningRatings
+ WHERE Id NOT IN (SELECT MAX(Id) FROM VideoScreeningRatings GROUP BY UserId, VideoScreeningId);
+CREATE UNIQUE INDEX VideoScreeningRatingsByUser ON VideoScreeningRatings (UserId, VideoScreeningId);
+
+-- the stored aggregates were never written, so bring them in line with the ratings
+UPDATE Panels
+   SET Rating = IFNULL((SELECT AVG(Rating) FROM PanelRatings WHERE PanelId = Panels.Id), 0);
+UPDATE VideoScreenings
+   SET Rating = IFNULL((SELECT AVG(Rating) FROM VideoScreeningRatings WHERE VideoScreeningId = VideoScreenings.Id), 0);
@@ -0,0 +1,21 @@
+DROP INDEX VendorsByBooth;
+
+-- SQLite cannot drop columns, so rebuild Booths without Label
+CREATE TABLE Booths_old (
+    Id           INTEGER  NOT NULL
+                          UNIQUE
+                          PRIMARY KEY AUTOINCREMENT,
+    LocationId   INTEGER  REFERENCES Locations (Id) 
+                          NOT NULL,
+    CreatorId    INTEGER  REFERENCES Users (Id) 
+                          NOT NULL,
+    CreationDate DATETIME NOT NULL
+                          DEFAULT (CURRENT_TIMESTAMP) 
+);
+INSERT INTO Booths_old (Id, LocationId, CreatorId, CreationDate)
+    SELECT Id, LocationId, CreatorId, CreationDate FROM Booths;
+DROP TABLE Booths;
+ALTER TABLE Booths_old RENAME TO Booths;
+
+DELETE FROM PrivilegeAssignments WHERE PrivId IN (11, 12);
+DELETE FROM Privileges WHERE Id IN (11, 12);
@@ -0,0 +1,15 @@
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (11, 'vendors.manage', 'Create, edit and delete vendors, artists, exhibitors and booths, assign booths and track invoices');
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (12, 'vendors.approve', 'Approve or unapprove vendors, artists and exhibitors');
+
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 11);
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 12);
+
+-- booths need something the dealers' room team can put on a map
+ALTER TABLE Booths ADD COLUMN Label STRING NOT NULL DEFAULT ('');
+
+-- a booth goes to at most one vendor or artist; artists already have a unique
+-- BoothId, so clear any vendor that shares a booth with an artist or another vendor
+UPDATE Vendors SET BoothId = NULL
+ WHERE BoothId IN (SELECT BoothId FROM Artists WHERE BoothId IS NOT NULL)
+    OR Id NOT IN (SELECT MIN(Id) FROM Vendors GROUP BY BoothId);
+CREATE UNIQUE INDEX VendorsByBooth ON Vendors (BoothId);
@@ -0,0 +1,36 @@
+DROP INDEX IF EXISTS PanelReviewLogByPanel;
+DROP TABLE IF EXISTS PanelReviewLog;
+DROP INDEX IF EXISTS PanelsByState;
+
+-- SQLite cannot drop columns, so rebuild Panels without State
+CREATE TABLE Panels_old (
+    Id                  INTEGER  PRIMARY KEY AUTOINCREMENT
+                                 NOT NULL
+                                 UNIQUE,
+    Topic               STRING   NOT NULL,
+    Description         TEXT     NOT NULL,
+    PanelRequestorEmail STRING   NOT NULL,
+    LocationId          INTEGER  REFERENCES Locations (Id),
+    ScheduledTime       DATETIME,
+    DurationInMinutes   INTEGER  NOT NULL
+                                 DEFAULT (30),
+    Rating              REAL     NOT NULL
+                                 DEFAULT (0),
+    AgeRestricted       BOOL     NOT NULL
+                                 DEFAULT (FALSE),
+    CreatorId           INTEGER  NOT NULL
+                                 REFERENCES Users (Id),
+    CreationDateTime    DATETIME NOT NULL
+                                 DEFAULT (CURRENT_TIMESTAMP),
+    ApprovalStatus      BOOL     NOT NULL
+                                 DEFAULT (FALSE),
+    ApprovedById        INTEGER  REFERENCES Users (Id),
+    ApprovalDateTime    DATETIME DEFAULT "",
+    Sequence            INTEGER  NOT NULL
+                                 DEFAULT (0)
+);
+
+INSERT INTO Panels_old (Id, Topic, Description, PanelRequestorEmail, LocationId, ScheduledTime, DurationInMinutes, Rating, AgeRestricted, CreatorId, CreationDateTime, ApprovalStatus, ApprovedById, ApprovalDateTime, Sequence)
+    SELECT Id, Topic, Description, PanelRequestorEmail, LocationId, ScheduledTime, DurationInMinutes, Rating, AgeRestricted, CreatorId, CreationDateTime, ApprovalStatus, ApprovedById, ApprovalDateTime, Sequence FROM Panels;
+DROP TABLE Panels;
+ALTER TABLE Panels_old RENAME TO Panels;
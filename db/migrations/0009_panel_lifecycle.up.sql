@@ -0,0 +1,37 @@
+-- Panels move through submitted -> under_review -> accepted / rejected /
+-- waitlisted -> scheduled -> cancelled. ApprovalStatus is kept in step with
+-- the state (true while accepted or scheduled) for existing readers.
+ALTER TABLE Panels ADD COLUMN State STRING NOT NULL DEFAULT ('submitted');
+
+UPDATE Panels
+   SET State = CASE
+       WHEN ApprovalStatus AND ScheduledTime IS NOT NULL AND ScheduledTime != '' THEN 'scheduled'
+       WHEN ApprovalStatus THEN 'accepted'
+       ELSE 'submitted'
+   END;
+
+CREATE INDEX PanelsByState ON Panels (State);
+
+-- Table: PanelReviewLog
+-- One row per state change, or per reviewer comment when FromState and
+-- ToState are the same
+CREATE TABLE IF NOT EXISTS PanelReviewLog (
+    Id             INTEGER  PRIMARY KEY AUTOINCREMENT
+                            NOT NULL
+                            UNIQUE,
+    PanelId        INTEGER  NOT NULL
+                            REFERENCES Panels (Id),
+    FromState      STRING   NOT NULL,
+    ToState        STRING   NOT NULL,
+    Comment        TEXT     NOT NULL
+                            DEFAULT (''),
+    ReviewerId     INTEGER  NOT NULL
+                            REFERENCES Users (Id),
+    ChangeDateTime DATETIME NOT NULL
+                            DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX PanelReviewLogByPanel ON PanelReviewLog (PanelId);
+
+INSERT INTO PanelReviewLog (PanelId, FromState, ToState, ReviewerId, ChangeDateTime)
+    SELECT Id, '', State, CreatorId, CreationDateTime FROM Panels;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS PanelProposalsByPanel;
+DROP INDEX IF EXISTS PanelProposalsByEmail;
+DROP INDEX IF EXISTS PanelProposalsByAddress;
+DROP TABLE IF EXISTS PanelProposals;
+
+-- the intake account stays if panels it created are still around
+DELETE FROM Users
+ WHERE UserName = 'panel-intake'
+   AND NOT EXISTS (SELECT 1 FROM Panels WHERE CreatorId = Users.Id)
+   AND NOT EXISTS (SELECT 1 FROM PanelReviewLog WHERE ReviewerId = Users.Id)
+   AND NOT EXISTS (SELECT 1 FROM Audit WHERE ChangedById = Users.Id);
@@ -0,0 +1,31 @@
+-- Panels proposed through the public form are created by this account. It is
+-- locked and has no usable password, so nobody can sign in as it.
+INSERT INTO Users (UserName, Status, PasswordHash)
+    SELECT 'panel-intake', 'locked', '!'
+     WHERE NOT EXISTS (SELECT 1 FROM Users WHERE UserName = 'panel-intake');
+
+-- Table: PanelProposals
+-- Proposals wait here until the proposer follows the link sent to their email
+-- address, which creates the panel and sets PanelId
+CREATE TABLE IF NOT EXISTS PanelProposals (
+    Id               INTEGER  PRIMARY KEY AUTOINCREMENT
+                              NOT NULL
+                              UNIQUE,
+    Topic            STRING   NOT NULL,
+    Description      TEXT     NOT NULL,
+    Name             STRING   NOT NULL,
+    EmailAddress     STRING   NOT NULL,
+    PhoneNumber      STRING   NOT NULL
+                              DEFAULT (''),
+    RemoteAddress    STRING   NOT NULL,
+    TokenHash        STRING   NOT NULL
+                              UNIQUE,
+    CreationDateTime DATETIME NOT NULL
+                              DEFAULT (CURRENT_TIMESTAMP),
+    VerifiedDateTime DATETIME,
+    PanelId          INTEGER  REFERENCES Panels (Id)
+);
+
+CREATE INDEX PanelProposalsByAddress ON PanelProposals (RemoteAddress, CreationDateTime);
+CREATE INDEX PanelProposalsByEmail ON PanelProposals (EmailAddress, CreationDateTime);
+CREATE INDEX PanelProposalsByPanel ON PanelProposals (PanelId);
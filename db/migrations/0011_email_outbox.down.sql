@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS EmailOutboxDue;
+DROP TABLE IF EXISTS EmailOutbox;
@@ -0,0 +1,24 @@
+-- Table: EmailOutbox
+-- Emails are queued in the same transaction as the change they report and
+-- sent afterwards, so a rolled back change never sends mail and a failed send
+-- is retried rather than lost. Data holds the template values as JSON.
+CREATE TABLE IF NOT EXISTS EmailOutbox (
+    Id                  INTEGER  PRIMARY KEY AUTOINCREMENT
+                                 NOT NULL
+                                 UNIQUE,
+    Recipient           STRING   NOT NULL,
+    Template            STRING   NOT NULL,
+    Data                TEXT     NOT NULL
+                                 DEFAULT ('{}'),
+    Attempts            INTEGER  NOT NULL
+                                 DEFAULT (0),
+    LastError           TEXT     NOT NULL
+                                 DEFAULT (''),
+    NextAttemptDateTime DATETIME NOT NULL
+                                 DEFAULT (CURRENT_TIMESTAMP),
+    CreationDateTime    DATETIME NOT NULL
+                                 DEFAULT (CURRENT_TIMESTAMP),
+    SentDateTime        DATETIME
+);
+
+CREATE INDEX EmailOutboxDue ON EmailOutbox (SentDateTime, NextAttemptDateTime);
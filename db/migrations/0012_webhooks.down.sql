@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS WebhookDeliveriesByWebhook;
+DROP INDEX IF EXISTS WebhookDeliveriesDue;
+DROP TABLE IF EXISTS WebhookDeliveries;
+DROP INDEX IF EXISTS WebhookEventsByType;
+DROP TABLE IF EXISTS WebhookEvents;
+DROP TABLE IF EXISTS Webhooks;
+
+DELETE FROM PrivilegeAssignments WHERE PrivId = 13;
+DELETE FROM Privileges WHERE Id = 13;
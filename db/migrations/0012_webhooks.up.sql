@@ -0,0 +1,57 @@
+INSERT INTO Privileges (Id, PrivShortName, PrivDescription) VALUES (13, 'webhooks.manage', 'Create, edit and delete webhook subscriptions and read their delivery log');
+
+INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (1, 13);
+
+-- Table: Webhooks
+-- Secret signs every delivery and is never returned by the API
+CREATE TABLE IF NOT EXISTS Webhooks (
+    Id           INTEGER  PRIMARY KEY AUTOINCREMENT
+                          NOT NULL
+                          UNIQUE,
+    Url          STRING   NOT NULL,
+    Secret       STRING   NOT NULL,
+    Active       BOOLEAN  NOT NULL
+                          DEFAULT (TRUE),
+    CreatorId    INTEGER  REFERENCES Users (Id)
+                          NOT NULL,
+    CreationDate DATETIME NOT NULL
+                          DEFAULT (CURRENT_TIMESTAMP)
+);
+
+-- Table: WebhookEvents
+-- The event types a webhook is subscribed to
+CREATE TABLE IF NOT EXISTS WebhookEvents (
+    WebhookId INTEGER REFERENCES Webhooks (Id)
+                      NOT NULL,
+    EventType STRING  NOT NULL,
+    UNIQUE (WebhookId, EventType)
+);
+
+CREATE INDEX WebhookEventsByType ON WebhookEvents (EventType);
+
+-- Table: WebhookDeliveries
+-- Deliveries are queued in the same transaction as the change they report and
+-- posted afterwards; the row doubles as the delivery log
+CREATE TABLE IF NOT EXISTS WebhookDeliveries (
+    Id                  INTEGER  PRIMARY KEY AUTOINCREMENT
+                                 NOT NULL
+                                 UNIQUE,
+    WebhookId           INTEGER  REFERENCES Webhooks (Id)
+                                 NOT NULL,
+    EventType           STRING   NOT NULL,
+    Payload             TEXT     NOT NULL,
+    Attempts            INTEGER  NOT NULL
+                                 DEFAULT (0),
+    LastStatusCode      INTEGER  NOT NULL
+                                 DEFAULT (0),
+    LastError           TEXT     NOT NULL
+                                 DEFAULT (''),
+    NextAttemptDateTime DATETIME NOT NULL
+                                 DEFAULT (CURRENT_TIMESTAMP),
+    CreationDateTime    DATETIME NOT NULL
+                                 DEFAULT (CURRENT_TIMESTAMP),
+    DeliveredDateTime   DATETIME
+);
+
+CREATE INDEX WebhookDeliveriesDue ON WebhookDeliveries (DeliveredDateTime, NextAttemptDateTime);
+CREATE INDEX WebhookDeliveriesByWebhook ON WebhookDeliveries (WebhookId, Id);
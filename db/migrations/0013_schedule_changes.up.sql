@@ -0,0 +1,21 @@
+-- Table: ScheduleChanges
+-- Every change to the schedule of a panel or screening, as it looked right
+-- after the change. Clients streaming the schedule resume from the Id of the
+-- last change they saw.
+CREATE TABLE IF NOT EXISTS ScheduleChanges (
+    Id                INTEGER  PRIMARY KEY AUTOINCREMENT
+                               NOT NULL
+                               UNIQUE,
+    EventType         STRING   NOT NULL,
+    EventId           INTEGER  NOT NULL,
+    Change            STRING   NOT NULL,
+    Title             STRING   NOT NULL,
+    State             STRING   NOT NULL
+                               DEFAULT (''),
+    LocationId        INTEGER,
+    ScheduledTime     DATETIME,
+    DurationInMinutes INTEGER  NOT NULL
+                               DEFAULT (0),
+    ChangeDateTime    DATETIME NOT NULL
+                               DEFAULT (CURRENT_TIMESTAMP)
+);
@@ -0,0 +1,25 @@
+-- Table: SyncLog
+-- The latest change to each building, floor, location, panel, screening and
+-- tag, for clients keeping a local copy in sync. An entity has one row, moved
+-- to the end of the log whenever it changes; deleted entities keep theirs as
+-- a tombstone. Clients pass the Id of the last row they saw as their cursor.
+CREATE TABLE IF NOT EXISTS SyncLog (
+    Id             INTEGER  PRIMARY KEY AUTOINCREMENT
+                            NOT NULL
+                            UNIQUE,
+    EntityType     STRING   NOT NULL,
+    EntityId       INTEGER  NOT NULL,
+    Deleted        BOOLEAN  NOT NULL
+                            DEFAULT (FALSE),
+    ChangeDateTime DATETIME NOT NULL
+                            DEFAULT (CURRENT_TIMESTAMP),
+    UNIQUE (EntityType, EntityId)
+);
+
+-- everything that exists now is new to a client syncing from scratch
+INSERT INTO SyncLog (EntityType, EntityId) SELECT 'building', Id FROM Buildings ORDER BY Id;
+INSERT INTO SyncLog (EntityType, EntityId) SELECT 'floor', Id FROM BuildingFloors ORDER BY Id;
+INSERT INTO SyncLog (EntityType, EntityId) SELECT 'location', Id FROM Locations ORDER BY Id;
+INSERT INTO SyncLog (EntityType, EntityId) SELECT 'tag', Id FROM Tags ORDER BY Id;
+INSERT INTO SyncLog (EntityType, EntityId) SELECT 'panel', Id FROM Panels ORDER BY Id;
+INSERT INTO SyncLog (EntityType, EntityId) SELECT 'screening', Id FROM VideoScreenings ORDER BY Id;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ApiTokenScopes;
+DROP INDEX IF EXISTS ApiTokensByUser;
+DROP TABLE IF EXISTS ApiTokens;
@@ -0,0 +1,30 @@
+-- Table: ApiTokens
+-- Named tokens users create for scripts and kiosks. Only a SHA-256 hash of
+-- each token is kept; the token itself is shown once, when it is created.
+CREATE TABLE IF NOT EXISTS ApiTokens (
+    Id               INTEGER  PRIMARY KEY AUTOINCREMENT
+                              NOT NULL
+                              UNIQUE,
+    UserId           INTEGER  REFERENCES Users (Id)
+                              NOT NULL,
+    Name             STRING   NOT NULL,
+    TokenHash        STRING   NOT NULL
+                              UNIQUE,
+    ExpiryDateTime   DATETIME,
+    LastUsedDateTime DATETIME,
+    CreationDateTime DATETIME NOT NULL
+                              DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX ApiTokensByUser ON ApiTokens (UserId);
+
+-- Table: ApiTokenScopes
+-- The privileges a token is limited to. A token without scopes may use every
+-- privilege its user holds.
+CREATE TABLE IF NOT EXISTS ApiTokenScopes (
+    TokenId INTEGER REFERENCES ApiTokens (Id)
+                    NOT NULL,
+    PrivId  INTEGER REFERENCES Privileges (Id)
+                    NOT NULL,
+    UNIQUE (TokenId, PrivId)
+);
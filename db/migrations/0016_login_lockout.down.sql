@@ -0,0 +1,7 @@
+-- accounts locked out automatically would otherwise stay locked for good
+UPDATE Users SET Status = 'enabled' WHERE Id IN (SELECT UserId FROM LoginLockouts);
+
+DROP TABLE IF EXISTS LoginLockouts;
+DROP INDEX IF EXISTS LoginFailuresByAddress;
+DROP INDEX IF EXISTS LoginFailuresByUserName;
+DROP TABLE IF EXISTS LoginFailures;
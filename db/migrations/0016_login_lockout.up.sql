@@ -0,0 +1,28 @@
+-- Table: LoginFailures
+-- Failed password logins, by the user name tried and the client address. Rows
+-- older than the lockout window no longer count and are pruned.
+CREATE TABLE IF NOT EXISTS LoginFailures (
+    Id              INTEGER  PRIMARY KEY AUTOINCREMENT
+                             NOT NULL
+                             UNIQUE,
+    UserName        STRING   NOT NULL,
+    RemoteAddress   STRING   NOT NULL,
+    AttemptDateTime DATETIME NOT NULL
+                             DEFAULT (CURRENT_TIMESTAMP)
+);
+
+CREATE INDEX LoginFailuresByUserName ON LoginFailures (UserName, AttemptDateTime);
+CREATE INDEX LoginFailuresByAddress ON LoginFailures (RemoteAddress, AttemptDateTime);
+
+-- Table: LoginLockouts
+-- Accounts locked automatically after too many failed logins, and when they
+-- are unlocked again. Accounts locked by an administrator have no row here and
+-- stay locked.
+CREATE TABLE IF NOT EXISTS LoginLockouts (
+    UserId         INTEGER  PRIMARY KEY
+                            REFERENCES Users (Id)
+                            NOT NULL,
+    LockedUntil    DATETIME NOT NULL,
+    LockedDateTime DATETIME NOT NULL
+                            DEFAULT (CURRENT_TIMESTAMP)
+);
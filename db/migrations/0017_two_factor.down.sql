@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS RecoveryCodesByUser;
+DROP TABLE IF EXISTS RecoveryCodes;
+DROP TABLE IF EXISTS UserTwoFactor;
+
+-- SQLite cannot drop columns, so rebuild Roles without RequireTwoFactor
+CREATE TABLE Roles_old (
+    Id           INTEGER  PRIMARY KEY AUTOINCREMENT
+                          UNIQUE
+                          NOT NULL,
+    RoleName     STRING   NOT NULL,
+    Description  STRING   NOT NULL,
+    CreationDate DATETIME NOT NULL
+                          DEFAULT (CURRENT_TIMESTAMP)
+);
+
+INSERT INTO Roles_old (Id, RoleName, Description, CreationDate)
+    SELECT Id, RoleName, Description, CreationDate FROM Roles;
+DROP TABLE Roles;
+ALTER TABLE Roles_old RENAME TO Roles;
@@ -0,0 +1,37 @@
+-- Roles whose holders must sign in with a second factor
+ALTER TABLE Roles ADD COLUMN RequireTwoFactor BOOLEAN NOT NULL DEFAULT (FALSE);
+
+-- Table: UserTwoFactor
+-- A user's TOTP secret. Enrollment is pending until the user confirms it with
+-- a code. LastUsedStep is the time step of the last code accepted, so a code
+-- cannot be replayed.
+CREATE TABLE IF NOT EXISTS UserTwoFactor (
+    Id               INTEGER  PRIMARY KEY AUTOINCREMENT
+                              NOT NULL
+                              UNIQUE,
+    UserId           INTEGER  REFERENCES Users (Id)
+                              NOT NULL
+                              UNIQUE,
+    Secret           STRING   NOT NULL,
+    Confirmed        BOOLEAN  NOT NULL
+                              DEFAULT (FALSE),
+    LastUsedStep     INTEGER  NOT NULL
+                              DEFAULT (0),
+    CreationDateTime DATETIME NOT NULL
+                              DEFAULT (CURRENT_TIMESTAMP)
+);
+
+-- Table: RecoveryCodes
+-- Single use codes that stand in for a TOTP code when the device is lost.
+-- Only a SHA-256 hash of each code is kept.
+CREATE TABLE IF NOT EXISTS RecoveryCodes (
+    Id           INTEGER  PRIMARY KEY AUTOINCREMENT
+                          NOT NULL
+                          UNIQUE,
+    UserId       INTEGER  REFERENCES Users (Id)
+                          NOT NULL,
+    CodeHash     STRING   NOT NULL,
+    UsedDateTime DATETIME
+);
+
+CREATE INDEX RecoveryCodesByUser ON RecoveryCodes (UserId, CodeHash);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS SessionsByExpiry;
+DROP INDEX IF EXISTS SessionsByUserName;
+DROP TABLE IF EXISTS Sessions;
@@ -0,0 +1,17 @@
+-- Table: Sessions
+-- Login sessions when sessionStore is "sqlite". The cookie only carries the
+-- session Id, which is stored hashed. Rows are deleted to sign a user out
+-- everywhere, and expired rows are pruned.
+CREATE TABLE IF NOT EXISTS Sessions (
+    Id               STRING   PRIMARY KEY
+                              NOT NULL
+                              UNIQUE,
+    UserName         STRING,
+    Data             BLOB     NOT NULL,
+    CreationDateTime DATETIME NOT NULL
+                              DEFAULT (CURRENT_TIMESTAMP),
+    ExpiryDateTime   DATETIME NOT NULL
+);
+
+CREATE INDEX SessionsByUserName ON Sessions (UserName);
+CREATE INDEX SessionsByExpiry ON Sessions (ExpiryDateTime);
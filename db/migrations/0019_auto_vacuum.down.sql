@@ -0,0 +1,3 @@
+-- migrate: no-transaction
+PRAGMA auto_vacuum = NONE;
+VACUUM;
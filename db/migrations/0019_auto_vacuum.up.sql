@@ -0,0 +1,7 @@
+-- migrate: no-transaction
+-- Return the space of deleted rows to the file system. auto_vacuum used to be
+-- set on every connection, which made new connections wait for the write
+-- lock. It is a property of the database file, so set it once here. On a
+-- database that already has tables it only takes effect after a VACUUM.
+PRAGMA auto_vacuum = FULL;
+VACUUM;
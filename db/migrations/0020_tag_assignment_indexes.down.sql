@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS LiveEventTagAssignmentsByLiveEvent;
+DROP INDEX IF EXISTS VideoScreeningTagAssignmentsByScreening;
+DROP INDEX IF EXISTS PanelTagAssignmentsByPanel;
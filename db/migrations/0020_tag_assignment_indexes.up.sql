@@ -0,0 +1,4 @@
+-- Tag names are looked up per event, for single events and sync windows
+CREATE INDEX IF NOT EXISTS PanelTagAssignmentsByPanel ON PanelTagAssignments (PanelId);
+CREATE INDEX IF NOT EXISTS VideoScreeningTagAssignmentsByScreening ON VideoScreeningTagAssignments (VideoScreeningId);
+CREATE INDEX IF NOT EXISTS LiveEventTagAssignmentsByLiveEvent ON LiveEventTagAssignments (LiveEventId);
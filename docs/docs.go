@@ -22,14 +22,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/building": {
+        "/artist": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Create a new building",
+                "description": "Create a new artist. New artists start out unapproved and not invoiced",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,17 +37,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "artists"
                 ],
-                "summary": "Create a new building",
+                "summary": "Create a new artist",
                 "parameters": [
                     {
-                        "description": "Building data",
-                        "name": "building",
+                        "description": "Artist data",
+                        "name": "artist",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ProposedBuilding"
+                            "$ref": "#/definitions/model.ProposedArtist"
                         }
                     }
                 ],
@@ -67,20 +67,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/building/{id}": {
+        "/artist/{id}": {
             "get": {
-                "description": "Retrieve building by Id",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve artist by Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "artists"
                 ],
-                "summary": "Retrieve building by Id",
+                "summary": "Retrieve artist by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Building Id",
+                        "description": "Artist Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -90,11 +95,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Building"
+                            "$ref": "#/definitions/model.Artist"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
@@ -107,21 +112,18 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete a building by Id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an artist by Id. Its booth, if any, is freed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "artists"
                 ],
-                "summary": "Delete a building by Id",
+                "summary": "Delete an artist by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Building Id",
+                        "description": "Artist Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -134,8 +136,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
@@ -148,29 +150,32 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "Update building information",
+                "description": "Update the details of an artist",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "artists"
                 ],
-                "summary": "Update building information",
+                "summary": "Update an artist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Building Id",
+                        "description": "Artist Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Building data",
-                        "name": "building",
+                        "description": "Artist data",
+                        "name": "json",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.BuildingUpdate"
+                            "$ref": "#/definitions/model.ProposedArtist"
                         }
                     }
                 ],
@@ -186,29 +191,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
-                    }
-                }
-            }
-        },
-        "/buildings": {
-            "get": {
-                "description": "Retrieve list of all panels",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "buildings"
-                ],
-                "summary": "Retrieve list of all panels",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.BuildingList"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
@@ -216,14 +201,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/floor": {
+        "/artist/{id}/approve": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Create a new floor",
+                "description": "Approve or unapprove an artist",
                 "consumes": [
                     "application/json"
                 ],
@@ -231,17 +216,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "floors"
+                    "artists"
                 ],
-                "summary": "Create a new floor",
+                "summary": "Set artist approval state",
                 "parameters": [
                     {
-                        "description": "Floor data",
-                        "name": "floor",
+                        "type": "string",
+                        "description": "Artist Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval state",
+                        "name": "json",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ProposedFloor"
+                            "$ref": "#/definitions/model.VendorHallApproval"
                         }
                     }
                 ],
@@ -257,34 +249,57 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             }
         },
-        "/floor/{id}": {
-            "get": {
-                "description": "Retrieve floor based on Id",
+        "/artist/{id}/booth": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Give an artist a booth, replacing the one it held before. A booth can only be held by one vendor or artist at a time",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "floors"
+                    "artists"
                 ],
-                "summary": "Retrieve floor based on Id",
+                "summary": "Assign a booth to an artist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Floor Id",
+                        "description": "Artist Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Booth to assign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BoothAssignment"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.BuildingFloor"
+                            "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
                     "400": {
@@ -292,6 +307,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             },
@@ -301,21 +328,18 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete a floor by Id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Free the booth held by an artist",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "floors"
+                    "artists"
                 ],
-                "summary": "Delete a floor by Id",
+                "summary": "Unassign the booth of an artist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Floor Id",
+                        "description": "Artist Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -328,43 +352,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/artist/{id}/invoice": {
+            "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Update floor information",
+                "description": "Mark an approved artist as \"invoiced\", then as \"fulfilled\" once paid. Skipping or repeating a step is refused",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "floors"
+                    "artists"
                 ],
-                "summary": "Update floor information",
+                "summary": "Set artist invoice state",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Floor Id",
+                        "description": "Artist Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Floor data",
-                        "name": "floor",
+                        "description": "Invoice state",
+                        "name": "json",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.FloorUpdate"
+                            "$ref": "#/definitions/model.InvoiceStateChange"
                         }
                     }
                 ],
@@ -380,29 +409,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             }
         },
-        "/floors": {
+        "/artists": {
             "get": {
-                "description": "Retrieve list of all floor records",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all artists, approved or not",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "floors"
+                    "artists"
                 ],
-                "summary": "Retrieve list of all floor records",
+                "summary": "Retrieve list of all artists",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FloorList"
+                            "$ref": "#/definitions/model.ArtistList"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
@@ -410,30 +456,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/floors/buildingId/{id}": {
+        "/audit": {
             "get": {
-                "description": "Retrieve list of all floors based on building Id",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve audit entries, newest first, optionally filtered by table, user, change class, record and date range",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "floors"
+                    "audit"
                 ],
-                "summary": "Retrieve list of all floors based on building Id",
+                "summary": "Retrieve the audit trail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Building Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Table changed, e.g. Panels",
+                        "name": "table",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the user who made the change",
+                        "name": "changedById",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "Change class",
+                        "name": "changeClass",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the changed record",
+                        "name": "recordId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest change date (inclusive), e.g. 2024-06-01 00:00:00",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest change date (exclusive)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FloorList"
+                            "$ref": "#/definitions/model.AuditList"
                         }
                     },
                     "400": {
@@ -445,40 +536,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Retrieve overall health of the service",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "serviceHealth"
-                ],
-                "summary": "Retrieve overall health of the service",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.HealthCheck"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/model.HealthCheck"
-                        }
-                    }
-                }
-            }
-        },
-        "/location": {
+        "/booth": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Create a new location",
+                "description": "Create a new booth in a location of the vendor hall",
                 "consumes": [
                     "application/json"
                 ],
@@ -486,17 +551,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "booths"
                 ],
-                "summary": "Create a new location",
+                "summary": "Create a new booth",
                 "parameters": [
                     {
-                        "description": "Location data",
-                        "name": "location",
+                        "description": "Booth data",
+                        "name": "booth",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ProposedLocation"
+                            "$ref": "#/definitions/model.ProposedBooth"
                         }
                     }
                 ],
@@ -516,20 +581,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/location/byBuildingId/{id}": {
+        "/booth/{id}": {
             "get": {
-                "description": "Retrieve list of locations by building Id",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve booth by Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "booths"
                 ],
-                "summary": "Retrieve list of locations by building Id",
+                "summary": "Retrieve booth by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Building Id",
+                        "description": "Booth Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -539,32 +609,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LocationList"
+                            "$ref": "#/definitions/model.Booth"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
                     }
                 }
-            }
-        },
-        "/location/byFloorId/{id}": {
-            "get": {
-                "description": "Retrieve list of locations by floor Id",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a booth by Id. A booth still held by a vendor or artist cannot be deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "booths"
                 ],
-                "summary": "Retrieve list of locations by floor Id",
+                "summary": "Delete a booth by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Floor Id",
+                        "description": "Booth Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -574,59 +647,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LocationList"
+                            "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
-                    }
-                }
-            }
-        },
-        "/location/{id}": {
-            "get": {
-                "description": "Retrieve location by Id",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "locations"
-                ],
-                "summary": "Retrieve location by Id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Location Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Location"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
                     }
                 }
             },
-            "delete": {
+            "patch": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete a location by Id",
+                "description": "Update the label and location of a booth",
                 "consumes": [
                     "application/json"
                 ],
@@ -634,62 +678,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
-                ],
-                "summary": "Delete a location by Id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Location Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.SuccessMsg"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/model.FailureMsg"
-                        }
-                    }
-                }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BasicAuth": []
-                    }
-                ],
-                "description": "Update location information",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "locations"
+                    "booths"
                 ],
-                "summary": "Update location information",
+                "summary": "Update a booth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Location Id",
+                        "description": "Booth Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Location data",
-                        "name": "floor",
+                        "description": "Booth data",
+                        "name": "json",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.LocationUpdate"
+                            "$ref": "#/definitions/model.ProposedBooth"
                         }
                     }
                 ],
@@ -705,29 +711,40 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             }
         },
-        "/locations": {
+        "/booths": {
             "get": {
-                "description": "Retrieve list of all location objects",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all booths, with the vendor or artist holding each of them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "booths"
                 ],
-                "summary": "Retrieve list of all location objects",
+                "summary": "Retrieve list of all booths",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LocationList"
+                            "$ref": "#/definitions/model.BoothList"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
@@ -735,14 +752,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/panel": {
+        "/building": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Create a new panel event",
+                "description": "Create a new building",
                 "consumes": [
                     "application/json"
                 ],
@@ -750,17 +767,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "buildings"
                 ],
-                "summary": "Create a new panel event",
+                "summary": "Create a new building",
                 "parameters": [
                     {
-                        "description": "Panel data",
-                        "name": "panel",
+                        "description": "Building data",
+                        "name": "building",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Panel"
+                            "$ref": "#/definitions/model.ProposedBuilding"
                         }
                     }
                 ],
@@ -780,20 +797,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/panel/{id}": {
+        "/building/{id}": {
             "get": {
-                "description": "Retrieve panel by Id",
+                "description": "Retrieve building by Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "buildings"
                 ],
-                "summary": "Retrieve panel by Id",
+                "summary": "Retrieve building by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Panel Id",
+                        "description": "Building Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -803,7 +820,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Panel"
+                            "$ref": "#/definitions/model.Building"
                         }
                     },
                     "400": {
@@ -820,7 +837,7 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete a panel by Id",
+                "description": "Delete a building by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -828,13 +845,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "buildings"
                 ],
-                "summary": "Delete a panel by Id",
+                "summary": "Delete a building by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Panel Id",
+                        "description": "Building Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -854,38 +871,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/panel/{id}/approve": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Set panel location",
+                "description": "Update building information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "buildings"
                 ],
-                "summary": "Set panel location",
+                "summary": "Update building information",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Panel Id",
+                        "description": "Building Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Approval data",
-                        "name": "json",
+                        "description": "Building data",
+                        "name": "building",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PanelApproval"
+                            "$ref": "#/definitions/model.BuildingUpdate"
                         }
                     }
                 ],
@@ -905,30 +920,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/panel/{id}/location": {
+        "/buildings": {
             "get": {
-                "description": "Retrieve panel location by the panel Id",
+                "description": "Retrieve list of all panels",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
-                ],
-                "summary": "Retrieve panel location by the panel Id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Panel Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "buildings"
                 ],
+                "summary": "Retrieve list of all panels",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Location"
+                            "$ref": "#/definitions/model.BuildingList"
                         }
                     },
                     "400": {
@@ -938,28 +944,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/exhibitor": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Set panel location",
+                "description": "Create a new exhibitor. New exhibitors start out unapproved and not invoiced. The live event it exhibits at must exist",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
                 ],
-                "summary": "Set panel location",
+                "summary": "Create a new exhibitor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Building Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Exhibitor data",
+                        "name": "exhibitor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedExhibitor"
+                        }
                     }
                 ],
                 "responses": {
@@ -978,69 +991,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/panel/{id}/restricted": {
-            "post": {
+        "/exhibitor/{id}": {
+            "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Set panel age restriction",
+                "description": "Retrieve exhibitor by Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
                 ],
-                "summary": "Set panel age restriction",
+                "summary": "Retrieve exhibitor by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Panel Id",
+                        "description": "Exhibitor Id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Age restriction state",
-                        "name": "json",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PanelAgeRestrictionState"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessMsg"
+                            "$ref": "#/definitions/model.Exhibitor"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
                     }
                 }
-            }
-        },
-        "/panel/{id}/schedule": {
-            "get": {
-                "description": "Retrieve panel schedule by the panel Id",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete an exhibitor by Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
                 ],
-                "summary": "Retrieve panel schedule by the panel Id",
+                "summary": "Delete an exhibitor by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Panel Id",
+                        "description": "Exhibitor Id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1050,46 +1057,49 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Schedule"
+                            "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Set the scheduled time for a panel",
+                "description": "Update the details of an exhibitor",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
                 ],
-                "summary": "Set the scheduled time for a panel",
+                "summary": "Update an exhibitor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Panel Id",
+                        "description": "Exhibitor Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Scheduled Time",
+                        "description": "Exhibitor data",
                         "name": "json",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PanelScheduledTime"
+                            "$ref": "#/definitions/model.ProposedExhibitor"
                         }
                     }
                 ],
@@ -1105,25 +1115,57 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             }
         },
-        "/panels": {
-            "get": {
-                "description": "Retrieve list of all approved panels",
+        "/exhibitor/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Approve or unapprove an exhibitor",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
                 ],
-                "summary": "Retrieve list of all approved panels",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Set exhibitor approval state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exhibitor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PanelList"
+                            "$ref": "#/definitions/model.VendorHallApproval"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
                     "400": {
@@ -1131,25 +1173,57 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             }
         },
-        "/panels/ByLocationId/{id}": {
-            "get": {
-                "description": "Retrieve list of all approved panels by location Id",
+        "/exhibitor/{id}/invoice": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Mark an approved exhibitor as \"invoiced\", then as \"fulfilled\" once paid. Skipping or repeating a step is refused",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
+                ],
+                "summary": "Set exhibitor invoice state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exhibitor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invoice state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InvoiceStateChange"
+                        }
+                    }
                 ],
-                "summary": "Retrieve list of all approved panels by location Id",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.PanelList"
+                            "$ref": "#/definitions/model.SuccessMsg"
                         }
                     },
                     "400": {
@@ -1157,34 +1231,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
                     }
                 }
             }
         },
-        "/panels/all": {
+        "/exhibitors": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Retrieve list of all panels",
+                "description": "Retrieve list of all exhibitors, approved or not",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "panels"
+                    "exhibitors"
                 ],
-                "summary": "Retrieve list of all panels",
+                "summary": "Retrieve list of all exhibitors",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.PanelList"
+                            "$ref": "#/definitions/model.ExhibitorList"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.FailureMsg"
                         }
@@ -1192,14 +1278,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
+        "/floor": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Add a new user",
+                "description": "Create a new floor",
                 "consumes": [
                     "application/json"
                 ],
@@ -1207,17 +1293,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "floors"
                 ],
-                "summary": "Register user",
+                "summary": "Create a new floor",
                 "parameters": [
                     {
-                        "description": "User Data",
-                        "name": "user",
+                        "description": "Floor data",
+                        "name": "floor",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ProposedUser"
+                            "$ref": "#/definitions/model.ProposedFloor"
                         }
                     }
                 ],
@@ -1237,56 +1323,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/id/{id}": {
-            "get": {
-                "description": "Retrieve a user by their Id",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Retrieve a user by their Id",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.SafeUser"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/model.FailureMsg"
-                        }
-                    }
-                }
-            }
-        },
-        "/user/name/{name}": {
+        "/floor/{id}": {
             "get": {
-                "description": "Retrieve a user by their UserName",
+                "description": "Retrieve floor based on Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "floors"
                 ],
-                "summary": "Retrieve a user by their UserName",
+                "summary": "Retrieve floor based on Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User name",
-                        "name": "name",
+                        "description": "Floor Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1295,7 +1346,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.SafeUser"
+                            "$ref": "#/definitions/model.BuildingFloor"
                         }
                     },
                     "400": {
@@ -1305,16 +1356,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/{name}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "Delete a user",
+                "description": "Delete a floor by Id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1322,14 +1371,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "floors"
                 ],
-                "summary": "Delete user",
+                "summary": "Delete a floor by Id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User name",
-                        "name": "name",
+                        "description": "Floor Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1355,32 +1404,29 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "Change password",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Update floor information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "floors"
                 ],
-                "summary": "Change password",
+                "summary": "Update floor information",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User name",
-                        "name": "name",
+                        "description": "Floor Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Password data",
-                        "name": "changePassword",
+                        "description": "Floor data",
+                        "name": "floor",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PasswordChange"
+                            "$ref": "#/definitions/model.FloorUpdate"
                         }
                     }
                 ],
@@ -1400,38 +1446,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{name}/status": {
+        "/floors": {
             "get": {
-                "security": [
-                    {
-                        "BasicAuth": []
-                    }
-                ],
-                "description": "Retrieve a user's active status",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve list of all floor records",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
-                ],
-                "summary": "Retrieve a user's active status. Can be either 'enabled' or 'locked'",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
-                    }
+                    "floors"
                 ],
+                "summary": "Retrieve list of all floor records",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserStatusMsg"
+                            "$ref": "#/definitions/model.FloorList"
                         }
                     },
                     "400": {
@@ -1441,38 +1470,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BasicAuth": []
-                    }
-                ],
-                "description": "Set a user's active status",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/floors/buildingId/{id}": {
+            "get": {
+                "description": "Retrieve list of all floors based on building Id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "floors"
                 ],
-                "summary": "Set a user's active status. Can be either 'enabled' or 'locked'",
+                "summary": "Retrieve list of all floors based on building Id",
                 "parameters": [
-                    {
-                        "description": "User Data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "User name",
-                        "name": "name",
+                        "description": "Building Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1481,7 +1495,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserStatusMsg"
+                            "$ref": "#/definitions/model.FloorList"
                         }
                     },
                     "400": {
@@ -1493,212 +1507,7350 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/health": {
             "get": {
-                "description": "Retrieve list of all users",
+                "description": "Retrieve overall health of the service",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "serviceHealth"
                 ],
-                "summary": "Retrieve list of all users",
+                "summary": "Retrieve overall health of the service",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UsersList"
+                            "$ref": "#/definitions/model.HealthCheck"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.FailureMsg"
+                            "$ref": "#/definitions/model.HealthCheck"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.SafeUser": {
-            "type": "object",
-            "properties": {
-                "Id": {
-                    "type": "integer"
-                },
-                "creationDate": {
+        },
+        "/liveEvent": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new live event. New live events start out unapproved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Create a new live event",
+                "parameters": [
+                    {
+                        "description": "Live event data",
+                        "name": "liveEvent",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedLiveEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}": {
+            "get": {
+                "description": "Retrieve live event by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Retrieve live event by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEvent"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a live event by Id, along with its tag assignments and ratings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Delete a live event by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update the topic and description of a live event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Update a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Live event data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Approve or unapprove a live event, recording who made the decision and when",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Set live event approval status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventApproval"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/assignTag": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Assign a tag to a live event. Assigning a tag the live event already carries is a no-op",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Assign a tag to a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to assign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/location": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Move a live event to another location. The move is refused if the live event is already scheduled and its slot conflicts with an event in the new location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Set live event location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/rating": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the average rating of a live event and how many ratings it is based on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Retrieve the rating of a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.EventRating"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Rate a live event from 1 to 5. Each user has one rating per live event; rating again replaces the earlier rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Rate a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RatingSubmission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/restricted": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set live event age restriction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Set live event age restriction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Age restriction state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventAgeRestrictionState"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/schedule": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set the location, scheduled time and, optionally, duration of a live event. The request is refused with the list of conflicting events if the slot overlaps, including the configured changeover buffer, with any panel, screening or live event in the same location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Set the scheduled time for a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled Time",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventScheduledTime"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the tags assigned to a live event",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Retrieve the tags assigned to a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvent/{id}/unassignTag": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a tag from a live event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Remove a tag from a live event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Live event Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to unassign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvents": {
+            "get": {
+                "description": "Retrieve list of all approved live events. When one or more tag names are given, only live events carrying every one of those tags are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Retrieve list of all approved live events",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag name to filter by",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/liveEvents/all": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all live events, approved or not",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "liveEvents"
+                ],
+                "summary": "Retrieve list of all live events",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LiveEventList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/location": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new location",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Create a new location",
+                "parameters": [
+                    {
+                        "description": "Location data",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/byBuildingId/{id}": {
+            "get": {
+                "description": "Retrieve list of locations by building Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Retrieve list of locations by building Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Building Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LocationList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/byFloorId/{id}": {
+            "get": {
+                "description": "Retrieve list of locations by floor Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Retrieve list of locations by floor Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Floor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LocationList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/{id}": {
+            "get": {
+                "description": "Retrieve location by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Retrieve location by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a location by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Delete a location by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update location information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Update location information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location data",
+                        "name": "floor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LocationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Retrieve list of all location objects",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Retrieve list of all location objects",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LocationList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new panel event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Create a new panel event",
+                "parameters": [
+                    {
+                        "description": "Panel data",
+                        "name": "panel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Panel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}": {
+            "get": {
+                "description": "Retrieve panel by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve panel by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Panel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a panel by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Delete a panel by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Accept a panel, or reject it when state is false. Prefer /panel/{id}/state, which covers the whole review lifecycle",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Approve or reject a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelApproval"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/assignTag": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Assign a tag to a panel. Assigning a tag the panel already carries is a no-op",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Assign a tag to a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to assign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/comment": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Add a comment to the review log of a panel without changing its state",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Add a reviewer comment to a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelReviewComment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/location": {
+            "get": {
+                "description": "Retrieve panel location by the panel Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve panel location by the panel Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LocationDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set panel location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Set panel location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/panelist": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Add a panelist to a panel. Panelists are identified across panels by email address, and cannot be added to a scheduled panel if they are on another panel at the same time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Add a panelist to a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Panelist data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedPanelist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/panelist/{panelistId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a panelist from a panel",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Remove a panelist from a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Panelist Id",
+                        "name": "panelistId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/panelists": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the panelists of a panel",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve the panelists of a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelistList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/proposal": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the contact details given by whoever proposed a panel through the public form",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve the proposer of a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelProposal"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/rating": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the average rating of a panel and how many ratings it is based on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve the rating of a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.EventRating"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Rate a panel from 1 to 5. Each user has one rating per panel; rating again replaces the earlier rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Rate a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RatingSubmission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/restricted": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set panel age restriction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Set panel age restriction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Age restriction state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelAgeRestrictionState"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/review": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the state changes and reviewer comments of a panel, oldest first, each with who made it and when",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve the review log of a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelReviewLog"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/schedule": {
+            "get": {
+                "description": "Retrieve panel schedule by the panel Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve panel schedule by the panel Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set the location, scheduled time and, optionally, duration of a panel. Only accepted panels can be given a slot, and doing so marks them scheduled. The request is refused with the list of conflicting events if the slot overlaps, including the configured changeover buffer, with any panel, screening or live event in the same location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Set the scheduled time for a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled Time",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelScheduledTime"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/state": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Move a panel to another review state, optionally with a comment. Panels go from submitted, through under_review, to accepted, rejected or waitlisted, then from accepted to scheduled, and may be cancelled. A panel can only be marked scheduled once it has a slot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Set the review state of a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelStateChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the tags assigned to a panel",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve the tags assigned to a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panel/{id}/unassignTag": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a tag from a panel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Remove a tag from a panel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to unassign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panels": {
+            "get": {
+                "description": "Retrieve list of all approved panels, that is those accepted or scheduled. When one or more tag names are given, only panels carrying every one of those tags are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve list of all approved panels",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag name to filter by",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panels/ByLocationId/{id}": {
+            "get": {
+                "description": "Retrieve list of all approved panels by location Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve list of all approved panels by location Id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panels/ByPanelistEmail/{email}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve every panel, approved or not, that the panelist with the given email address is on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve the panels a panelist is on",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panelist email address",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/panels/all": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all panels. When one or more states are given, only panels currently in one of those states are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "panels"
+                ],
+                "summary": "Retrieve list of all panels",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "submitted",
+                                "under_review",
+                                "accepted",
+                                "rejected",
+                                "waitlisted",
+                                "scheduled",
+                                "cancelled"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Review state to filter by",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/privileges": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all privileges that can be granted to roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Retrieve list of all privileges",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PrivilegeList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/buildings": {
+            "get": {
+                "description": "Retrieve every building used by the convention. No authentication needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve all buildings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicBuildingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/locations": {
+            "get": {
+                "description": "Retrieve every location with the floor and building it is in. No authentication needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve all locations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LocationDetailList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/panel/{id}": {
+            "get": {
+                "description": "Retrieve an approved, scheduled panel for attendees. No authentication needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve a published panel by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Panel Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicPanel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/panels": {
+            "get": {
+                "description": "Retrieve approved, scheduled panels for attendees. No authentication needed. When one or more tag names are given, only panels carrying every one of those tags are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve the published panels",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location Id",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag name to filter by",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicPanelList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/proposal": {
+            "post": {
+                "description": "Propose a panel without an account. The proposer is emailed a link to confirm their address, valid for 48 hours; once they follow it the panel is created in the submitted state. Proposals are limited per hour per client address and per email address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Propose a panel",
+                "parameters": [
+                    {
+                        "description": "Proposal",
+                        "name": "proposal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PanelProposalSubmission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/screening/{id}": {
+            "get": {
+                "description": "Retrieve a scheduled video screening for attendees. No authentication needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve a published screening by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicScreening"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/screenings": {
+            "get": {
+                "description": "Retrieve scheduled video screenings for attendees. No authentication needed. When one or more tag names are given, only screenings carrying every one of those tags are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve the published screenings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location Id",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag name to filter by",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicScreeningList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/sync": {
+            "get": {
+                "description": "Like /sync, but for attendee apps: no authentication needed, only approved, scheduled panels and scheduled screenings are sent, without staff or requestor details. Panels and screenings that were unpublished since the cursor are listed as deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve the changes to the published schedule since a sync cursor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous sync",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of changes to return (default 500, max 5000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PublicSyncChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/tags": {
+            "get": {
+                "description": "Retrieve every tag events can be filtered by. No authentication needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Retrieve all tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/topRated": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "List the best rated panels, screenings and live events of every room or every track (tag), best first. Events nobody has rated are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report the top-rated events per room or track",
+                "parameters": [
+                    {
+                        "enum": [
+                            "room",
+                            "track"
+                        ],
+                        "type": "string",
+                        "description": "Group events by room or by track (default room)",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events per group (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RatingReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/role": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new role with an optional list of privileges",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Create a new role",
+                "parameters": [
+                    {
+                        "description": "Role data",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/role/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve role by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Retrieve role by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a role by Id. The role is removed from any users holding it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Delete a role by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update the name and description of a role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Update role information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role data",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/role/{id}/assignPrivilege": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Assign a privilege to a role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Assign a privilege to a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Privilege to assign",
+                        "name": "privilege",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PrivilegeAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/role/{id}/twoFactor": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set whether holders of a role must sign in with a second factor. Holders without one are asked to set it up at their next login, and cannot sign in with Basic auth until they have",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Set whether a role requires two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Two-factor policy",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleTwoFactorPolicy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/role/{id}/unassignPrivilege": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Unassign a privilege from a role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Unassign a privilege from a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Privilege to unassign",
+                        "name": "privilege",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PrivilegeAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all roles and the privileges they grant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Retrieve list of all roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule.ics": {
+            "get": {
+                "description": "Publish the scheduled approved panels and screenings as an RFC 5545 iCalendar feed that calendar apps can subscribe to. The feed can be narrowed down to one location, one building, or the events carrying every one of the given tags",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Retrieve the schedule as an iCalendar feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location Id",
+                        "name": "locationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Building Id",
+                        "name": "buildingId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag name to filter by",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Stream panel and screening changes (created, scheduled, rescheduled, relocated, approved, rejected, cancelled, deleted) as Server-Sent Events named \u003ceventType\u003e.\u003cchange\u003e, e.g. panel.rescheduled. Each event's id is its position in the change log; a client reconnecting with Last-Event-ID, or the lastEventId parameter, gets every change it missed. Without either, the stream starts with the next change. The first event, \"ready\", carries the position the stream starts from",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Stream schedule changes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of the last change seen",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the last change seen, for clients that cannot set headers",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new video screening event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Create a new video screening event",
+                "parameters": [
+                    {
+                        "description": "Screening data",
+                        "name": "screening",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedScreening"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve screening by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve screening by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Screening"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a screening by Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Delete a screening by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/assignTag": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Assign a tag to a screening. Assigning a tag the screening already carries is a no-op",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Assign a tag to a screening",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to assign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/location": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve screening location by the screening Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve screening location by the screening Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set screening location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Set screening location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScreeningLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/rating": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the average rating of a screening and how many ratings it is based on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve the rating of a screening",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.EventRating"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Rate a screening from 1 to 5. Each user has one rating per screening; rating again replaces the earlier rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Rate a screening",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RatingSubmission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/restricted": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set screening age restriction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Set screening age restriction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Age restriction state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScreeningAgeRestrictionState"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve screening schedule by the screening Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve screening schedule by the screening Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set the location, scheduled time and, optionally, duration of a screening. The request is refused with the list of conflicting events if the slot overlaps, including the configured changeover buffer, with any panel, screening or live event in the same location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Set the scheduled time for a screening",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled Time",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScreeningScheduledTime"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleConflictMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the tags assigned to a screening",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve the tags assigned to a screening",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screening/{id}/unassignTag": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a tag from a screening",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Remove a tag from a screening",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Screening Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to unassign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screenings": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all screenings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve list of all screenings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScreeningList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/screenings/ByLocationId/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all screenings by location Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "screenings"
+                ],
+                "summary": "Retrieve list of all screenings by location Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScreeningList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the buildings, floors, locations, panels, screenings and tags created or updated since the cursor, and tombstones for the ones deleted. Without a cursor, everything is returned. Pass the returned cursor as since on the next call; while hasMore is true, call again straight away",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Retrieve the changes since a sync cursor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous sync",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of changes to return (default 500, max 5000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SyncChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a new tag",
+                "parameters": [
+                    {
+                        "description": "Tag data",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve tag by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Retrieve tag by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a tag by Id. Any assignments of the tag to events are removed as well",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update tag information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update tag information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag data",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all tags",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Retrieve list of all tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TagList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/token": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a named API token for the session user, optionally limited to some privileges and expiring after a number of days. The token is only returned by this call. Tokens cannot create further tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Create a new API token",
+                "parameters": [
+                    {
+                        "description": "API token data",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedApiToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NewApiToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke one of the session user's API tokens. It stops working at once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Revoke an API token by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API token Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the session user's API tokens with their scopes, expiry and when they were last used. Token values are never returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Retrieve the API tokens of the session user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApiTokenList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/twoFactor": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve whether the session user has two-factor authentication enabled or pending, whether one of their roles requires it, and how many unused recovery codes they have",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "twoFactor"
+                ],
+                "summary": "Retrieve the two-factor authentication status of the session user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret for the session user and return it with its otpauth URI. Asking again before confirming returns the same secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "twoFactor"
+                ],
+                "summary": "Start two-factor enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorEnrollment"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/twoFactor/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Turn on two-factor authentication with a code from the newly set up app. Returns the recovery codes, which are not shown again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "twoFactor"
+                ],
+                "summary": "Finish two-factor enrollment",
+                "parameters": [
+                    {
+                        "description": "Code from the authenticator app",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RecoveryCodeList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/twoFactor/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Turn off the session user's two-factor authentication, given a current code. Refused while one of their roles requires it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "twoFactor"
+                ],
+                "summary": "Turn off two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Current two-factor code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/twoFactor/recoveryCodes": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Replace the session user's recovery codes with new ones, given a current code. The old codes stop working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "twoFactor"
+                ],
+                "summary": "Replace the session user's recovery codes",
+                "parameters": [
+                    {
+                        "description": "Current two-factor code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RecoveryCodeList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Add a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Register user",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/id/{id}": {
+            "get": {
+                "description": "Retrieve a user by their Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Retrieve a user by their Id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SafeUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/name/{name}": {
+            "get": {
+                "description": "Retrieve a user by their UserName",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Retrieve a user by their UserName",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SafeUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{name}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Change password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Password data",
+                        "name": "changePassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PasswordChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{name}/assignRole": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Assign a role to a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Assign a role to a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role to assign",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{name}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the roles held by a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Retrieve the roles held by a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{name}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve a user's active status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Retrieve a user's active status. Can be either 'enabled' or 'locked'",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserStatusMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Set a user's active status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set a user's active status. Can be either 'enabled' or 'locked'",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserStatusMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{name}/twoFactor": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Remove a user's TOTP secret and recovery codes, for a user who lost their device. If one of their roles requires two-factor authentication, they set it up again at their next login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Remove a user's two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{name}/unassignRole": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Unassign a role from a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unassign a role from a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role to unassign",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieve list of all users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Retrieve list of all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UsersList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendor": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Create a new vendor. New vendors start out unapproved and not invoiced",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Create a new vendor",
+                "parameters": [
+                    {
+                        "description": "Vendor data",
+                        "name": "vendor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedVendor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendor/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve vendor by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Retrieve vendor by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Vendor"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a vendor by Id. Its booth, if any, is freed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Delete a vendor by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update the details of a vendor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Update a vendor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vendor data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedVendor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendor/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Approve or unapprove a vendor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Set vendor approval state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.VendorHallApproval"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendor/{id}/booth": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Give a vendor a booth, replacing the one it held before. A booth can only be held by one vendor or artist at a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Assign a booth to a vendor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Booth to assign",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BoothAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Free the booth held by a vendor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Unassign the booth of a vendor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendor/{id}/invoice": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Mark an approved vendor as \"invoiced\", then as \"fulfilled\" once paid. Skipping or repeating a step is refused",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Set vendor invoice state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vendor Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invoice state",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InvoiceStateChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all vendors, approved or not",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "Retrieve list of all vendors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.VendorList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to events. Deliveries are signed with the secret as an HMAC-SHA256 of the body in the X-Giron-Signature header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a new webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProposedWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve webhook by Id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retrieve webhook by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Delete a webhook by Id, together with its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook by Id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Update the URL, event types and active flag of a webhook. The secret is only changed if one is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook data",
+                        "name": "json",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessMsg"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve the deliveries of a webhook, newest first, with their attempts, last response status and error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retrieve the delivery log of a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries to return (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookDeliveryList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Retrieve list of all webhooks and the events they are subscribed to. Secrets are never returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retrieve list of all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FailureMsg"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.SafeUser": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ApiToken": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "expiryDateTime": {
+                    "type": "string"
+                },
+                "lastUsedDateTime": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ApiTokenList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ApiToken"
+                    }
+                }
+            }
+        },
+        "model.Artist": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "approvalDateTime": {
+                    "type": "string"
+                },
+                "approvalState": {
+                    "type": "boolean"
+                },
+                "approvedById": {
+                    "type": "integer"
+                },
+                "boothId": {
+                    "type": "integer"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "invoiceFulfilled": {
+                    "type": "boolean"
+                },
+                "invoiced": {
+                    "type": "boolean"
+                },
+                "mailingAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ArtistList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Artist"
+                    }
+                }
+            }
+        },
+        "model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "changeClass": {
+                    "type": "string"
+                },
+                "changeDate": {
+                    "type": "string"
+                },
+                "changedById": {
+                    "type": "integer"
+                },
+                "recordId": {
+                    "type": "integer"
+                },
+                "tableChanged": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditEntry"
+                    }
+                }
+            }
+        },
+        "model.Booth": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "holderId": {
+                    "type": "integer"
+                },
+                "holderName": {
+                    "type": "string"
+                },
+                "holderType": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "roomName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BoothAssignment": {
+            "type": "object",
+            "properties": {
+                "boothId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BoothList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Booth"
+                    }
+                }
+            }
+        },
+        "model.Building": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BuildingFloor": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "buildingId": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "floorName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BuildingList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Building"
+                    }
+                }
+            }
+        },
+        "model.BuildingUpdate": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EventRating": {
+            "type": "object",
+            "properties": {
+                "eventId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "ratingCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Exhibitor": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "approvalDateTime": {
+                    "type": "string"
+                },
+                "approvalState": {
+                    "type": "boolean"
+                },
+                "approvedById": {
+                    "type": "integer"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "invoiceFulfilled": {
+                    "type": "boolean"
+                },
+                "invoiced": {
+                    "type": "boolean"
+                },
+                "mailingAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExhibitorList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Exhibitor"
+                    }
+                }
+            }
+        },
+        "model.FailureMsg": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FloorList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BuildingFloor"
+                    }
+                }
+            }
+        },
+        "model.FloorUpdate": {
+            "type": "object",
+            "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "db": {
+                    "type": "string"
+                },
+                "diskSpace": {
+                    "type": "string"
+                },
+                "diskWritable": {
+                    "type": "string"
+                },
+                "health": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.InvoiceStateChange": {
+            "type": "object",
+            "properties": {
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LiveEvent": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "ageRestricted": {
+                    "type": "boolean"
+                },
+                "approvalDateTime": {
+                    "type": "string"
+                },
+                "approvalStatus": {
+                    "type": "boolean"
+                },
+                "approvedById": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/model.LocationDetail"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LiveEventAgeRestrictionState": {
+            "type": "object",
+            "properties": {
+                "restrictionState": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.LiveEventApproval": {
+            "type": "object",
+            "properties": {
+                "state": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.LiveEventList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LiveEvent"
+                    }
+                }
+            }
+        },
+        "model.LiveEventLocation": {
+            "type": "object",
+            "properties": {
+                "locationId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LiveEventScheduledTime": {
+            "type": "object",
+            "properties": {
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LiveEventUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Location": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "buildingId": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "floorId": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LocationDetail": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "buildingId": {
+                    "type": "integer"
+                },
+                "buildingName": {
+                    "type": "string"
+                },
+                "floorId": {
+                    "type": "integer"
+                },
+                "floorName": {
+                    "type": "string"
+                },
+                "roomName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LocationDetailList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LocationDetail"
+                    }
+                }
+            }
+        },
+        "model.LocationList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Location"
+                    }
+                }
+            }
+        },
+        "model.LocationUpdate": {
+            "type": "object",
+            "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "floorId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NewApiToken": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "expiryDateTime": {
+                    "type": "string"
+                },
+                "lastUsedDateTime": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Panel": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "ageRestricted": {
+                    "type": "boolean"
+                },
+                "approvalDateTime": {
+                    "type": "string"
+                },
+                "approvalStatus": {
+                    "type": "boolean"
+                },
+                "approvedById": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/model.LocationDetail"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "panelRequestorEmail": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelAgeRestrictionState": {
+            "type": "object",
+            "properties": {
+                "restrictionState": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.PanelApproval": {
+            "type": "object",
+            "properties": {
+                "state": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.PanelList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Panel"
+                    }
+                }
+            }
+        },
+        "model.PanelLocation": {
+            "type": "object",
+            "properties": {
+                "locationId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PanelProposal": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "panelId": {
+                    "type": "integer"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "verifiedDateTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelProposalSubmission": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelReviewComment": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelReviewEntry": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "changeDateTime": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "fromState": {
+                    "type": "string"
+                },
+                "panelId": {
+                    "type": "integer"
+                },
+                "reviewerId": {
+                    "type": "integer"
+                },
+                "reviewerName": {
+                    "type": "string"
+                },
+                "toState": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelReviewLog": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PanelReviewEntry"
+                    }
+                }
+            }
+        },
+        "model.PanelScheduledTime": {
+            "type": "object",
+            "properties": {
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelStateChange": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Panelist": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "panelId": {
+                    "type": "integer"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PanelistList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Panelist"
+                    }
+                }
+            }
+        },
+        "model.PasswordChange": {
+            "type": "object",
+            "properties": {
+                "newPassword": {
+                    "type": "string"
+                },
+                "oldPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Privilege": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "privilege": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PrivilegeAssignment": {
+            "type": "object",
+            "properties": {
+                "privilege": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PrivilegeList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Privilege"
+                    }
+                }
+            }
+        },
+        "model.ProposedApiToken": {
+            "type": "object",
+            "properties": {
+                "expiresInDays": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ProposedArtist": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "mailingAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedBooth": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "locationId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProposedBuilding": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedExhibitor": {
+            "type": "object",
+            "properties": {
+                "emailAddress": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "mailingAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedFloor": {
+            "type": "object",
+            "properties": {
+                "buildingName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedLiveEvent": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedLocation": {
+            "type": "object",
+            "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "floorId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedPanelist": {
+            "type": "object",
+            "properties": {
+                "emailAddress": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedRole": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "privileges": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roleName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedScreening": {
+            "type": "object",
+            "properties": {
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedTag": {
+            "type": "object",
+            "properties": {
+                "tagName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedUser": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedVendor": {
+            "type": "object",
+            "properties": {
+                "businessAddress": {
+                    "type": "string"
+                },
+                "companyName": {
+                    "type": "string"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "taxId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProposedWebhook": {
+            "type": "object",
+            "properties": {
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicBuilding": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicBuildingList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicBuilding"
+                    }
+                }
+            }
+        },
+        "model.PublicFloor": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "buildingId": {
+                    "type": "integer"
+                },
+                "floorName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicLocation": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "buildingId": {
+                    "type": "integer"
+                },
+                "floorId": {
+                    "type": "integer"
+                },
+                "roomName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicPanel": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "ageRestricted": {
+                    "type": "boolean"
+                },
+                "description": {
                     "type": "string"
                 },
-                "userName": {
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/model.LocationDetail"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicPanelList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicPanel"
+                    }
+                }
+            }
+        },
+        "model.PublicScreening": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "ageRestricted": {
+                    "type": "boolean"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "location": {
+                    "$ref": "#/definitions/model.LocationDetail"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublicScreeningList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicScreening"
+                    }
+                }
+            }
+        },
+        "model.PublicSyncChanges": {
+            "type": "object",
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicBuilding"
+                    }
+                },
+                "cursor": {
                     "type": "string"
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncTombstone"
+                    }
+                },
+                "floors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicFloor"
+                    }
+                },
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicLocation"
+                    }
+                },
+                "panels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicPanel"
+                    }
+                },
+                "screenings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PublicScreening"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
                 }
             }
         },
-        "model.Building": {
+        "model.RatedEvent": {
             "type": "object",
             "properties": {
                 "Id": {
                     "type": "integer"
                 },
-                "city": {
+                "eventType": {
                     "type": "string"
                 },
-                "creationDateTime": {
-                    "type": "string"
+                "rating": {
+                    "type": "number"
                 },
-                "creatorId": {
+                "ratingCount": {
                     "type": "integer"
                 },
-                "name": {
+                "title": {
                     "type": "string"
+                }
+            }
+        },
+        "model.RatingReport": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RatingReportGroup"
+                    }
                 },
-                "region": {
+                "groupBy": {
                     "type": "string"
                 }
             }
         },
-        "model.BuildingFloor": {
+        "model.RatingReportGroup": {
             "type": "object",
             "properties": {
-                "Id": {
-                    "type": "integer"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RatedEvent"
+                    }
                 },
-                "buildingId": {
+                "groupId": {
                     "type": "integer"
                 },
-                "creationDateTime": {
+                "groupName": {
                     "type": "string"
-                },
-                "creatorId": {
+                }
+            }
+        },
+        "model.RatingSubmission": {
+            "type": "object",
+            "properties": {
+                "rating": {
                     "type": "integer"
-                },
-                "floorName": {
-                    "type": "string"
                 }
             }
         },
-        "model.BuildingList": {
+        "model.RecoveryCodeList": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Building"
+                        "type": "string"
                     }
                 }
             }
         },
-        "model.BuildingUpdate": {
+        "model.Role": {
             "type": "object",
             "properties": {
-                "city": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDate": {
                     "type": "string"
                 },
-                "name": {
+                "description": {
                     "type": "string"
                 },
-                "region": {
+                "privileges": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requireTwoFactor": {
+                    "type": "boolean"
+                },
+                "roleName": {
                     "type": "string"
                 }
             }
         },
-        "model.FailureMsg": {
+        "model.RoleAssignment": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "roleId": {
+                    "type": "integer"
                 }
             }
         },
-        "model.FloorList": {
+        "model.RoleList": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.BuildingFloor"
+                        "$ref": "#/definitions/model.Role"
                     }
                 }
             }
         },
-        "model.FloorUpdate": {
+        "model.RoleTwoFactorPolicy": {
             "type": "object",
             "properties": {
-                "buildingId": {
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.RoleUpdate": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "roleName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Schedule": {
+            "type": "object",
+            "properties": {
+                "durationInMinutes": {
                     "type": "integer"
                 },
-                "name": {
+                "startTime": {
                     "type": "string"
                 }
             }
         },
-        "model.HealthCheck": {
+        "model.ScheduleChange": {
             "type": "object",
             "properties": {
-                "db": {
+                "Id": {
+                    "type": "integer"
+                },
+                "change": {
                     "type": "string"
                 },
-                "diskSpace": {
+                "changeDateTime": {
                     "type": "string"
                 },
-                "diskWritable": {
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "eventType": {
                     "type": "string"
                 },
-                "health": {
+                "locationId": {
+                    "type": "integer"
+                },
+                "scheduledTime": {
                     "type": "string"
                 },
-                "status": {
+                "state": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScheduleConflict": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "locationId": {
                     "type": "integer"
+                },
+                "panelist": {
+                    "description": "set when the conflict is a panelist who is booked elsewhere",
+                    "type": "string"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "model.Location": {
+        "model.ScheduleConflictMsg": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScheduleConflict"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Screening": {
             "type": "object",
             "properties": {
                 "Id": {
                     "type": "integer"
                 },
-                "buildingId": {
-                    "type": "integer"
+                "ageRestricted": {
+                    "type": "boolean"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScreeningAgeRestrictionState": {
+            "type": "object",
+            "properties": {
+                "restrictionState": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.ScreeningList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Screening"
+                    }
+                }
+            }
+        },
+        "model.ScreeningLocation": {
+            "type": "object",
+            "properties": {
+                "locationId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ScreeningScheduledTime": {
+            "type": "object",
+            "properties": {
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SuccessMsg": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SyncChanges": {
+            "type": "object",
+            "properties": {
+                "buildings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Building"
+                    }
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncTombstone"
+                    }
+                },
+                "floors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BuildingFloor"
+                    }
+                },
+                "hasMore": {
+                    "type": "boolean"
                 },
-                "creationDateTime": {
-                    "type": "string"
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Location"
+                    }
                 },
-                "creatorId": {
-                    "type": "integer"
+                "panels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncedPanel"
+                    }
                 },
-                "floorId": {
-                    "type": "integer"
+                "screenings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncedScreening"
+                    }
                 },
-                "location": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.LocationList": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Location"
+                        "$ref": "#/definitions/model.Tag"
                     }
                 }
             }
         },
-        "model.LocationUpdate": {
+        "model.SyncTombstone": {
             "type": "object",
             "properties": {
-                "buildingId": {
+                "Id": {
                     "type": "integer"
                 },
-                "floorId": {
-                    "type": "integer"
+                "deletionDateTime": {
+                    "type": "string"
+                },
+                "entityType": {
+                    "type": "string"
                 }
             }
         },
-        "model.Panel": {
+        "model.SyncedPanel": {
             "type": "object",
             "properties": {
                 "Id": {
@@ -1729,188 +8881,349 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "location": {
-                    "type": "string"
+                    "$ref": "#/definitions/model.LocationDetail"
+                },
+                "locationId": {
+                    "type": "integer"
                 },
                 "panelRequestorEmail": {
                     "type": "string"
                 },
+                "rating": {
+                    "type": "number"
+                },
                 "scheduledTime": {
                     "type": "string"
                 },
+                "state": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "topic": {
                     "type": "string"
                 }
             }
         },
-        "model.PanelAgeRestrictionState": {
+        "model.SyncedScreening": {
             "type": "object",
             "properties": {
-                "restrictionState": {
+                "Id": {
+                    "type": "integer"
+                },
+                "ageRestricted": {
                     "type": "boolean"
+                },
+                "creationDateTime": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "durationInMinutes": {
+                    "type": "integer"
+                },
+                "locationId": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "scheduledTime": {
+                    "type": "string"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "model.PanelApproval": {
+        "model.Tag": {
             "type": "object",
             "properties": {
-                "state": {
-                    "type": "boolean"
+                "Id": {
+                    "type": "integer"
+                },
+                "tagName": {
+                    "type": "string"
                 }
             }
         },
-        "model.PanelList": {
+        "model.TagAssignment": {
+            "type": "object",
+            "properties": {
+                "tagId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagList": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Panel"
+                        "$ref": "#/definitions/model.Tag"
                     }
                 }
             }
         },
-        "model.PanelScheduledTime": {
+        "model.TwoFactorCode": {
             "type": "object",
             "properties": {
-                "durationInMinutes": {
-                    "type": "integer"
-                },
-                "locationId": {
-                    "type": "integer"
-                },
-                "scheduledTime": {
+                "code": {
                     "type": "string"
                 }
             }
         },
-        "model.PasswordChange": {
+        "model.TwoFactorEnrollment": {
             "type": "object",
             "properties": {
-                "newPassword": {
+                "secret": {
                     "type": "string"
                 },
-                "oldPassword": {
+                "uri": {
                     "type": "string"
                 }
             }
         },
-        "model.ProposedBuilding": {
+        "model.TwoFactorStatus": {
             "type": "object",
             "properties": {
-                "city": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "pending": {
+                    "type": "boolean"
+                },
+                "recoveryCodesLeft": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "creationDate": {
                     "type": "string"
                 },
-                "name": {
+                "lastChangedDate": {
                     "type": "string"
                 },
-                "region": {
+                "status": {
+                    "type": "string"
+                },
+                "userName": {
                     "type": "string"
                 }
             }
         },
-        "model.ProposedFloor": {
+        "model.UserStatusMsg": {
             "type": "object",
             "properties": {
-                "buildingName": {
+                "message": {
                     "type": "string"
                 },
-                "name": {
+                "userStatus": {
                     "type": "string"
                 }
             }
         },
-        "model.ProposedLocation": {
+        "model.UsersList": {
             "type": "object",
             "properties": {
-                "buildingId": {
-                    "type": "integer"
-                },
-                "floorId": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
                 }
             }
         },
-        "model.ProposedUser": {
+        "model.Vendor": {
             "type": "object",
             "properties": {
                 "Id": {
                     "type": "integer"
                 },
-                "password": {
+                "approvalDateTime": {
                     "type": "string"
                 },
-                "status": {
+                "approvalState": {
+                    "type": "boolean"
+                },
+                "approvedById": {
+                    "type": "integer"
+                },
+                "boothId": {
+                    "type": "integer"
+                },
+                "businessAddress": {
                     "type": "string"
                 },
-                "userName": {
+                "companyName": {
+                    "type": "string"
+                },
+                "creationDate": {
+                    "type": "string"
+                },
+                "creatorId": {
+                    "type": "integer"
+                },
+                "emailAddress": {
+                    "type": "string"
+                },
+                "invoiceFulfilled": {
+                    "type": "boolean"
+                },
+                "invoiced": {
+                    "type": "boolean"
+                },
+                "phoneNumber": {
+                    "type": "string"
+                },
+                "taxId": {
                     "type": "string"
                 }
             }
         },
-        "model.Schedule": {
+        "model.VendorHallApproval": {
             "type": "object",
             "properties": {
-                "durationInMinutes": {
-                    "type": "integer"
-                },
-                "startTime": {
-                    "type": "string"
+                "state": {
+                    "type": "boolean"
                 }
             }
         },
-        "model.SuccessMsg": {
+        "model.VendorList": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Vendor"
+                    }
                 }
             }
         },
-        "model.User": {
+        "model.Webhook": {
             "type": "object",
             "properties": {
                 "Id": {
                     "type": "integer"
                 },
+                "active": {
+                    "type": "boolean"
+                },
                 "creationDate": {
                     "type": "string"
                 },
-                "lastChangedDate": {
+                "creatorId": {
+                    "type": "integer"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "creationDateTime": {
                     "type": "string"
                 },
-                "passwordHash": {
+                "deliveredDateTime": {
                     "type": "string"
                 },
-                "status": {
+                "eventType": {
                     "type": "string"
                 },
-                "userName": {
+                "lastError": {
+                    "type": "string"
+                },
+                "lastStatusCode": {
+                    "type": "integer"
+                },
+                "nextAttemptDateTime": {
+                    "type": "string"
+                },
+                "payload": {
                     "type": "string"
+                },
+                "webhookId": {
+                    "type": "integer"
                 }
             }
         },
-        "model.UserStatusMsg": {
+        "model.WebhookDeliveryList": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "userStatus": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WebhookDelivery"
+                    }
                 }
             }
         },
-        "model.UsersList": {
+        "model.WebhookList": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.User"
+                        "$ref": "#/definitions/model.Webhook"
+                    }
+                }
+            }
+        },
+        "model.WebhookUpdate": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
@@ -1918,6 +9231,12 @@ const docTemplate = `{
     "securityDefinitions": {
         "BasicAuth": {
             "type": "basic"
+        },
+        "BearerAuth": {
+            "description": "An API token, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
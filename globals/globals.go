@@ -18,6 +18,23 @@ package globals
 
 */
 
-var Secret = []byte("secret")
-
 const UserKey = "user"
+
+// PendingUserKey, PendingSinceKey Hold the user who gave their password on
+// the login page but still has to give a second factor, and when they did
+const (
+	PendingUserKey  = "pendingUser"
+	PendingSinceKey = "pendingSince"
+)
+
+// RenewSessionKey Asks the session store for a new session Id on the next
+// save; see middleware.RenewSession. It is never stored.
+const RenewSessionKey = "renewSession"
+
+// ApiTokenKey Holds the API token a request was authenticated with, if any
+const ApiTokenKey = "apiToken"
+
+// ApiTokenAllowedKey Is set once the route has let the request's API token
+// through, either by checking its scopes or because the route is open to
+// any token. Token requests on other routes are refused.
+const ApiTokenAllowedKey = "apiTokenAllowed"
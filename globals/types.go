@@ -25,4 +25,57 @@ type Config struct {
 	TLSKeyFile string `json:"tlsKeyFile"`
 	DbPath     string `json:"dbPath"`
 	UseTLS     bool   `json:"useTls"`
+	// addresses or CIDR ranges of the reverse proxies in front of the
+	// service. The client address is taken from X-Forwarded-For only when the
+	// request comes from one of them
+	TrustedProxies []string `json:"trustedProxies"`
+	// minutes a room must stay free between two events booked into it
+	ChangeoverBufferMinutes int `json:"changeoverBufferMinutes"`
+	// IANA name of the time zone scheduled times are given in, UTC if unset
+	TimeZone string `json:"timeZone"`
+	// seconds attendee devices and proxies may cache the public API for
+	PublicCacheSeconds int `json:"publicCacheSeconds"`
+	// address the service is reached at from outside, used in links sent by
+	// email. Panel proposals are turned off while it is unset
+	PublicUrl string `json:"publicUrl"`
+	// panel proposals accepted per hour from one address and for one email
+	// address
+	ProposalsPerHourPerAddress int `json:"proposalsPerHourPerAddress"`
+	ProposalsPerHourPerEmail   int `json:"proposalsPerHourPerEmail"`
+	// how email is delivered: "smtp", or "log" (the default) to write it to
+	// the log, or to files in mailDir, during development
+	MailSender string `json:"mailSender"`
+	MailFrom   string `json:"mailFrom"`
+	MailDir    string `json:"mailDir"`
+	SmtpHost   string `json:"smtpHost"`
+	SmtpPort   int    `json:"smtpPort"`
+	// SMTP credentials, only used when smtpUsername is set
+	SmtpUsername string `json:"smtpUsername"`
+	SmtpPassword string `json:"smtpPassword"`
+	// seconds between outbox runs and attempts per email before giving up
+	MailIntervalSeconds int `json:"mailIntervalSeconds"`
+	MailMaxAttempts     int `json:"mailMaxAttempts"`
+	// seconds between webhook delivery runs and attempts per delivery before
+	// giving up
+	WebhookIntervalSeconds int `json:"webhookIntervalSeconds"`
+	WebhookMaxAttempts     int `json:"webhookMaxAttempts"`
+	// failed logins within loginLockoutMinutes that lock an account for that
+	// many minutes. Each failure also doubles the wait before the next try
+	LoginLockoutThreshold int `json:"loginLockoutThreshold"`
+	LoginLockoutMinutes   int `json:"loginLockoutMinutes"`
+	// secrets session cookies are signed and encrypted with. The first one is
+	// used for new cookies and the others are still accepted, so secrets can
+	// be rotated. GIRON_SESSION_SECRETS, comma separated, takes precedence
+	SessionSecrets []string `json:"sessionSecrets"`
+	// where sessions are kept: "cookie" (the default), or "sqlite" to keep
+	// them in the database so they can be revoked
+	SessionStore string `json:"sessionStore"`
+	// minutes a session lasts after signing in
+	SessionMaxAgeMinutes int `json:"sessionMaxAgeMinutes"`
+	// whether the session cookie is only sent over HTTPS. Follows useTls if
+	// unset; set it when TLS is handled by a proxy
+	SessionCookieSecure *bool `json:"sessionCookieSecure"`
+	// SameSite mode of the session cookie: "lax" (the default), "strict" or
+	// "none"
+	SessionCookieSameSite string `json:"sessionCookieSameSite"`
 }
@@ -19,16 +19,28 @@ package helpers
 */
 
 import (
-	"crypto/sha512"
-	"encoding/hex"
+	"errors"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/JAFAX/giron-service/model"
 )
 
+// CheckIsNotLocked Reports whether the user may sign in. A user whose
+// automatic lockout has run out is unlocked first.
 func CheckIsNotLocked(u model.User) bool {
-	return u.Status != "locked"
+	if u.Status != "locked" {
+		return true
+	}
+
+	released, err := model.ReleaseExpiredLockout(u.Id)
+	if err != nil {
+		log.Println("ERROR: Cannot check lockout of user '" + u.UserName + "': " + string(err.Error()))
+		return false
+	}
+	return released
 }
 
 func CheckUserPass(username, password string) bool {
@@ -42,14 +54,128 @@ func CheckUserPass(username, password string) bool {
 		return false
 	}
 
-	// get the password hash from the user so we can compare it
-	pwHash := user.PasswordHash
+	match, needsRehash, err := model.VerifyPassword(password, user.PasswordHash)
+	if err != nil {
+		log.Println("ERROR: Cannot verify password for user '" + username + "': " + string(err.Error()))
+		return false
+	}
+	if !match {
+		return false
+	}
+
+	// legacy or outdated hashes are replaced now that we have the plaintext.
+	// Failing to upgrade shouldn't block the login.
+	if needsRehash {
+		if _, err := model.UpgradePasswordHash(username, password); err != nil {
+			log.Println("WARN: Could not upgrade password hash for user '" + username + "': " + string(err.Error()))
+		}
+	}
+
+	return true
+}
+
+// CheckLogin Checks a password login from a client address. While the user
+// name or the address is backing off after failed logins the attempt is
+// refused without looking at the password, and retryAfter says how long the
+// client has to wait.
+func CheckLogin(username, password, remoteAddress string) (bool, time.Duration) {
+	retryAfter, err := model.LoginRetryAfter(username, remoteAddress)
+	if err != nil {
+		return false, 0
+	}
+	if retryAfter > 0 {
+		log.Println("WARN: Login for user '" + username + "' from " + remoteAddress + " refused while backing off")
+		return false, retryAfter
+	}
+
+	if !CheckUserPass(username, password) {
+		// failing to record the failure shouldn't hide that the login failed
+		model.RecordLoginFailure(username, remoteAddress)
+		return false, 0
+	}
+
+	// with a second factor to check or to set up, the login isn't over yet.
+	// Clearing the failures now would let a known password reset the backoff
+	// between guesses of the code.
+	user, err := model.GetUserByUserName(username)
+	if err != nil {
+		return false, 0
+	}
+	status, err := model.GetTwoFactorStatus(user.Id)
+	if err != nil {
+		return false, 0
+	}
+	if !status.Enabled && !status.Required {
+		model.ClearLoginFailures(username)
+	}
+	return true, 0
+}
+
+// CheckSecondFactor Checks the TOTP or recovery code of a user who has
+// already given their password. Wrong codes count as failed logins and back
+// off the same way.
+func CheckSecondFactor(user model.User, code, remoteAddress string) (bool, time.Duration) {
+	retryAfter, err := model.LoginRetryAfter(user.UserName, remoteAddress)
+	if err != nil {
+		return false, 0
+	}
+	if retryAfter > 0 {
+		log.Println("WARN: Two-factor code for user '" + user.UserName + "' from " + remoteAddress + " refused while backing off")
+		return false, retryAfter
+	}
+
+	// the account may have been locked since the password was checked
+	if !CheckIsNotLocked(user) {
+		log.Println("WARN: Two-factor code for locked user '" + user.UserName + "' refused")
+		return false, 0
+	}
+
+	valid, err := model.VerifyTwoFactorCode(user.Id, code)
+	if err != nil || !valid {
+		model.RecordLoginFailure(user.UserName, remoteAddress)
+		return false, 0
+	}
+
+	model.ClearLoginFailures(user.UserName)
+	return true, 0
+}
+
+// ConfirmTwoFactorEnrollment Turns on the second factor a user is setting
+// up, given the first code from their app, and returns their recovery codes.
+// Wrong codes count as failed logins and back off like CheckSecondFactor;
+// while backing off, nothing is checked and retryAfter says how long to wait.
+func ConfirmTwoFactorEnrollment(user model.User, code, remoteAddress string) ([]string, time.Duration, error) {
+	retryAfter, err := model.LoginRetryAfter(user.UserName, remoteAddress)
+	if err != nil {
+		return nil, 0, err
+	}
+	if retryAfter > 0 {
+		log.Println("WARN: Two-factor enrollment code for user '" + user.UserName + "' from " + remoteAddress + " refused while backing off")
+		return nil, retryAfter, nil
+	}
+
+	if !CheckIsNotLocked(user) {
+		log.Println("WARN: Two-factor enrollment code for locked user '" + user.UserName + "' refused")
+		return nil, 0, &model.InvalidTwoFactorCode{Err: errors.New("account is locked")}
+	}
+
+	codes, err := model.ConfirmTwoFactorEnrollment(user.Id, code)
+	if err != nil {
+		var invalidCode *model.InvalidTwoFactorCode
+		if errors.As(err, &invalidCode) {
+			model.RecordLoginFailure(user.UserName, remoteAddress)
+		}
+		return nil, 0, err
+	}
 
-	// now calculate the sha512 of the password and see if it matches
-	sha := sha512.Sum512([]byte(password))
-	newPwHash := hex.EncodeToString(sha[:])
+	model.ClearLoginFailures(user.UserName)
+	return codes, 0, nil
+}
 
-	return pwHash == newPwHash // returns boolean based on equality
+// RetryAfterSeconds Formats a wait for the Retry-After header, rounding up to
+// whole seconds
+func RetryAfterSeconds(wait time.Duration) string {
+	return strconv.Itoa(int((wait + time.Second - 1) / time.Second))
 }
 
 func EmptyUserPass(username, password string) bool {
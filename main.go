@@ -25,12 +25,16 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 
 	swaggerfiles "github.com/swaggo/files"
@@ -42,6 +46,7 @@ import (
 	"github.com/JAFAX/giron-service/helpers"
 	"github.com/JAFAX/giron-service/middleware"
 	"github.com/JAFAX/giron-service/model"
+	"github.com/JAFAX/giron-service/notify"
 	"github.com/JAFAX/giron-service/routes"
 )
 
@@ -54,6 +59,11 @@ import (
 
 //	@securityDefinitions.basic	BasicAuth
 
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				An API token, sent as "Bearer <token>"
+
 //	@license.name	Apache 2.0
 //	@license.url	http://www.apache.org/licenses/LICENSE-2.0.html
 
@@ -62,9 +72,6 @@ import (
 
 // @schemas	http https
 func main() {
-	r := gin.Default()
-	r.SetTrustedProxies(nil)
-
 	// lets get our working directory
 	appdir, err := filepath.Abs(filepath.Dir(os.Args[0]))
 	helpers.FatalCheckError(err)
@@ -85,16 +92,93 @@ func main() {
 	GironService.AppPath = appdir
 	GironService.ConfigPath = configDir
 	GironService.ConfStruct = config
+	GironService.TimeZone = time.UTC
+	if config.TimeZone != "" {
+		GironService.TimeZone, err = time.LoadLocation(config.TimeZone)
+		helpers.FatalCheckError(err)
+	}
+
+	// emailed links are built from publicUrl alone, so it has to be usable
+	if GironService.ConfStruct.PublicUrl != "" {
+		publicUrl, err := url.Parse(GironService.ConfStruct.PublicUrl)
+		if err != nil || (publicUrl.Scheme != "http" && publicUrl.Scheme != "https") || publicUrl.Host == "" {
+			helpers.FatalCheckError(errors.New("publicUrl must be an absolute http or https URL"))
+		}
+	} else {
+		log.Println("WARN: publicUrl is not set, so panel proposals are turned off")
+	}
 
 	err = model.ConnectDatabase(GironService.ConfStruct.DbPath)
 	helpers.FatalCheckError(err)
+	model.ChangeoverBufferMinutes = GironService.ConfStruct.ChangeoverBufferMinutes
+	model.ProposalsPerHourPerAddress = GironService.ConfStruct.ProposalsPerHourPerAddress
+	model.ProposalsPerHourPerEmail = GironService.ConfStruct.ProposalsPerHourPerEmail
+	model.LoginLockoutThreshold = GironService.ConfStruct.LoginLockoutThreshold
+	model.LoginLockoutMinutes = GironService.ConfStruct.LoginLockoutMinutes
+
+	// `giron-service migrate ...` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(os.Args[2:]))
+	}
+
+	// refuse to run against a schema from a newer build, otherwise bring the
+	// database up to date before serving
+	_, err = model.MigrateUp()
+	helpers.FatalCheckError(err)
+
+	// `giron-service grant-admin <user>` bootstraps an administrator and exits
+	if len(os.Args) > 1 && os.Args[1] == "grant-admin" {
+		os.Exit(runGrantAdminCommand(os.Args[2:]))
+	}
+
+	// send the emails queued in the outbox in the background
+	sender, err := notify.NewSender(GironService.ConfStruct)
+	helpers.FatalCheckError(err)
+	emailTemplates, err := notify.LoadTemplates(filepath.Join("templates", "email"))
+	helpers.FatalCheckError(err)
+	mailInterval := GironService.ConfStruct.MailIntervalSeconds
+	if mailInterval <= 0 {
+		mailInterval = 30
+	}
+	mailMaxAttempts := GironService.ConfStruct.MailMaxAttempts
+	if mailMaxAttempts <= 0 {
+		mailMaxAttempts = 10
+	}
+	dispatcher := &notify.Dispatcher{
+		Sender:      sender,
+		Templates:   emailTemplates,
+		Interval:    time.Duration(mailInterval) * time.Second,
+		MaxAttempts: mailMaxAttempts,
+	}
+	go dispatcher.Run()
+
+	// post the queued webhook deliveries in the background
+	webhookInterval := GironService.ConfStruct.WebhookIntervalSeconds
+	if webhookInterval <= 0 {
+		webhookInterval = 10
+	}
+	webhookMaxAttempts := GironService.ConfStruct.WebhookMaxAttempts
+	if webhookMaxAttempts <= 0 {
+		webhookMaxAttempts = 10
+	}
+	webhooks := notify.NewWebhookDispatcher(time.Duration(webhookInterval)*time.Second, webhookMaxAttempts)
+	go webhooks.Run()
+
+	r := gin.Default()
+	// without trusted proxies, the client address is the peer address, which
+	// behind a proxy is the proxy's and would put every client in one bucket
+	// for the per-address login and proposal limits
+	err = r.SetTrustedProxies(GironService.ConfStruct.TrustedProxies)
+	helpers.FatalCheckError(err)
 
 	// set up our static assets
 	r.Static("/assets", "./assets")
 	r.LoadHTMLGlob("templates/*.html")
 
-	// some defaults for using session support
-	r.Use(sessions.Sessions("session", cookie.NewStore(globals.Secret)))
+	// keep sessions where the configuration says, signed with its secrets
+	sessionStore, err := middleware.NewSessionStore(GironService.ConfStruct)
+	helpers.FatalCheckError(err)
+	r.Use(sessions.Sessions("session", sessionStore))
 	// frontend
 	fePublic := r.Group("/")
 	routes.FePublicRoutes(fePublic, GironService)
@@ -20,10 +20,13 @@ package middleware
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/JAFAX/giron-service/globals"
 	"github.com/JAFAX/giron-service/helpers"
@@ -32,68 +35,240 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func processAuthorizationHeader(authHeader string) (string, string) {
-	// split the header value at the space
-	encodedString := strings.Split(authHeader, " ")
+// authRealm Names the protection space in WWW-Authenticate challenges
+const authRealm = "giron-service"
 
-	// remove base64 encoding
-	decodedString, _ := base64.StdEncoding.DecodeString(encodedString[1])
+// OTPHeader Carries the TOTP or recovery code of Basic auth requests from users
+// with two-factor authentication
+const OTPHeader = "X-Giron-OTP"
 
-	// now lets return both the
-	authValues := strings.Split(string(decodedString), ":")
+// authentication schemes accepted in the Authorization header
+const (
+	schemeBasic  = "basic"
+	schemeBearer = "bearer"
+)
 
-	return authValues[0], authValues[1]
+// authCredentials The credentials carried by an Authorization header. Scheme
+// is lower case; UserName and Password are set for Basic, Token for Bearer.
+type authCredentials struct {
+	Scheme   string
+	UserName string
+	Password string
+	Token    string
 }
 
-func AuthCheck(c *gin.Context) {
+// parseAuthorizationHeader Splits an Authorization header into its scheme and
+// credentials. Scheme names are case insensitive, and a Basic password may
+// itself contain ':'.
+func parseAuthorizationHeader(authHeader string) (authCredentials, error) {
+	scheme, value, found := strings.Cut(strings.TrimSpace(authHeader), " ")
+	value = strings.TrimSpace(value)
+	if !found || value == "" {
+		return authCredentials{}, errors.New("missing credentials")
+	}
+
+	credentials := authCredentials{Scheme: strings.ToLower(scheme)}
+	switch credentials.Scheme {
+	case schemeBasic:
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return authCredentials{}, errors.New("basic credentials are not valid base64")
+		}
+		userName, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return authCredentials{}, errors.New("basic credentials must be in the form user:password")
+		}
+		if helpers.EmptyUserPass(userName, password) {
+			return authCredentials{}, errors.New("user name and password are required")
+		}
+		credentials.UserName = userName
+		credentials.Password = password
+	case schemeBearer:
+		if strings.ContainsAny(value, " \t") {
+			return authCredentials{}, errors.New("bearer token cannot contain spaces")
+		}
+		credentials.Token = value
+	default:
+		return authCredentials{}, errors.New("unsupported authentication scheme '" + scheme + "'")
+	}
+
+	return credentials, nil
+}
+
+// unauthorized Rejects the request with 401 Unauthorized and the challenges
+// of every scheme we accept. bearerError, when set, is the RFC 6750 error
+// code for a rejected token.
+func unauthorized(c *gin.Context, message string, bearerError string) {
+	bearerChallenge := `Bearer realm="` + authRealm + `"`
+	if bearerError != "" {
+		bearerChallenge += `, error="` + bearerError + `"`
+	}
+	c.Writer.Header().Add("WWW-Authenticate", `Basic realm="`+authRealm+`", charset="UTF-8"`)
+	c.Writer.Header().Add("WWW-Authenticate", bearerChallenge)
+	c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": message})
+	c.Abort()
+}
+
+// tooManyLogins Rejects a login attempt made while backing off after failed
+// ones
+func tooManyLogins(c *gin.Context, retryAfter time.Duration) {
+	wait := helpers.RetryAfterSeconds(retryAfter)
+	c.Header("Retry-After", wait)
+	c.IndentedJSON(http.StatusTooManyRequests, gin.H{"error": "too many failed logins, try again in " + wait + " seconds"})
+	c.Abort()
+}
+
+// authenticateApiToken Checks a bearer token and makes its user the session
+// user for this request only. The session is not saved, so a token never
+// turns into a cookie and revoking it takes effect at once.
+func authenticateApiToken(c *gin.Context, value string) bool {
+	token, err := model.AuthenticateApiToken(value)
+	if err != nil || token.Id == 0 {
+		log.Println("ERROR: Unknown or expired API token")
+		return false
+	}
+
+	user, err := model.GetUserById(token.UserId)
+	if err != nil {
+		log.Println("ERROR: " + string(err.Error()))
+		return false
+	}
+	if !helpers.CheckIsNotLocked(user) {
+		log.Println("WARN: User '" + user.UserName + "' is locked!")
+		return false
+	}
+
 	session := sessions.Default(c)
-	user := session.Get("user")
-	if user == nil {
-		log.Println("INFO: No session found. Attempting to check for authentication headers")
-		baHeader := c.GetHeader("Authorization")
-		if baHeader == "" {
-			log.Println("ERROR: No authentication header found. Aborting")
-			c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": "not authorized!"})
-			c.Abort()
+	session.Set(globals.UserKey, user.UserName)
+	c.Set(globals.ApiTokenKey, token)
+	log.Println("INFO: Authenticated with API token Id '" + strconv.Itoa(token.Id) + "'")
+	return true
+}
+
+// checkSecondFactor Checks the code in the OTPHeader of a Basic auth request
+// from a user with two-factor authentication, and aborts the request if it is
+// missing or wrong. Users whose roles require a second factor they have not
+// set up yet are sent to the login page, where they can enroll.
+func checkSecondFactor(c *gin.Context, username string) bool {
+	user, err := model.GetUserByUserName(username)
+	if err != nil {
+		log.Println("ERROR: " + string(err.Error()))
+		unauthorized(c, "unable to authenticate: "+err.Error(), "")
+		return false
+	}
+	status, err := model.GetTwoFactorStatus(user.Id)
+	if err != nil {
+		unauthorized(c, "unable to authenticate: "+err.Error(), "")
+		return false
+	}
+
+	if !status.Enabled {
+		if status.Required {
+			log.Println("WARN: User '" + username + "' has to set up two-factor authentication")
+			unauthorized(c, "two-factor authentication is required for this account, sign in through the login page to set it up", "")
+			return false
+		}
+		return true
+	}
+
+	code := c.GetHeader(OTPHeader)
+	if code == "" {
+		c.Header(OTPHeader, "required")
+		unauthorized(c, "two-factor code required in the "+OTPHeader+" header", "")
+		return false
+	}
+	valid, retryAfter := helpers.CheckSecondFactor(user, code, c.ClientIP())
+	if retryAfter > 0 {
+		tooManyLogins(c, retryAfter)
+		return false
+	}
+	if !valid {
+		log.Println("ERROR: Invalid two-factor code for user '" + username + "'")
+		c.Header(OTPHeader, "required")
+		unauthorized(c, "invalid two-factor code", "")
+		return false
+	}
+
+	return true
+}
+
+// AuthCheck Lets the request through when it carries a valid API token, a
+// session of an unlocked user, or valid Basic credentials, in that order. A
+// successful Basic login is kept in the session.
+func AuthCheck(c *gin.Context) {
+	credentials := authCredentials{}
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		var err error
+		credentials, err = parseAuthorizationHeader(authHeader)
+		if err != nil {
+			log.Println("ERROR: Malformed Authorization header: " + string(err.Error()))
+			unauthorized(c, "malformed Authorization header: "+string(err.Error()), "")
 			return
 		}
-		// otherwise, lets process that header
-		username, password := processAuthorizationHeader(baHeader)
-		authStatus := helpers.CheckUserPass(username, password)
-		if authStatus {
-			session.Set(globals.UserKey, username)
-			if err := session.Save(); err != nil {
-				c.IndentedJSON(http.StatusInternalServerError,
-					gin.H{"error": "failed to save user session"})
-				// session saving is not fatal, so allow them to proceed
-			}
-			log.Println("INFO: Authenticated")
-		} else {
-			log.Println("ERROR: Authentication failed. Aborting")
-			c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": "not authorized!"})
-			c.Abort()
+	}
+
+	if credentials.Scheme == schemeBearer {
+		if !authenticateApiToken(c, credentials.Token) {
+			unauthorized(c, "not authorized!", "invalid_token")
 			return
 		}
-	} else {
+		c.Next()
+		return
+	}
+
+	session := sessions.Default(c)
+	user := session.Get(globals.UserKey)
+	if user != nil {
 		userString := fmt.Sprintf("%v", user)
 		log.Println("INFO: Session found: User: " + userString)
 		log.Println("INFO: Checking if user is locked or not...")
 		user, err := model.GetUserByUserName(userString)
 		if err != nil {
 			log.Println("ERROR: " + string(err.Error()))
-			c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": "unable to authenticate: " + err.Error()})
-			c.Abort()
+			unauthorized(c, "unable to authenticate: "+err.Error(), "")
 			return
 		}
-		status := helpers.CheckIsNotLocked(user)
-		if status {
-			log.Println("INFO: Authenticated")
-		} else {
+		if user.UserName == "" {
+			log.Println("WARN: Session user '" + userString + "' no longer exists")
+			unauthorized(c, "not authorized!", "")
+			return
+		}
+		if !helpers.CheckIsNotLocked(user) {
 			log.Println("WARN: User '" + userString + "' is locked!")
-			c.IndentedJSON(http.StatusUnauthorized, gin.H{"error": "not authorized!"})
-			c.Abort()
+			unauthorized(c, "not authorized!", "")
 			return
 		}
+		log.Println("INFO: Authenticated")
+		c.Next()
+		return
+	}
+
+	if credentials.Scheme != schemeBasic {
+		log.Println("ERROR: No session or authentication header found. Aborting")
+		unauthorized(c, "not authorized!", "")
+		return
+	}
+
+	log.Println("INFO: No session found. Checking Basic credentials")
+	authStatus, retryAfter := helpers.CheckLogin(credentials.UserName, credentials.Password, c.ClientIP())
+	if retryAfter > 0 {
+		tooManyLogins(c, retryAfter)
+		return
+	}
+	if !authStatus {
+		log.Println("ERROR: Authentication failed. Aborting")
+		unauthorized(c, "not authorized!", "")
+		return
+	}
+	if !checkSecondFactor(c, credentials.UserName) {
+		return
+	}
+	RenewSession(session)
+	session.Set(globals.UserKey, credentials.UserName)
+	// session saving is not fatal, so allow them to proceed
+	if err := session.Save(); err != nil {
+		log.Println("WARN: Failed to save user session: " + string(err.Error()))
 	}
+	log.Println("INFO: Authenticated")
 	c.Next()
 }
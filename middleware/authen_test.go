@@ -0,0 +1,105 @@
+package middleware
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func basicHeader(credentials string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+}
+
+func TestAuthCheckRejectsMalformedHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"bare basic", "Basic"},
+		{"basic with trailing space", "Basic "},
+		{"basic with invalid base64", "Basic !!not-base64!!"},
+		{"basic without colon", basicHeader("admin")},
+		{"basic with empty password", basicHeader("admin:")},
+		{"basic with empty user name", basicHeader(":password")},
+		{"basic password containing colons", basicHeader("nobody:pass:word:")},
+		{"unknown scheme", "Digest username=\"admin\""},
+		{"scheme only", "Negotiate"},
+		{"bare bearer", "Bearer"},
+		{"bearer with empty token", "Bearer "},
+		{"bearer with spaces in token", "Bearer gir_a gir_b"},
+		{"unknown bearer token", "Bearer gir_notarealtoken"},
+	}
+
+	r := newTestRouter(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/private", nil)
+			req.Header.Set("Authorization", test.header)
+			w := httptest.NewRecorder()
+
+			func() {
+				defer func() {
+					if p := recover(); p != nil {
+						t.Fatalf("AuthCheck panicked on %q: %v", test.header, p)
+					}
+				}()
+				r.ServeHTTP(w, req)
+			}()
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+			challenges := w.Header().Values("WWW-Authenticate")
+			if len(challenges) != 2 || !strings.HasPrefix(challenges[0], "Basic ") || !strings.HasPrefix(challenges[1], "Bearer ") {
+				t.Errorf("WWW-Authenticate = %q, want a Basic and a Bearer challenge", challenges)
+			}
+			if w.Header().Get("Set-Cookie") != "" {
+				t.Errorf("rejected request got a session cookie")
+			}
+		})
+	}
+}
+
+func TestParseAuthorizationHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   authCredentials
+	}{
+		{basicHeader("admin:password"), authCredentials{Scheme: schemeBasic, UserName: "admin", Password: "password"}},
+		{basicHeader("admin:pass:word"), authCredentials{Scheme: schemeBasic, UserName: "admin", Password: "pass:word"}},
+		{"bAsIc " + base64.StdEncoding.EncodeToString([]byte("admin:x")), authCredentials{Scheme: schemeBasic, UserName: "admin", Password: "x"}},
+		{"Bearer gir_abc", authCredentials{Scheme: schemeBearer, Token: "gir_abc"}},
+		{"  bearer   gir_abc  ", authCredentials{Scheme: schemeBearer, Token: "gir_abc"}},
+	}
+
+	for _, test := range tests {
+		got, err := parseAuthorizationHeader(test.header)
+		if err != nil {
+			t.Errorf("parseAuthorizationHeader(%q) failed: %v", test.header, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseAuthorizationHeader(%q) = %+v, want %+v", test.header, got, test.want)
+		}
+	}
+}
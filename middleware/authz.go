@@ -0,0 +1,98 @@
+package middleware
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/globals"
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// AllowApiTokens Opens a route that needs no privilege to API tokens. Token
+// requests are refused on routes that neither require a privilege nor use
+// this, so new routes stay closed to tokens until someone decides otherwise.
+func AllowApiTokens(c *gin.Context) {
+	c.Set(globals.ApiTokenAllowedKey, true)
+	c.Next()
+}
+
+// HasPrivilege Reports whether the session user may use the named privilege
+// in this request: one of their roles must grant it and, when the request was
+// made with an API token, the token must be scoped for it. Every privilege
+// check goes through here.
+func HasPrivilege(c *gin.Context, userId int, privilege string) (bool, error) {
+	allowed, err := model.UserHasPrivilege(userId, privilege)
+	if err != nil || !allowed {
+		return false, err
+	}
+
+	if value, viaToken := c.Get(globals.ApiTokenKey); viaToken && !value.(model.ApiToken).Allows(privilege) {
+		log.Println("WARN: API token Id '" + strconv.Itoa(value.(model.ApiToken).Id) + "' is not scoped for privilege '" + privilege + "'")
+		return false, nil
+	}
+	return true, nil
+}
+
+// RequirePrivilege Returns a handler that only lets the request through when
+// one of the session user's roles grants the named privilege. It must run
+// after AuthCheck has established the session.
+func RequirePrivilege(privilege string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+		user := session.Get(globals.UserKey)
+		if user == nil {
+			log.Println("ERROR: No session user found while checking privilege '" + privilege + "'")
+			c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+			c.Abort()
+			return
+		}
+
+		userString := fmt.Sprintf("%v", user)
+		userObject, err := model.GetUserByUserName(userString)
+		if err != nil {
+			log.Println("ERROR: " + string(err.Error()))
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "unable to check privileges: " + err.Error()})
+			c.Abort()
+			return
+		}
+
+		allowed, err := HasPrivilege(c, userObject.Id, privilege)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "unable to check privileges: " + err.Error()})
+			c.Abort()
+			return
+		}
+		if !allowed {
+			log.Println("WARN: User '" + userString + "' lacks privilege '" + privilege + "'")
+			c.IndentedJSON(http.StatusForbidden, gin.H{"error": "Insufficient access. Access denied!"})
+			c.Abort()
+			return
+		}
+
+		// the token was checked against the privilege above
+		c.Set(globals.ApiTokenAllowedKey, true)
+		c.Next()
+	}
+}
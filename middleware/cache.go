@@ -0,0 +1,101 @@
+package middleware
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultPublicCacheSeconds = 60
+
+// bufferedWriter Holds back the response body so it can be hashed before
+// anything is sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// etagMatches Reports whether an If-None-Match header lists the given ETag
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicCache Returns a handler that marks successful responses as cacheable
+// by browsers and shared caches for the given number of seconds (60 if not
+// set), tags them with an ETag of their body, and answers requests that
+// already hold the current body with 304 Not Modified.
+func PublicCache(seconds int) gin.HandlerFunc {
+	if seconds <= 0 {
+		seconds = defaultPublicCacheSeconds
+	}
+	cacheControl := "public, max-age=" + strconv.Itoa(seconds)
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.Status() != http.StatusOK {
+			c.Header("Cache-Control", "no-cache")
+			c.Writer.Write(writer.body.Bytes())
+			return
+		}
+
+		sum := sha256.Sum256(writer.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		c.Header("Cache-Control", cacheControl)
+		c.Header("ETag", etag)
+
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.WriteHeader(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+
+		c.Writer.Write(writer.body.Bytes())
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/JAFAX/giron-service/globals"
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// TestMain Runs the middleware tests against a freshly migrated database in
+// a temporary directory
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	gin.SetMode(gin.TestMode)
+
+	dir, err := os.MkdirTemp("", "giron-middleware-test")
+	if err != nil {
+		panic(err)
+	}
+
+	err = model.ConnectDatabase(filepath.Join(dir, "giron.db"))
+	if err != nil {
+		panic(err)
+	}
+	_, err = model.MigrateUp()
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	model.DB.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newTestRouter Returns a router with the session store and AuthCheck in
+// front of a handler that answers 200 once the request got through
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	store, err := NewSessionStore(globals.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(sessions.Sessions("session", store))
+	r.GET("/private", AuthCheck, func(c *gin.Context) {
+		c.IndentedJSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	return r
+}
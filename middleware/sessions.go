@@ -0,0 +1,300 @@
+package middleware
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/globals"
+	"github.com/JAFAX/giron-service/model"
+	"github.com/gin-contrib/sessions"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+)
+
+// SessionSecretsEnv Names the environment variable holding the session
+// secrets, comma separated. It takes precedence over the configuration.
+const SessionSecretsEnv = "GIRON_SESSION_SECRETS"
+
+// minSessionSecretLength Is the shortest session secret accepted
+const minSessionSecretLength = 32
+
+// defaultSessionMaxAgeMinutes Is how long a session lasts unless configured
+const defaultSessionMaxAgeMinutes = 12 * 60
+
+// sessionKey Derives a key for one purpose from a session secret, so the
+// signing and encryption keys differ and always have the right length
+func sessionKey(secret string, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// sessionKeyPairs Turns the configured secrets into securecookie key pairs,
+// newest first. Without secrets, a random one is made up, and sessions then
+// end when the service restarts.
+func sessionKeyPairs(secrets []string) ([][]byte, error) {
+	if len(secrets) == 0 {
+		log.Println("WARN: No session secret configured. Using a random one, so sessions will not survive a restart")
+		random := make([]byte, minSessionSecretLength)
+		_, err := rand.Read(random)
+		if err != nil {
+			return nil, err
+		}
+		secrets = []string{string(random)}
+	}
+
+	keyPairs := [][]byte{}
+	for i, secret := range secrets {
+		if len(secret) < minSessionSecretLength {
+			return nil, errors.New("session secret " + strconv.Itoa(i+1) + " is shorter than " + strconv.Itoa(minSessionSecretLength) + " characters")
+		}
+		keyPairs = append(keyPairs, sessionKey(secret, "authentication"), sessionKey(secret, "encryption"))
+	}
+
+	return keyPairs, nil
+}
+
+// sessionSecrets Returns the session secrets from the environment, or else
+// from the configuration
+func sessionSecrets(config globals.Config) []string {
+	value := os.Getenv(SessionSecretsEnv)
+	if value == "" {
+		return config.SessionSecrets
+	}
+
+	secrets := []string{}
+	for _, secret := range strings.Split(value, ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// sessionOptions Builds the session cookie attributes from the configuration
+func sessionOptions(config globals.Config) (sessions.Options, error) {
+	maxAge := config.SessionMaxAgeMinutes
+	if maxAge <= 0 {
+		maxAge = defaultSessionMaxAgeMinutes
+	}
+	secure := config.UseTLS
+	if config.SessionCookieSecure != nil {
+		secure = *config.SessionCookieSecure
+	}
+
+	options := sessions.Options{
+		Path:     "/",
+		MaxAge:   maxAge * 60,
+		Secure:   secure,
+		HttpOnly: true,
+	}
+	switch strings.ToLower(config.SessionCookieSameSite) {
+	case "", "lax":
+		options.SameSite = http.SameSiteLaxMode
+	case "strict":
+		options.SameSite = http.SameSiteStrictMode
+	case "none":
+		if !secure {
+			return sessions.Options{}, errors.New("sessionCookieSameSite 'none' needs a secure cookie")
+		}
+		options.SameSite = http.SameSiteNoneMode
+	default:
+		return sessions.Options{}, errors.New("unknown sessionCookieSameSite '" + config.SessionCookieSameSite + "'")
+	}
+
+	return options, nil
+}
+
+// NewSessionStore Returns the session store chosen by sessionStore in the
+// configuration: "cookie" (the default) or "sqlite"
+func NewSessionStore(config globals.Config) (sessions.Store, error) {
+	keyPairs, err := sessionKeyPairs(sessionSecrets(config))
+	if err != nil {
+		return nil, err
+	}
+	options, err := sessionOptions(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var store sessions.Store
+	switch config.SessionStore {
+	case "", "cookie":
+		store = &cookieSessionStore{gsessions.NewCookieStore(keyPairs...)}
+	case "sqlite":
+		store = &sqliteSessionStore{codecs: securecookie.CodecsFromPairs(keyPairs...)}
+	default:
+		return nil, errors.New("unknown sessionStore '" + config.SessionStore + "'")
+	}
+	store.Options(options)
+
+	return store, nil
+}
+
+// RenewSession Gives the session a new Id when it is next saved. Call it
+// whenever a session gains a user, so an Id planted in a browser before the
+// login (session fixation) never gets signed in.
+func RenewSession(session sessions.Session) {
+	session.Set(globals.RenewSessionKey, true)
+}
+
+// cookieSessionStore Keeps the whole session in the cookie, signed and
+// encrypted. Such sessions cannot be revoked before they expire.
+type cookieSessionStore struct {
+	*gsessions.CookieStore
+}
+
+// Save Every save already writes a new cookie, and an old cookie does not
+// share anything with it, so renewing only needs the request dropped
+func (s *cookieSessionStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	delete(session.Values, globals.RenewSessionKey)
+	return s.CookieStore.Save(r, w, session)
+}
+
+func (s *cookieSessionStore) Options(options sessions.Options) {
+	s.CookieStore.Options = options.ToGorillaOptions()
+	// also refuse cookies older than that, not just ask browsers to drop them
+	s.CookieStore.MaxAge(options.MaxAge)
+}
+
+// sqliteSessionStore Keeps sessions in the database. The cookie only holds
+// the signed and encrypted session Id.
+type sqliteSessionStore struct {
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+}
+
+func (s *sqliteSessionStore) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+	for _, codec := range s.codecs {
+		if cookie, ok := codec.(*securecookie.SecureCookie); ok {
+			cookie.MaxAge(options.MaxAge)
+		}
+	}
+}
+
+func (s *sqliteSessionStore) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+func (s *sqliteSessionStore) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	options := *s.options
+	session.Options = &options
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	var id string
+	err = securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...)
+	if err != nil {
+		return session, err
+	}
+	data, err := model.GetSession(id)
+	if err != nil || data == nil {
+		return session, err
+	}
+	err = securecookie.GobEncoder{}.Deserialize(data, &session.Values)
+	if err != nil {
+		return session, err
+	}
+	session.ID = id
+	session.IsNew = false
+
+	return session, nil
+}
+
+func (s *sqliteSessionStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			err := model.DeleteSession(session.ID)
+			if err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if _, renew := session.Values[globals.RenewSessionKey]; renew {
+		delete(session.Values, globals.RenewSessionKey)
+		if session.ID != "" {
+			err := model.DeleteSession(session.ID)
+			if err != nil {
+				return err
+			}
+			session.ID = ""
+		}
+	}
+
+	if session.ID == "" {
+		random := make([]byte, 32)
+		_, err := rand.Read(random)
+		if err != nil {
+			return err
+		}
+		session.ID = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(random)
+		// a good moment to clear out the sessions nobody will come back for
+		model.PruneExpiredSessions()
+	}
+
+	data, err := securecookie.GobEncoder{}.Serialize(session.Values)
+	if err != nil {
+		return err
+	}
+	maxAge := session.Options.MaxAge
+	if maxAge == 0 {
+		maxAge = s.options.MaxAge
+	}
+	err = model.SaveSession(session.ID, sessionUserName(session), data, maxAge)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// sessionUserName Returns the user a session belongs to, including one who
+// still has to give their second factor
+func sessionUserName(session *gsessions.Session) string {
+	for _, key := range []string{globals.UserKey, globals.PendingUserKey} {
+		if user, ok := session.Values[key]; ok && user != nil {
+			return fmt.Sprintf("%v", user)
+		}
+	}
+	return ""
+}
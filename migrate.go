@@ -0,0 +1,87 @@
+package main
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+const migrateUsage = `usage: giron-service migrate <command>
+
+commands:
+  up        apply all pending migrations
+  down [n]  revert the last n applied migrations (default 1)
+  status    list migrations and whether they have been applied`
+
+// runMigrateCommand Handles the `migrate` subcommand and returns the process
+// exit code
+func runMigrateCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := model.MigrateUp()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "migrate up failed: "+err.Error())
+			return 1
+		}
+		fmt.Println("Applied " + strconv.Itoa(applied) + " migration(s)")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				fmt.Fprintln(os.Stderr, "migrate down: step count must be a positive number")
+				return 2
+			}
+			steps = n
+		}
+		reverted, err := model.MigrateDown(steps)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "migrate down failed: "+err.Error())
+			return 1
+		}
+		fmt.Println("Reverted " + strconv.Itoa(reverted) + " migration(s)")
+	case "status":
+		statuses, err := model.GetMigrationStatus()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "migrate status failed: "+err.Error())
+			return 1
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedDate
+			}
+			fmt.Printf("%04d  %-40s  %s\n", s.Version, s.Name, state)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
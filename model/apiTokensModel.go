@@ -0,0 +1,328 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// ApiTokenPrefix Starts every API token, so leaked tokens are easy to spot
+const ApiTokenPrefix = "gir_"
+
+// apiTokenColumns Selects a token without its hash
+const apiTokenColumns = `Id, UserId, Name, ExpiryDateTime, LastUsedDateTime, CreationDateTime`
+
+func hashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func scanApiToken(row interface{ Scan(...any) error }, token *ApiToken) error {
+	var expiry, lastUsed sql.NullString
+	err := row.Scan(&token.Id, &token.UserId, &token.Name, &expiry, &lastUsed, &token.CreationDateTime)
+	if err != nil {
+		return err
+	}
+	token.ExpiryDateTime = expiry.String
+	token.LastUsedDateTime = lastUsed.String
+	return nil
+}
+
+// Allows Reports whether the token is scoped for the privilege. Tokens
+// without scopes allow every privilege of their user.
+func (t ApiToken) Allows(privilege string) bool {
+	if len(t.Scopes) == 0 {
+		return true
+	}
+	for _, scope := range t.Scopes {
+		if scope == privilege {
+			return true
+		}
+	}
+	return false
+}
+
+// apiTokenScopes Maps the Ids of a user's tokens to the privileges they are
+// limited to
+func apiTokenScopes(userId int) (map[int][]string, error) {
+	rows, err := DB.Query(`SELECT s.TokenId, p.PrivShortName FROM ApiTokenScopes s
+		INNER JOIN ApiTokens t ON t.Id = s.TokenId
+		INNER JOIN Privileges p ON p.Id = s.PrivId
+		WHERE t.UserId = ? ORDER BY s.TokenId, p.PrivShortName`, userId)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	scopes := make(map[int][]string)
+	for rows.Next() {
+		var id int
+		var privilege string
+		err = rows.Scan(&id, &privilege)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the API token scopes!" + string(err.Error()))
+			return nil, err
+		}
+		scopes[id] = append(scopes[id], privilege)
+	}
+
+	return scopes, rows.Err()
+}
+
+// withApiTokenScopes Fills in the scopes of a token of the given user
+func withApiTokenScopes(token ApiToken) (ApiToken, error) {
+	scopes, err := apiTokenScopes(token.UserId)
+	if err != nil {
+		return ApiToken{}, err
+	}
+	token.Scopes = scopes[token.Id]
+	if token.Scopes == nil {
+		token.Scopes = make([]string, 0)
+	}
+	return token, nil
+}
+
+// CreateApiToken Creates a token for the user and returns its Id and the
+// token itself, which cannot be retrieved again
+func CreateApiToken(p ProposedApiToken, userId int) (int, string, error) {
+	log.Println("INFO: Creating an API token for user Id '" + strconv.Itoa(userId) + "': " + p.Name)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return 0, "", err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	privIds := make([]int, 0, len(p.Scopes))
+	for _, scope := range p.Scopes {
+		privId, perr := getPrivilegeId(t, scope)
+		if perr != nil {
+			err = perr
+			return 0, "", err
+		}
+		privIds = append(privIds, privId)
+	}
+
+	raw := make([]byte, 32)
+	_, err = rand.Read(raw)
+	if err != nil {
+		log.Println("ERROR: Cannot generate API token: " + string(err.Error()))
+		return 0, "", err
+	}
+	token := ApiTokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	// datetime() gives NULL, so no expiry, when there is no modifier
+	var expiry interface{}
+	if p.ExpiresInDays > 0 {
+		expiry = "+" + strconv.Itoa(p.ExpiresInDays) + " days"
+	}
+
+	result, err := t.Exec("INSERT INTO ApiTokens (UserId, Name, TokenHash, ExpiryDateTime) VALUES (?, ?, ?, datetime('now', ?))",
+		userId, strings.TrimSpace(p.Name), hashApiToken(token), expiry)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return 0, "", err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot get the Id of the new API token: " + string(err.Error()))
+		return 0, "", err
+	}
+
+	for _, privId := range privIds {
+		_, err = t.Exec("INSERT OR IGNORE INTO ApiTokenScopes (TokenId, PrivId) VALUES (?, ?)", id, privId)
+		if err != nil {
+			log.Println("ERROR: Cannot set scopes of API token Id '" + strconv.Itoa(int(id)) + "': " + string(err.Error()))
+			return 0, "", err
+		}
+	}
+
+	err = auditInsert(t, userId, "ApiTokens", result)
+	if err != nil {
+		return 0, "", err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return 0, "", err
+	}
+
+	log.Println("INFO: API token entry created")
+	return int(id), token, nil
+}
+
+func GetApiTokensByUserId(userId int) ([]ApiToken, error) {
+	log.Println("INFO: List of API tokens requested for user Id: " + strconv.Itoa(userId))
+	scopes, err := apiTokenScopes(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := DB.Query("SELECT "+apiTokenColumns+" FROM ApiTokens WHERE UserId = ? ORDER BY Id", userId)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]ApiToken, 0)
+	for rows.Next() {
+		token := ApiToken{}
+		err = scanApiToken(rows, &token)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the API token objects!" + string(err.Error()))
+			return nil, err
+		}
+		token.Scopes = scopes[token.Id]
+		if token.Scopes == nil {
+			token.Scopes = make([]string, 0)
+		}
+		tokens = append(tokens, token)
+	}
+
+	log.Println("INFO: List of API tokens retrieved")
+	return tokens, rows.Err()
+}
+
+func GetApiTokenById(id int) (ApiToken, error) {
+	log.Println("INFO: API token by Id requested: " + strconv.Itoa(id))
+	token := ApiToken{}
+	err := scanApiToken(DB.QueryRow("SELECT "+apiTokenColumns+" FROM ApiTokens WHERE Id = ?", id), &token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such API token found in DB: " + string(err.Error()))
+			return ApiToken{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve API token from DB: " + string(err.Error()))
+		return ApiToken{}, err
+	}
+
+	log.Println("INFO: API token by Id '" + strconv.Itoa(id) + "' retrieved")
+	return withApiTokenScopes(token)
+}
+
+// AuthenticateApiToken Looks up an unexpired token and notes that it has been
+// used. An empty token is returned when it is unknown or has expired.
+func AuthenticateApiToken(value string) (ApiToken, error) {
+	token := ApiToken{}
+	err := scanApiToken(DB.QueryRow("SELECT "+apiTokenColumns+` FROM ApiTokens
+		WHERE TokenHash = ? AND (ExpiryDateTime IS NULL OR ExpiryDateTime > CURRENT_TIMESTAMP)`, hashApiToken(value)), &token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ApiToken{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve API token from DB: " + string(err.Error()))
+		return ApiToken{}, err
+	}
+
+	// failing to note the use shouldn't block the request
+	_, err = DB.Exec("UPDATE ApiTokens SET LastUsedDateTime = CURRENT_TIMESTAMP WHERE Id = ?", token.Id)
+	if err != nil {
+		log.Println("WARN: Cannot record use of API token Id '" + strconv.Itoa(token.Id) + "': " + string(err.Error()))
+	}
+
+	return withApiTokenScopes(token)
+}
+
+// DeleteApiTokenById Revokes one of the user's own tokens
+func DeleteApiTokenById(id int, userId int) (bool, error) {
+	log.Println("INFO: API token revocation requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var ownerId int
+	err = t.QueryRow("SELECT UserId FROM ApiTokens WHERE Id = ?", id).Scan(&ownerId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such API token found in DB: " + string(err.Error()))
+			err = t.Rollback()
+			return false, err
+		}
+		log.Println("ERROR: Cannot retrieve API token from DB: " + string(err.Error()))
+		return false, err
+	}
+	if ownerId != userId {
+		log.Println("WARN: User Id '" + strconv.Itoa(userId) + "' tried to revoke API token Id '" + strconv.Itoa(id) + "' of another user")
+		err = t.Rollback()
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "ApiTokens", id)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = t.Exec("DELETE FROM ApiTokenScopes WHERE TokenId = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete scopes of API token with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	_, err = t.Exec("DELETE FROM ApiTokens WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete API token with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "ApiTokens", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: API token with id '" + strconv.Itoa(id) + "' has been revoked")
+	return true, nil
+}
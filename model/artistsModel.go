@@ -0,0 +1,215 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const artistColumns = `Id, Name, Description, MailingAddress, EmailAddress, PhoneNumber, IFNULL(BoothId, 0), Invoiced, InvoiceFulfilled, CreatorId, CreationDate, ApprovalState, IFNULL(ApprovedById, 0), IFNULL(ApprovalDateTime, '')`
+
+func CreateArtist(p ProposedArtist, userId int) (bool, error) {
+	log.Println("INFO: Creating an artist: " + p.Name)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	q, err := t.Prepare("INSERT INTO Artists (Name, Description, MailingAddress, EmailAddress, PhoneNumber, CreatorId) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.Name), strings.TrimSpace(p.Description), strings.TrimSpace(p.MailingAddress), strings.TrimSpace(p.EmailAddress), strings.TrimSpace(p.PhoneNumber), userId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Artists", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Artist entry created")
+	return true, nil
+}
+
+func scanArtists(rows *sql.Rows) ([]Artist, error) {
+	artists := make([]Artist, 0)
+	for rows.Next() {
+		artist := Artist{}
+		err := rows.Scan(
+			&artist.Id,
+			&artist.Name,
+			&artist.Description,
+			&artist.MailingAddress,
+			&artist.EmailAddress,
+			&artist.PhoneNumber,
+			&artist.BoothId,
+			&artist.Invoiced,
+			&artist.InvoiceFulfilled,
+			&artist.CreatorId,
+			&artist.CreationDate,
+			&artist.ApprovalState,
+			&artist.ApprovedById,
+			&artist.ApprovalDateTime,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the artist objects!" + string(err.Error()))
+			return nil, err
+		}
+		artists = append(artists, artist)
+	}
+
+	return artists, rows.Err()
+}
+
+func GetArtists() ([]Artist, error) {
+	log.Println("INFO: List of artist objects requested")
+	rows, err := DB.Query("SELECT " + artistColumns + " FROM Artists ORDER BY Name")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	artists, err := scanArtists(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all artists retrieved")
+	return artists, nil
+}
+
+func GetArtistById(id int) (Artist, error) {
+	log.Println("INFO: Artist by Id requested: " + strconv.Itoa(id))
+	rows, err := DB.Query("SELECT "+artistColumns+" FROM Artists WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return Artist{}, err
+	}
+	defer rows.Close()
+
+	artists, err := scanArtists(rows)
+	if err != nil {
+		return Artist{}, err
+	}
+	if len(artists) == 0 {
+		log.Println("ERROR: No such artist found in DB")
+		return Artist{}, nil
+	}
+
+	log.Println("INFO: Artist by Id '" + strconv.Itoa(id) + "' retrieved")
+	return artists[0], nil
+}
+
+func UpdateArtistById(id int, p ProposedArtist, userId int) (bool, error) {
+	log.Println("INFO: Update artist Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Artists", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE Artists SET Name = ?, Description = ?, MailingAddress = ?, EmailAddress = ?, PhoneNumber = ? WHERE Id = ?", strings.TrimSpace(p.Name), strings.TrimSpace(p.Description), strings.TrimSpace(p.MailingAddress), strings.TrimSpace(p.EmailAddress), strings.TrimSpace(p.PhoneNumber), id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for artist Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such artist found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Artists", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Artist entry updated")
+	return true, nil
+}
+
+func DeleteArtistById(id int, userId int) (bool, error) {
+	return deleteVendorHallEntry(artistTable, id, userId)
+}
+
+func SetApprovalStateArtistById(id int, status VendorHallApproval, userId int) (bool, error) {
+	return setVendorHallApproval(artistTable, id, status, userId)
+}
+
+func SetArtistInvoiceState(id int, change InvoiceStateChange, userId int) (bool, error) {
+	return setVendorHallInvoiceState(artistTable, id, change, userId)
+}
+
+// SetArtistBooth Gives a artist a booth, or takes it away when the booth Id is 0
+func SetArtistBooth(id int, j BoothAssignment, userId int) (bool, error) {
+	return setBooth(artistTable, BoothHolderArtist, id, j.BoothId, userId)
+}
@@ -0,0 +1,280 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// columns that must never be copied into the audit trail
+var auditRedactedColumns = map[string]bool{
+	"PasswordHash": true,
+	"Secret":       true,
+	"TokenHash":    true,
+}
+
+// auditSnapshot Reads a row as a column to value map so it can be stored as
+// the before or after value of an audit entry. Returns nil if the row does
+// not exist. The table name must be a constant, never user input.
+func auditSnapshot(t *sql.Tx, table string, id int) (map[string]interface{}, error) {
+	rows, err := t.Query("SELECT * FROM "+table+" WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot read '" + table + "' row for audit: " + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	err = rows.Scan(pointers...)
+	if err != nil {
+		log.Println("ERROR: Cannot scan '" + table + "' row for audit: " + string(err.Error()))
+		return nil, err
+	}
+
+	snapshot := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if auditRedactedColumns[column] {
+			continue
+		}
+		// text columns can come back from the driver as raw bytes
+		if b, ok := values[i].([]byte); ok {
+			snapshot[column] = string(b)
+		} else {
+			snapshot[column] = values[i]
+		}
+	}
+
+	return snapshot, nil
+}
+
+// recordAudit Writes an audit entry as part of the caller's transaction, so the
+// entry is only kept if the change itself is committed
+func recordAudit(t *sql.Tx, userId int, table string, changeClass string, recordId int, before map[string]interface{}, after map[string]interface{}) error {
+	beforeValue, err := auditValue(before)
+	if err != nil {
+		return err
+	}
+	afterValue, err := auditValue(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.Exec(`INSERT INTO Audit (ChangedById, TableChanged, ChangeClass, RecordId, BeforeValue, AfterValue)
+		VALUES (?, ?, ?, ?, ?, ?)`, userId, table, changeClass, recordId, beforeValue, afterValue)
+	if err != nil {
+		log.Println("ERROR: Cannot write audit entry for '" + table + "' Id '" + strconv.Itoa(recordId) + "': " + string(err.Error()))
+		return err
+	}
+
+	// every audited change to a synced table is also a change for sync clients
+	return recordSyncChange(t, table, recordId, changeClass == AuditDelete)
+}
+
+// auditInsert Records the row created by result
+func auditInsert(t *sql.Tx, userId int, table string, result sql.Result) error {
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve the new '" + table + "' Id for audit: " + string(err.Error()))
+		return err
+	}
+	after, err := auditSnapshot(t, table, int(id))
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(t, userId, table, AuditCreate, int(id), nil, after)
+}
+
+// auditUpdate Records the change to a row, given its snapshot from before the
+// update. Nothing is recorded if the row did not exist.
+func auditUpdate(t *sql.Tx, userId int, table string, id int, before map[string]interface{}) error {
+	if before == nil {
+		return nil
+	}
+	after, err := auditSnapshot(t, table, id)
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(t, userId, table, AuditUpdate, id, before, after)
+}
+
+// auditDelete Records the removal of a row, given its snapshot from before the
+// delete. Nothing is recorded if the row did not exist.
+func auditDelete(t *sql.Tx, userId int, table string, id int, before map[string]interface{}) error {
+	if before == nil {
+		return nil
+	}
+
+	return recordAudit(t, userId, table, AuditDelete, id, before, nil)
+}
+
+// auditDeleteWhere Records the removal of every row of a table matching a
+// condition, before the caller deletes them. The table and condition must be
+// constants, never user input.
+func auditDeleteWhere(t *sql.Tx, userId int, table string, where string, args ...interface{}) error {
+	rows, err := t.Query("SELECT Id FROM "+table+" WHERE "+where, args...)
+	if err != nil {
+		log.Println("ERROR: Cannot read '" + table + "' rows for audit: " + string(err.Error()))
+		return err
+	}
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot scan '" + table + "' row for audit: " + string(err.Error()))
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		before, err := auditSnapshot(t, table, id)
+		if err != nil {
+			return err
+		}
+		err = auditDelete(t, userId, table, id, before)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func auditValue(snapshot map[string]interface{}) (sql.NullString, error) {
+	if snapshot == nil {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Println("ERROR: Cannot marshal audit value: " + string(err.Error()))
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+func GetAuditEntries(f AuditFilter) ([]AuditEntry, error) {
+	log.Println("INFO: List of audit entries requested")
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if f.TableChanged != "" {
+		conditions = append(conditions, "TableChanged = ?")
+		args = append(args, f.TableChanged)
+	}
+	if f.ChangedById != 0 {
+		conditions = append(conditions, "ChangedById = ?")
+		args = append(args, f.ChangedById)
+	}
+	if f.ChangeClass != "" {
+		conditions = append(conditions, "ChangeClass = ?")
+		args = append(args, f.ChangeClass)
+	}
+	if f.RecordId != 0 {
+		conditions = append(conditions, "RecordId = ?")
+		args = append(args, f.RecordId)
+	}
+	if f.Since != "" {
+		conditions = append(conditions, "ChangeDate >= ?")
+		args = append(args, f.Since)
+	}
+	if f.Until != "" {
+		conditions = append(conditions, "ChangeDate < ?")
+		args = append(args, f.Until)
+	}
+
+	query := "SELECT Id, ChangedById, TableChanged, ChangeClass, RecordId, BeforeValue, AfterValue, ChangeDate FROM Audit"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY Id DESC LIMIT ?"
+	args = append(args, f.Limit)
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]AuditEntry, 0)
+	for rows.Next() {
+		entry := AuditEntry{}
+		var recordId sql.NullInt64
+		var before, after sql.NullString
+		err = rows.Scan(
+			&entry.Id,
+			&entry.ChangedById,
+			&entry.TableChanged,
+			&entry.ChangeClass,
+			&recordId,
+			&before,
+			&after,
+			&entry.ChangeDate,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the audit objects!" + string(err.Error()))
+			return nil, err
+		}
+		entry.RecordId = int(recordId.Int64)
+		if before.Valid {
+			if err = json.Unmarshal([]byte(before.String), &entry.Before); err != nil {
+				return nil, err
+			}
+		}
+		if after.Valid {
+			if err = json.Unmarshal([]byte(after.String), &entry.After); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	log.Println("INFO: List of audit entries retrieved")
+	return entries, nil
+}
@@ -0,0 +1,274 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+func CreateBooth(p ProposedBooth, userId int) (bool, error) {
+	log.Println("INFO: Creating a booth: " + p.Label)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	exists, err := locationExists(t, p.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(p.LocationId))}
+		return false, err
+	}
+
+	q, err := t.Prepare("INSERT INTO Booths (Label, LocationId, CreatorId) VALUES (?, ?, ?)")
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.Label), p.LocationId, userId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Booths", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Booth entry created")
+	return true, nil
+}
+
+func DeleteBoothById(id int, userId int) (bool, error) {
+	log.Println("INFO: Booth deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	holderType, holderId, err := boothHolder(t, id)
+	if err != nil {
+		return false, err
+	}
+	if holderType != "" {
+		err = &BoothInUse{Err: errors.New("booth Id '" + strconv.Itoa(id) + "' is held by " + holderType + " Id '" + strconv.Itoa(holderId) + "'")}
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "Booths", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("DELETE FROM Booths WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete booth with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such booth found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "Booths", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Booth with id '" + strconv.Itoa(id) + "' has been deleted")
+	return true, nil
+}
+
+// boothQuery Selects booths with their room and whoever holds them
+const boothQuery = `SELECT b.Id, b.Label, b.LocationId, IFNULL(l.RoomName, ''),
+		CASE WHEN v.Id IS NOT NULL THEN 'vendor' WHEN a.Id IS NOT NULL THEN 'artist' ELSE '' END,
+		IFNULL(v.Id, IFNULL(a.Id, 0)), IFNULL(v.CompanyName, IFNULL(a.Name, '')),
+		b.CreatorId, b.CreationDate
+	FROM Booths b
+	LEFT JOIN Locations l ON l.Id = b.LocationId
+	LEFT JOIN Vendors v ON v.BoothId = b.Id
+	LEFT JOIN Artists a ON a.BoothId = b.Id`
+
+func boothScanTargets(booth *Booth) []any {
+	return []any{
+		&booth.Id,
+		&booth.Label,
+		&booth.LocationId,
+		&booth.RoomName,
+		&booth.HolderType,
+		&booth.HolderId,
+		&booth.HolderName,
+		&booth.CreatorId,
+		&booth.CreationDate,
+	}
+}
+
+func GetBooths() ([]Booth, error) {
+	log.Println("INFO: List of booth objects requested")
+	rows, err := DB.Query(boothQuery + " ORDER BY l.RoomName, b.Label, b.Id")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	booths := make([]Booth, 0)
+	for rows.Next() {
+		booth := Booth{}
+		err = rows.Scan(boothScanTargets(&booth)...)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the booth objects!" + string(err.Error()))
+			return nil, err
+		}
+		booths = append(booths, booth)
+	}
+
+	log.Println("INFO: List of all booths retrieved")
+	return booths, rows.Err()
+}
+
+func GetBoothById(id int) (Booth, error) {
+	log.Println("INFO: Booth by Id requested: " + strconv.Itoa(id))
+	booth := Booth{}
+	err := DB.QueryRow(boothQuery+" WHERE b.Id = ?", id).Scan(boothScanTargets(&booth)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such booth found in DB: " + string(err.Error()))
+			return Booth{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve booth from DB: " + string(err.Error()))
+		return Booth{}, err
+	}
+
+	log.Println("INFO: Booth by Id '" + strconv.Itoa(id) + "' retrieved")
+	return booth, nil
+}
+
+func UpdateBoothById(id int, p ProposedBooth, userId int) (bool, error) {
+	log.Println("INFO: Update booth Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	exists, err := locationExists(t, p.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(p.LocationId))}
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "Booths", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE Booths SET Label = ?, LocationId = ? WHERE Id = ?", strings.TrimSpace(p.Label), p.LocationId, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for booth Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such booth found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Booths", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Booth entry updated")
+	return true, nil
+}
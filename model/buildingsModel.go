@@ -20,7 +20,6 @@ package model
 
 import (
 	"database/sql"
-	"encoding/json"
 	"log"
 	"strconv"
 )
@@ -50,12 +49,17 @@ func CreateBuilding(p ProposedBuilding, id int) (bool, error) {
 		return false, err
 	}
 
-	_, err = q.Exec(p.Name, p.City, p.Region, id)
+	result, err := q.Exec(p.Name, p.City, p.Region, id)
 	if err != nil {
 		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
 		return false, err
 	}
 
+	err = auditInsert(t, id, "Buildings", result)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -103,31 +107,16 @@ func GetBuildingById(id int) (Building, error) {
 
 func GetBuildingIdByName(buildingName string) (int, error) {
 	log.Println("INFO: Getting building id by name")
-	ent, err := DB.Prepare("SELECT Id FROM Buildings WHERE Name = ?")
-	if err != nil {
-		log.Println("ERROR: Cannot prepare SQL query: " + string(err.Error()))
-		return -1, err
-	}
-	defer ent.Close()
-
 	var id int
-	record, err := ent.Query(buildingName)
+	err := DB.QueryRow("SELECT Id FROM Buildings WHERE Name = ?", buildingName).Scan(&id)
 	if err != nil {
-		if err != sql.ErrNoRows {
-			log.Println("ERROR: No such building found in DB: " + string(err.Error()))
-			return -1, sql.ErrNoRows
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such building found in DB: " + buildingName)
+			return -1, err
 		}
 		log.Println("ERROR: Cannot retrieve building from DB: " + string(err.Error()))
 		return -1, err
 	}
-	err = record.Scan(
-		&id,
-	)
-	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the building object!" + string(err.Error()))
-		return -1, err
-	}
-	defer record.Close()
 
 	return id, nil
 }
@@ -164,7 +153,7 @@ func GetBuildings() ([]Building, error) {
 	return buildings, nil
 }
 
-func UpdateBuildingById(id int, b BuildingUpdate) (bool, error) {
+func UpdateBuildingById(id int, b BuildingUpdate, userId int) (bool, error) {
 	t, err := DB.Begin()
 	if err != nil {
 		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
@@ -181,6 +170,11 @@ func UpdateBuildingById(id int, b BuildingUpdate) (bool, error) {
 		}
 	}()
 
+	before, err := auditSnapshot(t, "Buildings", id)
+	if err != nil {
+		return false, err
+	}
+
 	q, err := t.Prepare("UPDATE Buildings SET Name = ?, City = ?, Region = ? WHERE Id = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
@@ -189,14 +183,14 @@ func UpdateBuildingById(id int, b BuildingUpdate) (bool, error) {
 	log.Println("INFO: Building ID to update: " + strconv.Itoa(id))
 	log.Println("INFO: Incoming data: name: " + b.Name + ", city: " + b.City + ", region: " + b.Region)
 
-	building, err := json.Marshal(b)
+	_, err = q.Exec(b.Name, b.City, b.Region, id)
 	if err != nil {
-		log.Println("ERROR: Cannot marshal JSON: " + string(err.Error()))
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
 		return false, err
 	}
-	_, err = q.Exec(building, id)
+
+	err = auditUpdate(t, userId, "Buildings", id, before)
 	if err != nil {
-		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
 		return false, err
 	}
 
@@ -210,7 +204,7 @@ func UpdateBuildingById(id int, b BuildingUpdate) (bool, error) {
 	return true, nil
 }
 
-func DeleteBuildingById(id int) (bool, error) {
+func DeleteBuildingById(id int, userId int) (bool, error) {
 	log.Println("INFO: User deletion requested for Id: " + strconv.Itoa(id))
 	t, err := DB.Begin()
 	if err != nil {
@@ -228,7 +222,12 @@ func DeleteBuildingById(id int) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("DELETE FROM Buildings WHERE Id IS ?")
+	before, err := auditSnapshot(t, "Buildings", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("DELETE FROM Buildings WHERE Id IS ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
 		return false, err
@@ -240,6 +239,11 @@ func DeleteBuildingById(id int) (bool, error) {
 		return false, err
 	}
 
+	err = auditDelete(t, userId, "Buildings", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
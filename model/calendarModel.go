@@ -0,0 +1,142 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"time"
+)
+
+// calendarQuery Selects every scheduled event that may be published: approved
+// panels and all screenings, with the place they are held in. An event was
+// last changed when it, or the room, floor or building it is held in, last
+// moved in the sync log, or else when it was created.
+const calendarQuery = `SELECT e.EventType, e.Id, e.Title, e.Description, IFNULL(e.LocationId, 0),
+		IFNULL(l.RoomName, ''), IFNULL(f.FloorName, ''), IFNULL(b.Name, ''),
+		e.ScheduledTime, e.DurationInMinutes, e.Sequence,
+		MAX(IFNULL(CAST(strftime('%s', e.CreationDateTime) AS INTEGER), 0),
+			IFNULL(CAST(strftime('%s', es.ChangeDateTime) AS INTEGER), 0),
+			IFNULL(CAST(strftime('%s', ls.ChangeDateTime) AS INTEGER), 0),
+			IFNULL(CAST(strftime('%s', fs.ChangeDateTime) AS INTEGER), 0),
+			IFNULL(CAST(strftime('%s', bs.ChangeDateTime) AS INTEGER), 0))
+	FROM (
+		SELECT 'panel' AS EventType, Id, Topic AS Title, Description, LocationId, ScheduledTime, DurationInMinutes, Sequence, CreationDateTime
+			FROM Panels WHERE ApprovalStatus = TRUE
+		UNION ALL
+		SELECT 'screening', Id, Title, Synopsis, LocationId, ScheduledTime, DurationInMinutes, Sequence, CreationDateTime
+			FROM VideoScreenings
+	) e
+	LEFT JOIN Locations l ON l.Id = e.LocationId
+	LEFT JOIN BuildingFloors f ON f.Id = l.FloorId
+	LEFT JOIN Buildings b ON b.Id = l.BuildingId
+	LEFT JOIN SyncLog es ON es.EntityType = e.EventType AND es.EntityId = e.Id
+	LEFT JOIN SyncLog ls ON ls.EntityType = '` + SyncLocation + `' AND ls.EntityId = l.Id
+	LEFT JOIN SyncLog fs ON fs.EntityType = '` + SyncFloor + `' AND fs.EntityId = f.Id
+	LEFT JOIN SyncLog bs ON bs.EntityType = '` + SyncBuilding + `' AND bs.EntityId = b.Id
+	WHERE e.ScheduledTime IS NOT NULL AND e.ScheduledTime != ''`
+
+// GetCalendarEvents Lists the events to publish in the iCalendar feeds, in
+// order of their start time
+func GetCalendarEvents(filter CalendarFilter) ([]CalendarEvent, error) {
+	log.Println("INFO: Calendar events requested")
+	query := calendarQuery
+	args := make([]interface{}, 0)
+	if filter.LocationId != 0 {
+		query += " AND l.Id = ?"
+		args = append(args, filter.LocationId)
+	}
+	if filter.BuildingId != 0 {
+		query += " AND l.BuildingId = ?"
+		args = append(args, filter.BuildingId)
+	}
+	query += " ORDER BY e.ScheduledTime, e.EventType, e.Id"
+
+	// only events carrying every requested tag make it into the feed
+	var taggedEvents map[string]map[int]bool
+	if len(filter.TagNames) > 0 {
+		taggedPanels, err := GetPanelIdsByTagNames(filter.TagNames)
+		if err != nil {
+			return nil, err
+		}
+		taggedScreenings, err := GetScreeningIdsByTagNames(filter.TagNames)
+		if err != nil {
+			return nil, err
+		}
+		taggedEvents = map[string]map[int]bool{
+			EventPanel:     taggedPanels,
+			EventScreening: taggedScreenings,
+		}
+	}
+
+	panelTags, err := GetPublishedPanelTagNames()
+	if err != nil {
+		return nil, err
+	}
+	screeningTags, err := GetPublishedScreeningTagNames()
+	if err != nil {
+		return nil, err
+	}
+	eventTags := map[string]map[int][]string{
+		EventPanel:     panelTags,
+		EventScreening: screeningTags,
+	}
+
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]CalendarEvent, 0)
+	for rows.Next() {
+		event := CalendarEvent{}
+		var lastChanged int64
+		err = rows.Scan(
+			&event.EventType,
+			&event.Id,
+			&event.Title,
+			&event.Description,
+			&event.LocationId,
+			&event.RoomName,
+			&event.FloorName,
+			&event.BuildingName,
+			&event.ScheduledTime,
+			&event.DurationInMinutes,
+			&event.Sequence,
+			&lastChanged,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the calendar events!" + string(err.Error()))
+			return nil, err
+		}
+		if taggedEvents != nil && !taggedEvents[event.EventType][event.Id] {
+			continue
+		}
+		event.Tags = eventTags[event.EventType][event.Id]
+		event.LastChanged = time.Unix(lastChanged, 0).UTC()
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: Calendar events retrieved")
+	return events, nil
+}
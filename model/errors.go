@@ -35,9 +35,162 @@ func (p *PasswordHashMismatch) Error() string {
 }
 
 type SchedulingConflict struct {
-	Err error
+	Err       error
+	Conflicts []ScheduleConflict
 }
 
 func (s *SchedulingConflict) Error() string {
 	return "Scheduling conflict: Start or end of event conflicts with existing scheduled event"
 }
+
+type NoSuchLocation struct {
+	Err error
+}
+
+func (n *NoSuchLocation) Error() string {
+	return "No such location: Location Id does not exist"
+}
+
+type InvalidRating struct {
+	Err error
+}
+
+func (i *InvalidRating) Error() string {
+	return "Invalid rating! Must be a whole number from 1 to 5"
+}
+
+type NoSuchBooth struct {
+	Err error
+}
+
+func (n *NoSuchBooth) Error() string {
+	return "No such booth: Booth Id does not exist"
+}
+
+type BoothInUse struct {
+	Err error
+}
+
+func (b *BoothInUse) Error() string {
+	return "Booth in use: " + b.Err.Error()
+}
+
+type InvalidInvoiceTransition struct {
+	Err error
+}
+
+func (i *InvalidInvoiceTransition) Error() string {
+	return "Invalid invoice state change: " + i.Err.Error()
+}
+
+type InvalidPanelStateTransition struct {
+	Err error
+}
+
+func (i *InvalidPanelStateTransition) Error() string {
+	return "Invalid panel state change: " + i.Err.Error()
+}
+
+type NoSuchLiveEvent struct {
+	Err error
+}
+
+func (n *NoSuchLiveEvent) Error() string {
+	return "No such live event: Live event Id does not exist"
+}
+
+type NoSuchProposal struct {
+	Err error
+}
+
+func (n *NoSuchProposal) Error() string {
+	return "No such proposal: verification link is invalid or has expired"
+}
+
+type TooManyRequests struct {
+	Err error
+}
+
+func (t *TooManyRequests) Error() string {
+	return "Too many requests: " + t.Err.Error()
+}
+
+type NoSuchTag struct {
+	Err error
+}
+
+func (n *NoSuchTag) Error() string {
+	return "No such tag: Tag Id does not exist"
+}
+
+type NoSuchPrivilege struct {
+	Err error
+}
+
+func (n *NoSuchPrivilege) Error() string {
+	return "No such privilege: Privilege name does not exist"
+}
+
+type NoSuchRole struct {
+	Err error
+}
+
+func (n *NoSuchRole) Error() string {
+	return "No such role: Role Id does not exist"
+}
+
+type UnknownPasswordHashFormat struct {
+	Err error
+}
+
+func (u *UnknownPasswordHashFormat) Error() string {
+	return "Unknown password hash format: " + u.Err.Error()
+}
+
+type SchemaTooNew struct {
+	Err error
+}
+
+func (s *SchemaTooNew) Error() string {
+	return "Schema too new: " + s.Err.Error()
+}
+
+type InvalidTwoFactorCode struct {
+	Err error
+}
+
+func (i *InvalidTwoFactorCode) Error() string {
+	return "Invalid two-factor code: " + i.Err.Error()
+}
+
+type TwoFactorState struct {
+	Err error
+}
+
+func (t *TwoFactorState) Error() string {
+	return "Two-factor authentication: " + t.Err.Error()
+}
+
+type LastAdministrator struct {
+	Err error
+}
+
+func (l *LastAdministrator) Error() string {
+	return "Last administrator: " + l.Err.Error()
+}
+
+type NoSuchPanel struct {
+	Err error
+}
+
+func (n *NoSuchPanel) Error() string {
+	return "No such panel: Panel Id does not exist"
+}
+
+type NoSuchScreening struct {
+	Err error
+}
+
+func (n *NoSuchScreening) Error() string {
+	return "No such screening: Screening Id does not exist"
+}
@@ -0,0 +1,232 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const exhibitorColumns = `Id, Name, MailingAddress, PhoneNumber, EmailAddress, EventId, Invoiced, InvoiceFulfilled, CreatorId, CreationDate, ApprovalState, IFNULL(ApprovedById, 0), IFNULL(ApprovalDateTime, '')`
+
+func CreateExhibitor(p ProposedExhibitor, userId int) (bool, error) {
+	log.Println("INFO: Creating an exhibitor: " + p.Name)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM LiveEvents WHERE Id = ?", p.EventId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for live event Id '" + strconv.Itoa(p.EventId) + "': " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		err = &NoSuchLiveEvent{Err: errors.New("invalid live event Id: " + strconv.Itoa(p.EventId))}
+		return false, err
+	}
+
+	q, err := t.Prepare("INSERT INTO Exhibitors (Name, MailingAddress, PhoneNumber, EmailAddress, EventId, CreatorId) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.Name), strings.TrimSpace(p.MailingAddress), strings.TrimSpace(p.PhoneNumber), strings.TrimSpace(p.EmailAddress), p.EventId, userId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Exhibitors", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Exhibitor entry created")
+	return true, nil
+}
+
+func scanExhibitors(rows *sql.Rows) ([]Exhibitor, error) {
+	exhibitors := make([]Exhibitor, 0)
+	for rows.Next() {
+		exhibitor := Exhibitor{}
+		err := rows.Scan(
+			&exhibitor.Id,
+			&exhibitor.Name,
+			&exhibitor.MailingAddress,
+			&exhibitor.PhoneNumber,
+			&exhibitor.EmailAddress,
+			&exhibitor.EventId,
+			&exhibitor.Invoiced,
+			&exhibitor.InvoiceFulfilled,
+			&exhibitor.CreatorId,
+			&exhibitor.CreationDate,
+			&exhibitor.ApprovalState,
+			&exhibitor.ApprovedById,
+			&exhibitor.ApprovalDateTime,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the exhibitor objects!" + string(err.Error()))
+			return nil, err
+		}
+		exhibitors = append(exhibitors, exhibitor)
+	}
+
+	return exhibitors, rows.Err()
+}
+
+func GetExhibitors() ([]Exhibitor, error) {
+	log.Println("INFO: List of exhibitor objects requested")
+	rows, err := DB.Query("SELECT " + exhibitorColumns + " FROM Exhibitors ORDER BY Name")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	exhibitors, err := scanExhibitors(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all exhibitors retrieved")
+	return exhibitors, nil
+}
+
+func GetExhibitorById(id int) (Exhibitor, error) {
+	log.Println("INFO: Exhibitor by Id requested: " + strconv.Itoa(id))
+	rows, err := DB.Query("SELECT "+exhibitorColumns+" FROM Exhibitors WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return Exhibitor{}, err
+	}
+	defer rows.Close()
+
+	exhibitors, err := scanExhibitors(rows)
+	if err != nil {
+		return Exhibitor{}, err
+	}
+	if len(exhibitors) == 0 {
+		log.Println("ERROR: No such exhibitor found in DB")
+		return Exhibitor{}, nil
+	}
+
+	log.Println("INFO: Exhibitor by Id '" + strconv.Itoa(id) + "' retrieved")
+	return exhibitors[0], nil
+}
+
+func UpdateExhibitorById(id int, p ProposedExhibitor, userId int) (bool, error) {
+	log.Println("INFO: Update exhibitor Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM LiveEvents WHERE Id = ?", p.EventId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for live event Id '" + strconv.Itoa(p.EventId) + "': " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		err = &NoSuchLiveEvent{Err: errors.New("invalid live event Id: " + strconv.Itoa(p.EventId))}
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "Exhibitors", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE Exhibitors SET Name = ?, MailingAddress = ?, PhoneNumber = ?, EmailAddress = ?, EventId = ? WHERE Id = ?", strings.TrimSpace(p.Name), strings.TrimSpace(p.MailingAddress), strings.TrimSpace(p.PhoneNumber), strings.TrimSpace(p.EmailAddress), p.EventId, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for exhibitor Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such exhibitor found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Exhibitors", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Exhibitor entry updated")
+	return true, nil
+}
+
+func DeleteExhibitorById(id int, userId int) (bool, error) {
+	return deleteVendorHallEntry(exhibitorTable, id, userId)
+}
+
+func SetApprovalStateExhibitorById(id int, status VendorHallApproval, userId int) (bool, error) {
+	return setVendorHallApproval(exhibitorTable, id, status, userId)
+}
+
+func SetExhibitorInvoiceState(id int, change InvoiceStateChange, userId int) (bool, error) {
+	return setVendorHallInvoiceState(exhibitorTable, id, change, userId)
+}
@@ -45,7 +45,7 @@ func CreateFloor(f ProposedFloor, id int) (bool, error) {
 	// get building ID from building name
 	buildingId, err := GetBuildingIdByName(f.BuildingName)
 	if err != nil {
-		log.Println("ERROR: Cannot prepare SQL query: " + string(err.Error()))
+		log.Println("ERROR: Cannot find building '" + f.BuildingName + "': " + string(err.Error()))
 		return false, err
 	}
 
@@ -55,12 +55,17 @@ func CreateFloor(f ProposedFloor, id int) (bool, error) {
 		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
 		return false, err
 	}
-	_, err = q.Exec(f.Name, buildingId, id)
+	result, err := q.Exec(f.Name, buildingId, id)
 	if err != nil {
 		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
 		return false, err
 	}
 
+	err = auditInsert(t, id, "BuildingFloors", result)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -71,7 +76,7 @@ func CreateFloor(f ProposedFloor, id int) (bool, error) {
 	return true, nil
 }
 
-func DeleteFloorById(id int) (bool, error) {
+func DeleteFloorById(id int, userId int) (bool, error) {
 	log.Println("INFO: Floor deletion requested: " + strconv.Itoa(id))
 	t, err := DB.Begin()
 	if err != nil {
@@ -89,7 +94,12 @@ func DeleteFloorById(id int) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("DELETE FROM BuildingFloors WHERE Id IS ?")
+	before, err := auditSnapshot(t, "BuildingFloors", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("DELETE FROM BuildingFloors WHERE Id IS ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
 		return false, err
@@ -101,6 +111,11 @@ func DeleteFloorById(id int) (bool, error) {
 		return false, err
 	}
 
+	err = auditDelete(t, userId, "BuildingFloors", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -207,7 +222,7 @@ func GetFloorById(id int) (BuildingFloor, error) {
 	return floor, nil
 }
 
-func UpdateFloorById(id int, f FloorUpdate) (bool, error) {
+func UpdateFloorById(id int, f FloorUpdate, userId int) (bool, error) {
 	t, err := DB.Begin()
 	if err != nil {
 		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
@@ -224,6 +239,11 @@ func UpdateFloorById(id int, f FloorUpdate) (bool, error) {
 		}
 	}()
 
+	before, err := auditSnapshot(t, "BuildingFloors", id)
+	if err != nil {
+		return false, err
+	}
+
 	q, err := t.Prepare("UPDATE BuildingFloors SET FloorName = ?, BuildingId = ? WHERE Id = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
@@ -238,6 +258,11 @@ func UpdateFloorById(id int, f FloorUpdate) (bool, error) {
 		return false, err
 	}
 
+	err = auditUpdate(t, userId, "BuildingFloors", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -0,0 +1,48 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"testing"
+)
+
+func TestCreateFloor(t *testing.T) {
+	_, err := CreateBuilding(ProposedBuilding{Name: "Convention Center", City: "Dayton", Region: "OH"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildingId := lastId(t, "Buildings")
+
+	status, err := CreateFloor(ProposedFloor{Name: "Ground", BuildingName: "Convention Center"}, testUserId)
+	if err != nil || !status {
+		t.Fatalf("CreateFloor() = %v, %v, want true, nil", status, err)
+	}
+	floorId := lastId(t, "BuildingFloors")
+	if n := countRows(t, "BuildingFloors", "Id = ? AND FloorName = 'Ground' AND BuildingId = ?", floorId, buildingId); n != 1 {
+		t.Errorf("the new floor is not on building Id %d", buildingId)
+	}
+	wantAudited(t, "BuildingFloors", AuditCreate, floorId)
+}
+
+func TestCreateFloorInUnknownBuilding(t *testing.T) {
+	status, err := CreateFloor(ProposedFloor{Name: "Ground", BuildingName: "Nowhere"}, testUserId)
+	if err == nil || status {
+		t.Errorf("CreateFloor() in an unknown building = %v, %v, want false and an error", status, err)
+	}
+}
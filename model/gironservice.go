@@ -28,7 +28,10 @@ import (
 var DB *sql.DB
 
 func ConnectDatabase(dbPath string) error {
-	db, err := sql.Open("sqlite3", "file:"+dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000&_temp_store=MEMORY&_auto_vacuum=FULL&_synchronous=NORMAL&_tx_locking=IMMEDIATE")
+	// auto_vacuum is left out of the DSN: setting it on every new pooled
+	// connection needs the write lock, which an open transaction already holds.
+	// Migration 0019 sets it once on the database file instead
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000&_temp_store=MEMORY&_synchronous=NORMAL&_txlock=immediate")
 	if err != nil {
 		return err
 	}
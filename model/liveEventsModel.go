@@ -0,0 +1,619 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+)
+
+func CreateLiveEvent(p ProposedLiveEvent, id int) (bool, error) {
+	log.Println("INFO: Creating a live event: " + p.Topic)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	duration := p.DurationInMinutes
+	if duration <= 0 {
+		duration = 30
+	}
+
+	q, err := t.Prepare(`INSERT INTO LiveEvents (Topic, Description, DurationInMinutes, CreatorId, ApprovalStatus) VALUES (?, ?, ?, ?, FALSE)`)
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(p.Topic, p.Description, duration, id)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, id, "LiveEvents", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Live event entry created")
+	return true, nil
+}
+
+func DeleteLiveEventById(id int, userId int) (bool, error) {
+	log.Println("INFO: Live event deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+
+	// tag assignments and ratings reference the live event, so they go first
+	for _, stmt := range []string{
+		"DELETE FROM LiveEventTagAssignments WHERE LiveEventId = ?",
+		"DELETE FROM LiveEventRatings WHERE LiveEventId = ?",
+	} {
+		_, err = t.Exec(stmt, id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete records for live event with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	q, err := t.Prepare("DELETE FROM LiveEvents WHERE Id IS ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete live event with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such live event found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = queueWebhookEvent(t, WebhookLiveEventDeleted, "LiveEvents", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "LiveEvents", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Live event with id '" + strconv.Itoa(id) + "' has been deleted")
+	return true, nil
+}
+
+// liveEventQuery Selects live events together with the room, floor and
+// building of their location, if they have one
+const liveEventQuery = `SELECT e.Id, e.Topic, e.Description,
+		e.LocationId, l.RoomName, f.Id, f.FloorName, b.Id, b.Name,
+		e.ScheduledTime, e.DurationInMinutes, e.Rating, e.AgeRestricted, e.CreatorId, e.CreationDateTime,
+		e.ApprovalStatus, e.ApprovedById, e.ApprovalDateTime
+	FROM LiveEvents e
+	LEFT JOIN Locations l ON l.Id = e.LocationId
+	LEFT JOIN BuildingFloors f ON f.Id = l.FloorId
+	LEFT JOIN Buildings b ON b.Id = l.BuildingId`
+
+func liveEventScanTargets(event *LiveEventSQL) []any {
+	return []any{
+		&event.Id,
+		&event.Topic,
+		&event.Description,
+		&event.LocationId,
+		&event.RoomName,
+		&event.FloorId,
+		&event.FloorName,
+		&event.BuildingId,
+		&event.BuildingName,
+		&event.ScheduledTime,
+		&event.DurationInMinutes,
+		&event.Rating,
+		&event.AgeRestricted,
+		&event.CreatorId,
+		&event.CreationDateTime,
+		&event.ApprovalStatus,
+		&event.ApprovedById,
+		&event.ApprovalDateTime,
+	}
+}
+
+func scanLiveEvents(rows *sql.Rows) ([]LiveEventSQL, error) {
+	events := make([]LiveEventSQL, 0)
+	for rows.Next() {
+		event := LiveEventSQL{}
+		err := rows.Scan(liveEventScanTargets(&event)...)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the live event objects!" + string(err.Error()))
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func GetLiveEvents() ([]LiveEventSQL, error) {
+	log.Println("INFO: List of live event objects requested")
+	rows, err := DB.Query(liveEventQuery + " ORDER BY e.Id")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	events, err := scanLiveEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all live events retrieved")
+	return events, nil
+}
+
+func GetApprovedLiveEvents() ([]LiveEventSQL, error) {
+	log.Println("INFO: List of approved live event objects requested")
+	rows, err := DB.Query(liveEventQuery + " WHERE e.ApprovalStatus = TRUE ORDER BY e.Id")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	events, err := scanLiveEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of approved live events retrieved")
+	return events, nil
+}
+
+func GetLiveEventById(id int) (LiveEventSQL, error) {
+	log.Println("INFO: Live event by Id requested: " + strconv.Itoa(id))
+	event := LiveEventSQL{}
+	err := DB.QueryRow(liveEventQuery+" WHERE e.Id = ?", id).Scan(liveEventScanTargets(&event)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such live event found in DB: " + string(err.Error()))
+			return LiveEventSQL{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve live event from DB: " + string(err.Error()))
+		return LiveEventSQL{}, err
+	}
+
+	log.Println("INFO: Live event by Id '" + strconv.Itoa(id) + "' retrieved")
+	return event, nil
+}
+
+func UpdateLiveEventById(id int, u LiveEventUpdate, userId int) (bool, error) {
+	log.Println("INFO: Update live event Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE LiveEvents SET Topic = ?, Description = ? WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(u.Topic, u.Description, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for live event Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such live event found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "LiveEvents", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Live event entry updated")
+	return true, nil
+}
+
+func SetLiveEventLocation(id int, j LiveEventLocation, userId int) (bool, error) {
+	log.Println("INFO: Set location for live event Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+	if before == nil {
+		err = &NoSuchLiveEvent{Err: errors.New("invalid live event Id: " + strconv.Itoa(id))}
+		return false, err
+	}
+
+	exists, err := locationExists(t, j.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(j.LocationId))}
+		return false, err
+	}
+
+	conflicts, err := findRoomChangeConflicts(t, EventLiveEvent, "LiveEvents", id, j.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE LiveEvents SET LocationId = ? WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(j.LocationId, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for live event Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = queueWebhookEventFor(t, WebhookLiveEventRescheduled, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "LiveEvents", id, before)
+	if err != nil {
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	return numberOfRows > 0, nil
+}
+
+func SetLiveEventScheduledTimeById(id int, json LiveEventScheduledTime, userId int) (bool, string, error) {
+	log.Println("INFO: Set scheduled time for live event Id '" + strconv.Itoa(id) + "'")
+
+	start, err := time.Parse("2006-01-02 15:04:05", json.ScheduledTime)
+	if err != nil {
+		log.Println("ERROR: Could not parse scheduled time: " + string(err.Error()))
+		return false, "Could not convert from " + json.ScheduledTime + " to UNIX time", err
+	}
+
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	// keep the live event's current length unless a new one was asked for
+	var duration int
+	err = t.QueryRow("SELECT DurationInMinutes FROM LiveEvents WHERE Id = ?", id).Scan(&duration)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such live event found in DB: " + string(err.Error()))
+			err = &NoSuchLiveEvent{Err: err}
+			return false, "No live event with Id '" + strconv.Itoa(id) + "'", err
+		}
+		log.Println("ERROR: Cannot retrieve live event from DB: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+	if json.DurationInMinutes > 0 {
+		duration = json.DurationInMinutes
+	}
+
+	exists, err := locationExists(t, json.LocationId)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(json.LocationId))}
+		return false, "Location Id '" + strconv.Itoa(json.LocationId) + "' does not exist", err
+	}
+
+	conflicts, err := findScheduleConflicts(t, EventLiveEvent, id, json.LocationId, start, duration)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, "Live event conflicts with " + strconv.Itoa(len(conflicts)) + " scheduled event(s) in location", err
+	}
+
+	before, err := auditSnapshot(t, "LiveEvents", id)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	q, err := t.Prepare("UPDATE LiveEvents SET LocationId = ?, ScheduledTime = ?, DurationInMinutes = ? WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	_, err = q.Exec(json.LocationId, json.ScheduledTime, duration, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for live event Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	err = queueWebhookEventFor(t, WebhookLiveEventRescheduled, "LiveEvents", id)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	err = auditUpdate(t, userId, "LiveEvents", id, before)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	log.Println("INFO: Scheduled time for live event Id '" + strconv.Itoa(id) + "' set to '" + json.ScheduledTime + "'")
+	return true, json.ScheduledTime, nil
+}
+
+func SetApprovalStatusLiveEventById(id int, status LiveEventApproval, userId int) (bool, error) {
+	log.Println("INFO: Set Approval status for live event Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE LiveEvents SET ApprovalStatus = ?, ApprovedById = ?, ApprovalDateTime = CURRENT_TIMESTAMP WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(status.State, userId, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for live event Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	eventType := WebhookLiveEventUnapproved
+	if status.State {
+		eventType = WebhookLiveEventApproved
+	}
+	err = queueWebhookEventFor(t, eventType, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "LiveEvents", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Approval status for live event Id '" + strconv.Itoa(id) + "' set to '" + strconv.FormatBool(status.State) + "'")
+	return numberOfRows > 0, nil
+}
+
+func SetLiveEventAgeRestrictionById(id int, status LiveEventAgeRestrictionState, userId int) (bool, error) {
+	log.Println("INFO: Set age restriction status for live event Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "LiveEvents", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE LiveEvents SET AgeRestricted = ? WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(status.RestrictionState, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for live event Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "LiveEvents", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Age restriction status for live event Id '" + strconv.Itoa(id) + "' set to '" + strconv.FormatBool(status.RestrictionState) + "'")
+	return numberOfRows > 0, nil
+}
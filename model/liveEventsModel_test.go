@@ -0,0 +1,41 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetLocationOfMissingLiveEvent(t *testing.T) {
+	locationId := newTestLocation(t, "Live Event Room")
+	status, err := SetLiveEventLocation(9999, LiveEventLocation{LocationId: locationId}, testUserId)
+	var noSuchLiveEvent *NoSuchLiveEvent
+	if status || !errors.As(err, &noSuchLiveEvent) {
+		t.Errorf("SetLiveEventLocation() = %v, %v, want false, NoSuchLiveEvent", status, err)
+	}
+}
+
+func TestScheduleMissingLiveEvent(t *testing.T) {
+	status, _, err := SetLiveEventScheduledTimeById(9999, LiveEventScheduledTime{LocationId: 1, ScheduledTime: "2026-10-18 10:00:00"}, testUserId)
+	var noSuchLiveEvent *NoSuchLiveEvent
+	if status || !errors.As(err, &noSuchLiveEvent) {
+		t.Errorf("SetLiveEventScheduledTimeById() = %v, %v, want false, NoSuchLiveEvent", status, err)
+	}
+}
@@ -49,12 +49,17 @@ func CreateLocation(p ProposedLocation, id int) (bool, error) {
 		return false, err
 	}
 
-	_, err = q.Exec(p.RoomName, p.FloorId, p.BuildingId, id)
+	result, err := q.Exec(p.RoomName, p.FloorId, p.BuildingId, id)
 	if err != nil {
 		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
 		return false, err
 	}
 
+	err = auditInsert(t, id, "Locations", result)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -65,7 +70,7 @@ func CreateLocation(p ProposedLocation, id int) (bool, error) {
 	return true, nil
 }
 
-func DeleteLocationById(id int) (bool, error) {
+func DeleteLocationById(id int, userId int) (bool, error) {
 	log.Println("INFO: Location deletion requested: " + strconv.Itoa(id))
 	t, err := DB.Begin()
 	if err != nil {
@@ -83,7 +88,12 @@ func DeleteLocationById(id int) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("DELETE FROM Locations WHERE Id IS ?")
+	before, err := auditSnapshot(t, "Locations", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("DELETE FROM Locations WHERE Id IS ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
 		return false, err
@@ -95,6 +105,11 @@ func DeleteLocationById(id int) (bool, error) {
 		return false, err
 	}
 
+	err = auditDelete(t, userId, "Locations", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -235,7 +250,7 @@ func GetLocationsByBuildingId(id int) ([]Location, error) {
 	return locations, nil
 }
 
-func UpdateLocationById(id int, l LocationUpdate) (bool, error) {
+func UpdateLocationById(id int, l LocationUpdate, userId int) (bool, error) {
 	t, err := DB.Begin()
 	if err != nil {
 		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
@@ -252,6 +267,11 @@ func UpdateLocationById(id int, l LocationUpdate) (bool, error) {
 		}
 	}()
 
+	before, err := auditSnapshot(t, "Locations", id)
+	if err != nil {
+		return false, err
+	}
+
 	q, err := t.Prepare("UPDATE Locations SET FloorId = ?, BuildingId = ? WHERE Id = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
@@ -266,6 +286,11 @@ func UpdateLocationById(id int, l LocationUpdate) (bool, error) {
 		return false, err
 	}
 
+	err = auditUpdate(t, userId, "Locations", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -275,3 +300,14 @@ func UpdateLocationById(id int, l LocationUpdate) (bool, error) {
 	log.Println("INFO: Location entry updated")
 	return true, nil
 }
+
+func locationExists(t *sql.Tx, id int) (bool, error) {
+	var count int
+	err := t.QueryRow("SELECT COUNT(*) FROM Locations WHERE Id = ?", id).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for location Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,250 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"time"
+)
+
+// LoginLockoutThreshold, LoginLockoutMinutes How many failed logins within
+// the lockout window lock an account, and for how many minutes. The window
+// is as long as the lockout. Set from the service configuration; the defaults
+// apply when unset.
+var (
+	LoginLockoutThreshold int
+	LoginLockoutMinutes   int
+)
+
+const (
+	defaultLoginLockoutThreshold = 5
+	defaultLoginLockoutMinutes   = 15
+	// maxLoginBackoffSeconds Caps the wait between failed logins
+	maxLoginBackoffSeconds = 300
+	// loginAddressAllowance Staff often sign in from behind one shared
+	// address, so an address only starts backing off after this many times
+	// the failures that lock an account
+	loginAddressAllowance = 4
+)
+
+func loginLockoutPolicy() (int, int) {
+	threshold := LoginLockoutThreshold
+	if threshold <= 0 {
+		threshold = defaultLoginLockoutThreshold
+	}
+	minutes := LoginLockoutMinutes
+	if minutes <= 0 {
+		minutes = defaultLoginLockoutMinutes
+	}
+	return threshold, minutes
+}
+
+// loginBackoff Returns the wait after a number of failed logins: one second
+// after the first, doubling with each one after that
+func loginBackoff(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	seconds := maxLoginBackoffSeconds
+	if failures <= 10 {
+		seconds = 1 << (failures - 1)
+	}
+	if seconds > maxLoginBackoffSeconds {
+		seconds = maxLoginBackoffSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// LoginRetryAfter Returns how long a client has to wait before it may try to
+// sign in as the user, or from the address, again. It is zero when a login
+// may be attempted now.
+func LoginRetryAfter(username string, remoteAddress string) (time.Duration, error) {
+	threshold, minutes := loginLockoutPolicy()
+	window := "-" + strconv.Itoa(minutes) + " minutes"
+
+	var retryAfter time.Duration
+	for _, limit := range []struct {
+		column    string
+		value     string
+		allowance int
+	}{
+		{"UserName", username, 0},
+		{"RemoteAddress", remoteAddress, threshold * loginAddressAllowance},
+	} {
+		var failures int
+		var lastFailure int64
+		err := DB.QueryRow(`SELECT COUNT(*), IFNULL(CAST(strftime('%s', MAX(AttemptDateTime)) AS INTEGER), 0)
+			FROM LoginFailures WHERE `+limit.column+` = ? AND AttemptDateTime > datetime('now', ?)`, limit.value, window).Scan(&failures, &lastFailure)
+		if err != nil {
+			log.Println("ERROR: Cannot count failed logins: " + string(err.Error()))
+			return 0, err
+		}
+
+		wait := time.Until(time.Unix(lastFailure, 0).Add(loginBackoff(failures - limit.allowance)))
+		if wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	return retryAfter, nil
+}
+
+// RecordLoginFailure Notes a failed login and locks the account once it has
+// failed too often within the lockout window. Accounts that are already
+// locked are left as they are.
+func RecordLoginFailure(username string, remoteAddress string) error {
+	threshold, minutes := loginLockoutPolicy()
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	window := "-" + strconv.Itoa(minutes) + " minutes"
+	_, err = t.Exec("DELETE FROM LoginFailures WHERE AttemptDateTime <= datetime('now', ?)", window)
+	if err != nil {
+		log.Println("ERROR: Cannot prune failed logins: " + string(err.Error()))
+		return err
+	}
+
+	_, err = t.Exec("INSERT INTO LoginFailures (UserName, RemoteAddress) VALUES (?, ?)", username, remoteAddress)
+	if err != nil {
+		log.Println("ERROR: Cannot record failed login: " + string(err.Error()))
+		return err
+	}
+	log.Println("WARN: Failed login for user '" + username + "' from " + remoteAddress)
+
+	var failures int
+	err = t.QueryRow("SELECT COUNT(*) FROM LoginFailures WHERE UserName = ?", username).Scan(&failures)
+	if err != nil {
+		log.Println("ERROR: Cannot count failed logins: " + string(err.Error()))
+		return err
+	}
+
+	if failures >= threshold {
+		var id int
+		err = t.QueryRow("SELECT Id FROM Users WHERE UserName = ? AND Status = 'enabled'", username).Scan(&id)
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
+			return err
+		}
+		err = nil
+		if id != 0 {
+			_, err = t.Exec("UPDATE Users SET Status = 'locked' WHERE Id = ?", id)
+			if err != nil {
+				log.Println("ERROR: Cannot lock user '" + username + "': " + string(err.Error()))
+				return err
+			}
+			_, err = t.Exec("INSERT OR REPLACE INTO LoginLockouts (UserId, LockedUntil) VALUES (?, datetime('now', ?))", id, "+"+strconv.Itoa(minutes)+" minutes")
+			if err != nil {
+				log.Println("ERROR: Cannot record lockout of user '" + username + "': " + string(err.Error()))
+				return err
+			}
+			// whoever is guessing may already be signed in somewhere
+			_, err = t.Exec("DELETE FROM Sessions WHERE UserName = ?", username)
+			if err != nil {
+				log.Println("ERROR: Cannot revoke sessions of user '" + username + "': " + string(err.Error()))
+				return err
+			}
+			log.Println("WARN: User '" + username + "' locked for " + strconv.Itoa(minutes) + " minutes after " + strconv.Itoa(failures) + " failed logins, the last from " + remoteAddress)
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// ClearLoginFailures Forgets the failed logins of a user after signing in.
+// Failures from the client address still count.
+func ClearLoginFailures(username string) error {
+	_, err := DB.Exec("DELETE FROM LoginFailures WHERE UserName = ?", username)
+	if err != nil {
+		log.Println("ERROR: Cannot clear failed logins of user '" + username + "': " + string(err.Error()))
+		return err
+	}
+	return nil
+}
+
+// ReleaseExpiredLockout Unlocks a user whose automatic lockout has run out
+// and reports whether it did
+func ReleaseExpiredLockout(userId int) (bool, error) {
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	result, err := t.Exec("DELETE FROM LoginLockouts WHERE UserId = ? AND LockedUntil <= CURRENT_TIMESTAMP", userId)
+	if err != nil {
+		log.Println("ERROR: Cannot release lockout of user Id '" + strconv.Itoa(userId) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		err = t.Rollback()
+		return false, err
+	}
+
+	_, err = t.Exec("UPDATE Users SET Status = 'enabled' WHERE Id = ?", userId)
+	if err != nil {
+		log.Println("ERROR: Cannot unlock user Id '" + strconv.Itoa(userId) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Lockout of user Id '" + strconv.Itoa(userId) + "' has expired")
+	return true, nil
+}
@@ -0,0 +1,128 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testUserId The user that the model tests make their changes as
+var testUserId int
+
+// TestMain Runs the model tests against a freshly migrated database in a
+// temporary directory
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+
+	dir, err := os.MkdirTemp("", "giron-model-test")
+	if err != nil {
+		panic(err)
+	}
+
+	err = ConnectDatabase(filepath.Join(dir, "giron.db"))
+	if err != nil {
+		panic(err)
+	}
+	_, err = MigrateUp()
+	if err != nil {
+		panic(err)
+	}
+
+	result, err := DB.Exec("INSERT INTO Users (UserName, PasswordHash) VALUES ('tester', '')")
+	if err != nil {
+		panic(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		panic(err)
+	}
+	testUserId = int(id)
+
+	code := m.Run()
+	DB.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// lastId Returns the highest Id in a table, which is the row a test has
+// just created
+func lastId(t *testing.T, table string) int {
+	t.Helper()
+	var id int
+	err := DB.QueryRow("SELECT MAX(Id) FROM " + table).Scan(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// countRows Returns the number of rows in a table that match a condition
+func countRows(t *testing.T, table string, where string, args ...interface{}) int {
+	t.Helper()
+	var count int
+	err := DB.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE "+where, args...).Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// newTestTag Creates a tag and returns its Id
+func newTestTag(t *testing.T, name string) int {
+	t.Helper()
+	_, err := CreateTag(ProposedTag{TagName: name}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lastId(t, "Tags")
+}
+
+// wantAudited Fails the test unless the test user has an audit entry for a
+// change of one class to a record
+func wantAudited(t *testing.T, table string, changeClass string, recordId int) {
+	t.Helper()
+	n := countRows(t, "Audit", "TableChanged = ? AND ChangeClass = ? AND RecordId = ? AND ChangedById = ?", table, changeClass, recordId, testUserId)
+	if n == 0 {
+		t.Errorf("no %s audit entry for %s Id %d", changeClass, table, recordId)
+	}
+}
+
+// newTestLocation Creates a room, on a floor of a building of its own, and
+// returns its Id
+func newTestLocation(t *testing.T, roomName string) int {
+	t.Helper()
+	_, err := CreateBuilding(ProposedBuilding{Name: roomName + " Hall", City: "Dayton", Region: "OH"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildingId := lastId(t, "Buildings")
+	_, err = CreateFloor(ProposedFloor{Name: roomName + " Floor", BuildingName: roomName + " Hall"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CreateLocation(ProposedLocation{RoomName: roomName, FloorId: lastId(t, "BuildingFloors"), BuildingId: buildingId}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lastId(t, "Locations")
+}
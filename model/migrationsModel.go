@@ -0,0 +1,445 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/JAFAX/giron-service/db"
+)
+
+// noTransactionDirective Starts a migration file that has to run outside a
+// transaction, such as one that runs VACUUM
+const noTransactionDirective = "-- migrate: no-transaction"
+
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	// NoTransaction Runs the scripts outside a transaction. Such scripts
+	// must be safe to run again if they are interrupted.
+	NoTransaction bool
+}
+
+// loadMigrations Reads the embedded migrations, ordered by version
+func loadMigrations() ([]migration, error) {
+	files, err := fs.Glob(db.Migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*migration)
+	for _, file := range files {
+		base := strings.TrimPrefix(file, "migrations/")
+		direction := ""
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+			base = strings.TrimSuffix(base, ".up.sql")
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+			base = strings.TrimSuffix(base, ".down.sql")
+		default:
+			return nil, errors.New("migration file '" + file + "' must end in .up.sql or .down.sql")
+		}
+
+		versionString, name, found := strings.Cut(base, "_")
+		if !found {
+			return nil, errors.New("migration file '" + file + "' must be named NNNN_description")
+		}
+		version, err := strconv.Atoi(versionString)
+		if err != nil {
+			return nil, errors.New("migration file '" + file + "' has an invalid version: " + string(err.Error()))
+		}
+
+		contents, err := fs.ReadFile(db.Migrations, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(contents)
+		} else {
+			m.Down = string(contents)
+		}
+		if strings.HasPrefix(string(contents), noTransactionDirective) {
+			m.NoTransaction = true
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, errors.New("migration " + strconv.Itoa(m.Version) + " needs both an up and a down file")
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// latestMigrationVersion The schema version this binary was built for
+func latestMigrationVersion(migrations []migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func tableExists(name string) (bool, error) {
+	var count int
+	err := DB.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ensureSchemaVersionTable Creates the schema_version table if needed. A
+// database created from the old schema.sql dump has tables but no version,
+// so it is stamped as the baseline rather than having the baseline applied.
+func ensureSchemaVersionTable() error {
+	exists, err := tableExists("schema_version")
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	legacy, err := tableExists("Users")
+	if err != nil {
+		return err
+	}
+
+	_, err = DB.Exec(`CREATE TABLE schema_version (
+		Version     INTEGER  PRIMARY KEY
+		                     NOT NULL,
+		Name        TEXT     NOT NULL,
+		AppliedDate DATETIME NOT NULL
+		                     DEFAULT (CURRENT_TIMESTAMP)
+	)`)
+	if err != nil {
+		log.Println("ERROR: Cannot create schema_version table: " + string(err.Error()))
+		return err
+	}
+
+	if legacy {
+		log.Println("NOTICE: Existing database without a schema version found. Stamping it as the baseline schema")
+		_, err = DB.Exec("INSERT INTO schema_version (Version, Name) VALUES (1, 'baseline')")
+		if err != nil {
+			log.Println("ERROR: Cannot stamp baseline schema version: " + string(err.Error()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func currentSchemaVersion() (int, error) {
+	var version sql.NullInt64
+	err := DB.QueryRow("SELECT MAX(Version) FROM schema_version").Scan(&version)
+	if err != nil {
+		log.Println("ERROR: Cannot read schema version: " + string(err.Error()))
+		return 0, err
+	}
+
+	return int(version.Int64), nil
+}
+
+func countForeignKeyViolations(t *sql.Tx) (int, error) {
+	rows, err := t.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		log.Println("ERROR: Cannot check foreign keys: " + string(err.Error()))
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// applyMigration Runs one migration script and records the resulting version
+// in a single transaction
+func applyMigration(m migration, up bool) error {
+	ctx := context.Background()
+	// table rebuilds need foreign keys switched off, which SQLite only allows
+	// outside a transaction, so pin a connection for the whole migration
+	conn, err := DB.Conn(ctx)
+	if err != nil {
+		log.Println("ERROR: Cannot get a DB connection: " + string(err.Error()))
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+	if err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+
+	if m.NoTransaction {
+		return applyMigrationWithoutTransaction(ctx, conn, m, up)
+	}
+
+	t, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	// rows orphaned before the migration ran are not its fault
+	violationsBefore, err := countForeignKeyViolations(t)
+	if err != nil {
+		return err
+	}
+
+	script := m.Up
+	if !up {
+		script = m.Down
+	}
+	_, err = t.Exec(script)
+	if err != nil {
+		log.Println("ERROR: Migration " + strconv.Itoa(m.Version) + " (" + m.Name + ") failed: " + string(err.Error()))
+		return err
+	}
+
+	if up {
+		_, err = t.Exec("INSERT INTO schema_version (Version, Name) VALUES (?, ?)", m.Version, m.Name)
+	} else {
+		_, err = t.Exec("DELETE FROM schema_version WHERE Version = ?", m.Version)
+	}
+	if err != nil {
+		log.Println("ERROR: Cannot record schema version: " + string(err.Error()))
+		return err
+	}
+
+	// with enforcement off, make sure the migration didn't leave any new
+	// dangling references behind before committing it
+	violationsAfter, err := countForeignKeyViolations(t)
+	if err != nil {
+		return err
+	}
+	if violationsAfter > violationsBefore {
+		err = errors.New("migration " + strconv.Itoa(m.Version) + " (" + m.Name + ") leaves foreign key violations")
+		return err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// applyMigrationWithoutTransaction Runs a migration script that cannot run in
+// a transaction, then records the resulting version
+func applyMigrationWithoutTransaction(ctx context.Context, conn *sql.Conn, m migration, up bool) error {
+	script := m.Up
+	if !up {
+		script = m.Down
+	}
+	_, err := conn.ExecContext(ctx, script)
+	if err != nil {
+		log.Println("ERROR: Migration " + strconv.Itoa(m.Version) + " (" + m.Name + ") failed: " + string(err.Error()))
+		return err
+	}
+
+	if up {
+		_, err = conn.ExecContext(ctx, "INSERT INTO schema_version (Version, Name) VALUES (?, ?)", m.Version, m.Name)
+	} else {
+		_, err = conn.ExecContext(ctx, "DELETE FROM schema_version WHERE Version = ?", m.Version)
+	}
+	if err != nil {
+		log.Println("ERROR: Cannot record schema version: " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// CheckSchemaVersion Refuses to work with a database that has been migrated by
+// a newer build than this one
+func CheckSchemaVersion() error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	err = ensureSchemaVersionTable()
+	if err != nil {
+		return err
+	}
+
+	current, err := currentSchemaVersion()
+	if err != nil {
+		return err
+	}
+	latest := latestMigrationVersion(migrations)
+	if current > latest {
+		return &SchemaTooNew{Err: errors.New("database schema version " + strconv.Itoa(current) +
+			" is newer than the latest version this build knows about (" + strconv.Itoa(latest) + ")")}
+	}
+
+	return nil
+}
+
+// MigrateUp Applies all pending migrations and returns how many were applied
+func MigrateUp() (int, error) {
+	err := CheckSchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	current, err := currentSchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		log.Println("NOTICE: Applying migration " + strconv.Itoa(m.Version) + " (" + m.Name + ")")
+		err = applyMigration(m, true)
+		if err != nil {
+			return applied, err
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// MigrateDown Reverts the given number of most recently applied migrations
+func MigrateDown(steps int) (int, error) {
+	err := CheckSchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	current, err := currentSchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	reverted := 0
+	for i := len(migrations) - 1; i >= 0 && reverted < steps; i-- {
+		m := migrations[i]
+		if m.Version > current {
+			continue
+		}
+		log.Println("NOTICE: Reverting migration " + strconv.Itoa(m.Version) + " (" + m.Name + ")")
+		err = applyMigration(m, false)
+		if err != nil {
+			return reverted, err
+		}
+		reverted++
+	}
+
+	return reverted, nil
+}
+
+// GetMigrationStatus Lists every known migration and whether it has been applied
+func GetMigrationStatus() ([]MigrationStatus, error) {
+	err := ensureSchemaVersionTable()
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := DB.Query("SELECT Version, Name, AppliedDate FROM schema_version ORDER BY Version")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]MigrationStatus)
+	for rows.Next() {
+		status := MigrationStatus{Applied: true}
+		err = rows.Scan(&status.Version, &status.Name, &status.AppliedDate)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the schema version objects!" + string(err.Error()))
+			return nil, err
+		}
+		applied[status.Version] = status
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		if status, ok := applied[m.Version]; ok {
+			statuses = append(statuses, status)
+			delete(applied, m.Version)
+		} else {
+			statuses = append(statuses, MigrationStatus{Version: m.Version, Name: m.Name})
+		}
+	}
+	// versions applied by a newer build are still worth reporting
+	for _, status := range applied {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
@@ -0,0 +1,135 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+)
+
+// email templates queued by the model, found under templates/email
+const (
+	EmailPanelAccepted  = "panel_accepted"
+	EmailPanelRejected  = "panel_rejected"
+	EmailPanelScheduled = "panel_scheduled"
+	EmailProposalVerify = "proposal_verify"
+)
+
+// queueEmail Adds an email to the outbox. It must be called inside the
+// transaction making the change the email reports, so both are committed or
+// rolled back together.
+func queueEmail(t *sql.Tx, recipient string, template string, data map[string]string) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Println("ERROR: Cannot encode email data: " + string(err.Error()))
+		return err
+	}
+
+	_, err = t.Exec("INSERT INTO EmailOutbox (Recipient, Template, Data) VALUES (?, ?, ?)", recipient, template, string(encoded))
+	if err != nil {
+		log.Println("ERROR: Cannot queue '" + template + "' email: " + string(err.Error()))
+		return err
+	}
+
+	log.Println("INFO: Queued '" + template + "' email")
+	return nil
+}
+
+// queuePanelEmail Queues an email to the requestor of a panel, with the
+// panel's topic, slot and room added to data
+func queuePanelEmail(t *sql.Tx, panelId int, template string, data map[string]string) error {
+	var topic, email, scheduledTime, roomName string
+	var duration int
+	err := t.QueryRow(`SELECT p.Topic, p.PanelRequestorEmail, IFNULL(p.ScheduledTime, ''), p.DurationInMinutes, IFNULL(l.RoomName, '')
+		FROM Panels p
+		LEFT JOIN Locations l ON l.Id = p.LocationId
+		WHERE p.Id = ?`, panelId).Scan(&topic, &email, &scheduledTime, &duration, &roomName)
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve panel for email: " + string(err.Error()))
+		return err
+	}
+	if email == "" {
+		log.Println("WARN: Panel Id '" + strconv.Itoa(panelId) + "' has no requestor email; not sending '" + template + "'")
+		return nil
+	}
+
+	if data == nil {
+		data = make(map[string]string)
+	}
+	data["PanelId"] = strconv.Itoa(panelId)
+	data["Topic"] = topic
+	data["ScheduledTime"] = scheduledTime
+	data["DurationInMinutes"] = strconv.Itoa(duration)
+	data["RoomName"] = roomName
+
+	return queueEmail(t, email, template, data)
+}
+
+// GetDueEmails Lists up to limit unsent emails whose next attempt is due and
+// that have been tried fewer than maxAttempts times, oldest first
+func GetDueEmails(limit int, maxAttempts int) ([]OutboxMessage, error) {
+	rows, err := DB.Query(`SELECT Id, Recipient, Template, Data, Attempts FROM EmailOutbox
+		WHERE SentDateTime IS NULL AND Attempts < ? AND NextAttemptDateTime <= CURRENT_TIMESTAMP
+		ORDER BY Id LIMIT ?`, maxAttempts, limit)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]OutboxMessage, 0)
+	for rows.Next() {
+		message := OutboxMessage{}
+		var data string
+		err = rows.Scan(&message.Id, &message.Recipient, &message.Template, &data, &message.Attempts)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the outbox objects!" + string(err.Error()))
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(data), &message.Data)
+		if err != nil {
+			log.Println("ERROR: Cannot decode data of email Id '" + strconv.Itoa(message.Id) + "': " + string(err.Error()))
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+func MarkEmailSent(id int) error {
+	_, err := DB.Exec("UPDATE EmailOutbox SET SentDateTime = CURRENT_TIMESTAMP, Attempts = Attempts + 1, LastError = '' WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot mark email Id '" + strconv.Itoa(id) + "' as sent: " + string(err.Error()))
+	}
+	return err
+}
+
+// MarkEmailFailed Records a failed attempt and when to try again
+func MarkEmailFailed(id int, reason string, retryIn time.Duration) error {
+	_, err := DB.Exec(`UPDATE EmailOutbox SET Attempts = Attempts + 1, LastError = ?,
+		NextAttemptDateTime = datetime('now', ?) WHERE Id = ?`,
+		reason, "+"+strconv.Itoa(int(retryIn.Seconds()))+" seconds", id)
+	if err != nil {
+		log.Println("ERROR: Cannot record failed attempt for email Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+	}
+	return err
+}
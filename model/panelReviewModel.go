@@ -0,0 +1,330 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// panel review lifecycle states
+const (
+	PanelSubmitted   = "submitted"
+	PanelUnderReview = "under_review"
+	PanelAccepted    = "accepted"
+	PanelRejected    = "rejected"
+	PanelWaitlisted  = "waitlisted"
+	PanelScheduled   = "scheduled"
+	PanelCancelled   = "cancelled"
+)
+
+// panelTransitions The states a panel may move to from each state. Reviewers
+// may decide on a submission straight away without putting it under review
+// first, and a rejected panel can be taken back under review. Cancelled is
+// final.
+var panelTransitions = map[string][]string{
+	PanelSubmitted:   {PanelUnderReview, PanelAccepted, PanelRejected, PanelWaitlisted, PanelCancelled},
+	PanelUnderReview: {PanelAccepted, PanelRejected, PanelWaitlisted, PanelCancelled},
+	PanelWaitlisted:  {PanelUnderReview, PanelAccepted, PanelRejected, PanelCancelled},
+	PanelAccepted:    {PanelScheduled, PanelWaitlisted, PanelCancelled},
+	PanelRejected:    {PanelUnderReview},
+	PanelScheduled:   {PanelCancelled},
+	PanelCancelled:   {},
+}
+
+// IsPanelState Reports whether state is one of the panel lifecycle states
+func IsPanelState(state string) bool {
+	_, ok := panelTransitions[state]
+	return ok
+}
+
+func panelCanMove(from string, to string) bool {
+	for _, state := range panelTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// panelState Looks up the current state of a panel, and whether it exists
+func panelState(t *sql.Tx, id int) (string, bool, error) {
+	var state string
+	err := t.QueryRow("SELECT State FROM Panels WHERE Id = ?", id).Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such panel found in DB: " + string(err.Error()))
+			return "", false, nil
+		}
+		log.Println("ERROR: Cannot retrieve state of panel Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return "", false, err
+	}
+
+	return state, true, nil
+}
+
+func logPanelReview(t *sql.Tx, panelId int, from string, to string, comment string, userId int) (sql.Result, error) {
+	result, err := t.Exec("INSERT INTO PanelReviewLog (PanelId, FromState, ToState, Comment, ReviewerId) VALUES (?, ?, ?, ?, ?)",
+		panelId, from, to, strings.TrimSpace(comment), userId)
+	if err != nil {
+		log.Println("ERROR: Cannot record review of panel Id '" + strconv.Itoa(panelId) + "': " + string(err.Error()))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// panel states that are announced to webhooks
+var panelStateWebhookEvents = map[string]string{
+	PanelAccepted:  WebhookPanelApproved,
+	PanelRejected:  WebhookPanelRejected,
+	PanelCancelled: WebhookPanelCancelled,
+	PanelScheduled: WebhookPanelScheduled,
+}
+
+// panel states that are streamed as schedule changes. Scheduling is streamed
+// by the slot change itself.
+var panelStateScheduleChanges = map[string]string{
+	PanelAccepted:  ScheduleApproved,
+	PanelRejected:  ScheduleRejected,
+	PanelCancelled: ScheduleCancelled,
+}
+
+// changePanelState Moves a panel from one state to another if the lifecycle
+// allows it and records the change in the review log. ApprovalStatus follows
+// the state, so it is true while the panel is accepted or scheduled. The
+// requestor is emailed when their panel is accepted or rejected, and webhooks
+// hear of panels being accepted, rejected, cancelled or scheduled.
+func changePanelState(t *sql.Tx, id int, from string, to string, comment string, userId int) error {
+	if !panelCanMove(from, to) {
+		return &InvalidPanelStateTransition{Err: errors.New("a panel cannot move from '" + from + "' to '" + to + "'")}
+	}
+
+	var err error
+	approved := to == PanelAccepted || to == PanelScheduled
+	if to == PanelAccepted {
+		_, err = t.Exec("UPDATE Panels SET State = ?, ApprovalStatus = ?, ApprovedById = ?, ApprovalDateTime = CURRENT_TIMESTAMP WHERE Id = ?", to, approved, userId, id)
+	} else {
+		_, err = t.Exec("UPDATE Panels SET State = ?, ApprovalStatus = ? WHERE Id = ?", to, approved, id)
+	}
+	if err != nil {
+		log.Println("ERROR: Could not execute query for panel Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return err
+	}
+
+	_, err = logPanelReview(t, id, from, to, comment, userId)
+	if err != nil {
+		return err
+	}
+
+	// let the requestor know how their panel fared
+	switch to {
+	case PanelAccepted:
+		err = queuePanelEmail(t, id, EmailPanelAccepted, map[string]string{"Comment": comment})
+	case PanelRejected:
+		err = queuePanelEmail(t, id, EmailPanelRejected, map[string]string{"Comment": comment})
+	}
+	if err != nil {
+		return err
+	}
+
+	if change, ok := panelStateScheduleChanges[to]; ok {
+		err = recordScheduleChange(t, EventPanel, id, change)
+		if err != nil {
+			return err
+		}
+	}
+
+	if eventType, ok := panelStateWebhookEvents[to]; ok {
+		err = queueWebhookEventFor(t, eventType, "Panels", id)
+	}
+	return err
+}
+
+// SetPanelStateById Moves a panel along its review lifecycle. A panel can
+// only be marked scheduled once it has a slot; booking a slot for an accepted
+// panel marks it scheduled by itself.
+func SetPanelStateById(id int, change PanelStateChange, userId int) (bool, error) {
+	log.Println("INFO: Set state for panel Id '" + strconv.Itoa(id) + "' to '" + change.State + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	current, found, err := panelState(t, id)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		err = t.Rollback()
+		return false, err
+	}
+
+	if change.State == PanelScheduled {
+		var scheduledTime sql.NullString
+		err = t.QueryRow("SELECT ScheduledTime FROM Panels WHERE Id = ?", id).Scan(&scheduledTime)
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve panel from DB: " + string(err.Error()))
+			return false, err
+		}
+		if !scheduledTime.Valid || scheduledTime.String == "" {
+			err = &InvalidPanelStateTransition{Err: errors.New("panel has no scheduled time")}
+			return false, err
+		}
+	}
+
+	before, err := auditSnapshot(t, "Panels", id)
+	if err != nil {
+		return false, err
+	}
+
+	err = changePanelState(t, id, current, change.State, change.Comment, userId)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Panels", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: State for panel Id '" + strconv.Itoa(id) + "' moved from '" + current + "' to '" + change.State + "'")
+	return true, nil
+}
+
+// AddPanelReviewComment Adds a reviewer comment to the review log of a panel
+// without changing its state
+func AddPanelReviewComment(id int, comment PanelReviewComment, userId int) (bool, error) {
+	log.Println("INFO: Add review comment to panel Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	current, found, err := panelState(t, id)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		err = t.Rollback()
+		return false, err
+	}
+
+	result, err := logPanelReview(t, id, current, current, comment.Comment, userId)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "PanelReviewLog", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Review comment added to panel Id '" + strconv.Itoa(id) + "'")
+	return true, nil
+}
+
+// GetPanelReviewLog Lists the state changes and reviewer comments of a panel,
+// oldest first
+func GetPanelReviewLog(id int) ([]PanelReviewEntry, error) {
+	log.Println("INFO: Review log for panel Id '" + strconv.Itoa(id) + "' requested")
+	rows, err := DB.Query(`SELECT r.Id, r.PanelId, r.FromState, r.ToState, r.Comment, r.ReviewerId, IFNULL(u.UserName, ''), r.ChangeDateTime
+		FROM PanelReviewLog r
+		LEFT JOIN Users u ON u.Id = r.ReviewerId
+		WHERE r.PanelId = ?
+		ORDER BY r.ChangeDateTime, r.Id`, id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]PanelReviewEntry, 0)
+	for rows.Next() {
+		entry := PanelReviewEntry{}
+		err = rows.Scan(&entry.Id, &entry.PanelId, &entry.FromState, &entry.ToState, &entry.Comment, &entry.ReviewerId, &entry.ReviewerName, &entry.ChangeDateTime)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the review log objects!" + string(err.Error()))
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	log.Println("INFO: Review log for panel Id '" + strconv.Itoa(id) + "' retrieved")
+	return entries, rows.Err()
+}
+
+// GetPanelsByState Lists the panels currently in any of the given states
+func GetPanelsByState(states []string) ([]PanelSQL, error) {
+	log.Println("INFO: Panels by state requested: " + strings.Join(states, ", "))
+	args := make([]any, 0)
+	for _, state := range states {
+		args = append(args, state)
+	}
+	rows, err := DB.Query(panelQuery+" WHERE p.State IN (?"+strings.Repeat(", ?", len(states)-1)+") ORDER BY p.Id", args...)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	panels, err := scanPanels(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: Panels by state retrieved")
+	return panels, nil
+}
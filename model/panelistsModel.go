@@ -0,0 +1,309 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// normalizeEmail Panelists are matched across panels by email address, so
+// addresses are stored trimmed and in lower case
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func AddPanelistToPanel(panelId int, p ProposedPanelist, userId int) (bool, error) {
+	log.Println("INFO: Adding a panelist to panel Id '" + strconv.Itoa(panelId) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var scheduledTime sql.NullString
+	var duration int
+	err = t.QueryRow("SELECT ScheduledTime, DurationInMinutes FROM Panels WHERE Id = ?", panelId).Scan(&scheduledTime, &duration)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such panel found in DB: " + string(err.Error()))
+			err = t.Rollback()
+			return false, err
+		}
+		log.Println("ERROR: Cannot retrieve panel from DB: " + string(err.Error()))
+		return false, err
+	}
+
+	email := normalizeEmail(p.EmailAddress)
+
+	// a panel that already has a slot cannot take on someone who is booked
+	// on another panel at the same time
+	if scheduledTime.Valid && scheduledTime.String != "" {
+		start, perr := ParseScheduledTime(scheduledTime.String)
+		if perr != nil {
+			log.Println("WARN: Cannot parse scheduled time of panel Id '" + strconv.Itoa(panelId) + "': " + string(perr.Error()))
+		} else {
+			conflicts, cerr := findPanelistConflicts(t, panelId, []string{email}, start, duration)
+			if cerr != nil {
+				err = cerr
+				return false, err
+			}
+			if len(conflicts) > 0 {
+				err = &SchedulingConflict{Conflicts: conflicts}
+				return false, err
+			}
+		}
+	}
+
+	q, err := t.Prepare("INSERT INTO Panelists (Name, EmailAddress, PhoneNumber, PanelId, CreatorId) VALUES (?, ?, ?, ?, ?)")
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.Name), email, strings.TrimSpace(p.PhoneNumber), panelId, userId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Panelists", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Panelist added to panel Id '" + strconv.Itoa(panelId) + "'")
+	return true, nil
+}
+
+func RemovePanelistFromPanel(panelId int, panelistId int, userId int) (bool, error) {
+	log.Println("INFO: Removing panelist Id '" + strconv.Itoa(panelistId) + "' from panel Id '" + strconv.Itoa(panelId) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Panelists", panelistId)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("DELETE FROM Panelists WHERE Id = ? AND PanelId = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(panelistId, panelId)
+	if err != nil {
+		log.Println("ERROR: Cannot delete panelist with id '" + strconv.Itoa(panelistId) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such panelist on panel found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "Panelists", panelistId, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Panelist with id '" + strconv.Itoa(panelistId) + "' has been removed")
+	return true, nil
+}
+
+func GetPanelistsByPanelId(panelId int) ([]Panelist, error) {
+	log.Println("INFO: Panelists by panel Id requested: " + strconv.Itoa(panelId))
+	rows, err := DB.Query(`SELECT Id, Name, EmailAddress, PhoneNumber, PanelId, CreatorId, CreationDate
+		FROM Panelists WHERE PanelId = ? ORDER BY Name, Id`, panelId)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	panelists := make([]Panelist, 0)
+	for rows.Next() {
+		panelist := Panelist{}
+		err = rows.Scan(
+			&panelist.Id,
+			&panelist.Name,
+			&panelist.EmailAddress,
+			&panelist.PhoneNumber,
+			&panelist.PanelId,
+			&panelist.CreatorId,
+			&panelist.CreationDate,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the panelist objects!" + string(err.Error()))
+			return nil, err
+		}
+		panelists = append(panelists, panelist)
+	}
+
+	log.Println("INFO: Panelists for panel Id '" + strconv.Itoa(panelId) + "' retrieved")
+	return panelists, rows.Err()
+}
+
+func GetPanelsByPanelistEmail(email string) ([]PanelSQL, error) {
+	log.Println("INFO: Panels by panelist email requested")
+	rows, err := DB.Query(panelQuery+" WHERE p.Id IN (SELECT PanelId FROM Panelists WHERE EmailAddress = ?) ORDER BY p.ScheduledTime, p.Id", normalizeEmail(email))
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	panels, err := scanPanels(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: Panels by panelist email retrieved")
+	return panels, nil
+}
+
+// panelistEmailsByPanelId Lists the email addresses of a panel's panelists
+func panelistEmailsByPanelId(t *sql.Tx, panelId int) ([]string, error) {
+	rows, err := t.Query("SELECT EmailAddress FROM Panelists WHERE PanelId = ?", panelId)
+	if err != nil {
+		log.Println("ERROR: Could not get panelists of panel Id '" + strconv.Itoa(panelId) + "': " + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	emails := make([]string, 0)
+	for rows.Next() {
+		var email string
+		err = rows.Scan(&email)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the panelist emails: " + string(err.Error()))
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+
+	return emails, rows.Err()
+}
+
+// findPanelistConflicts Lists the other scheduled panels that any of the given
+// panelists are on whose time slot overlaps with the requested one. Like the
+// room check, it must run inside the transaction that writes the change.
+func findPanelistConflicts(t *sql.Tx, panelId int, emails []string, start time.Time, durationInMinutes int) ([]ScheduleConflict, error) {
+	conflicts := make([]ScheduleConflict, 0)
+	if len(emails) == 0 {
+		return conflicts, nil
+	}
+
+	args := []any{panelId}
+	for _, email := range emails {
+		args = append(args, email)
+	}
+	rows, err := t.Query(`SELECT pl.EmailAddress, p.Id, p.Topic, IFNULL(p.LocationId, 0), p.ScheduledTime, p.DurationInMinutes
+		FROM Panelists pl
+		INNER JOIN Panels p ON p.Id = pl.PanelId
+		WHERE pl.PanelId != ? AND p.ScheduledTime IS NOT NULL AND p.ScheduledTime != ''
+		AND p.State NOT IN ('rejected', 'cancelled')
+		AND pl.EmailAddress IN (?`+strings.Repeat(", ?", len(emails)-1)+`)`, args...)
+	if err != nil {
+		log.Println("ERROR: Could not get panelist bookings: " + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	end := start.Add(time.Duration(durationInMinutes) * time.Minute)
+	for rows.Next() {
+		booking := ScheduleConflict{EventType: EventPanel}
+		err = rows.Scan(
+			&booking.Panelist,
+			&booking.Id,
+			&booking.Title,
+			&booking.LocationId,
+			&booking.ScheduledTime,
+			&booking.DurationInMinutes,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the panelist bookings: " + string(err.Error()))
+			return nil, err
+		}
+
+		bookingStart, err := ParseScheduledTime(booking.ScheduledTime)
+		if err != nil {
+			log.Println("WARN: Cannot parse scheduled time of panel Id '" + strconv.Itoa(booking.Id) + "': " + string(err.Error()))
+			continue
+		}
+		bookingEnd := bookingStart.Add(time.Duration(booking.DurationInMinutes) * time.Minute)
+		if start.Before(bookingEnd) && bookingStart.Before(end) {
+			conflicts = append(conflicts, booking)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) > 0 {
+		log.Println("WARN: Requested slot for panel Id '" + strconv.Itoa(panelId) + "' double-books " +
+			strconv.Itoa(len(conflicts)) + " panelist booking(s)")
+	}
+	return conflicts, nil
+}
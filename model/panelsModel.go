@@ -20,6 +20,7 @@ package model
 
 import (
 	"database/sql"
+	"errors"
 	"log"
 	"strconv"
 	"time"
@@ -50,12 +51,31 @@ func CreatePanel(p ProposedPanel, id int) (bool, error) {
 		return false, err
 	}
 
-	_, err = q.Exec(p.Topic, p.Description, p.PanelRequestorEmail, id)
+	result, err := q.Exec(p.Topic, p.Description, p.PanelRequestorEmail, id)
 	if err != nil {
 		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
 		return false, err
 	}
 
+	err = auditInsert(t, id, "Panels", result)
+	if err != nil {
+		return false, err
+	}
+
+	panelId, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot get Id of new panel: " + string(err.Error()))
+		return false, err
+	}
+	_, err = logPanelReview(t, int(panelId), "", PanelSubmitted, "", id)
+	if err != nil {
+		return false, err
+	}
+	err = recordScheduleChange(t, EventPanel, int(panelId), ScheduleCreated)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -66,7 +86,7 @@ func CreatePanel(p ProposedPanel, id int) (bool, error) {
 	return true, nil
 }
 
-func DeletePanelById(id int) (bool, error) {
+func DeletePanelById(id int, userId int) (bool, error) {
 	log.Println("INFO: Panel deletion requested: " + strconv.Itoa(id))
 	t, err := DB.Begin()
 	if err != nil {
@@ -84,7 +104,34 @@ func DeletePanelById(id int) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("DELETE FROM Panels WHERE Id IS ?")
+	before, err := auditSnapshot(t, "Panels", id)
+	if err != nil {
+		return false, err
+	}
+	if before != nil {
+		err = recordScheduleChange(t, EventPanel, id, ScheduleDeleted)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// panelists, tag assignments, ratings, the review log and the public
+	// proposal only exist as part of their panel
+	for _, stmt := range []string{
+		"DELETE FROM Panelists WHERE PanelId = ?",
+		"DELETE FROM PanelTagAssignments WHERE PanelId = ?",
+		"DELETE FROM PanelRatings WHERE PanelId = ?",
+		"DELETE FROM PanelReviewLog WHERE PanelId = ?",
+		"DELETE FROM PanelProposals WHERE PanelId = ?",
+	} {
+		_, err = t.Exec(stmt, id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete records for panel with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	q, err := t.Prepare("DELETE FROM Panels WHERE Id IS ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
 		return false, err
@@ -96,6 +143,16 @@ func DeletePanelById(id int) (bool, error) {
 		return false, err
 	}
 
+	err = queueWebhookEvent(t, WebhookPanelDeleted, "Panels", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "Panels", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -106,34 +163,47 @@ func DeletePanelById(id int) (bool, error) {
 	return true, nil
 }
 
-func GetPanels() ([]PanelSQL, error) {
-	log.Println("INFO: List of panel objects requested")
-	rows, err := DB.Query("SELECT * FROM Panels")
-	if err != nil {
-		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
-		return nil, err
+// panelQuery Selects panels together with the room, floor and building of
+// their location, if they have one
+const panelQuery = `SELECT p.Id, p.Topic, p.Description, p.PanelRequestorEmail,
+		p.LocationId, l.RoomName, f.Id, f.FloorName, b.Id, b.Name,
+		p.ScheduledTime, p.DurationInMinutes, p.Rating, p.AgeRestricted, p.CreatorId, p.CreationDateTime,
+		p.ApprovalStatus, p.ApprovedById, p.ApprovalDateTime, p.State
+	FROM Panels p
+	LEFT JOIN Locations l ON l.Id = p.LocationId
+	LEFT JOIN BuildingFloors f ON f.Id = l.FloorId
+	LEFT JOIN Buildings b ON b.Id = l.BuildingId`
+
+func panelScanTargets(panel *PanelSQL) []any {
+	return []any{
+		&panel.Id,
+		&panel.Topic,
+		&panel.Description,
+		&panel.PanelRequestorEmail,
+		&panel.LocationId,
+		&panel.RoomName,
+		&panel.FloorId,
+		&panel.FloorName,
+		&panel.BuildingId,
+		&panel.BuildingName,
+		&panel.ScheduledTime,
+		&panel.DurationInMinutes,
+		&panel.Rating,
+		&panel.AgeRestricted,
+		&panel.CreatorId,
+		&panel.CreationDateTime,
+		&panel.ApprovalStatus,
+		&panel.ApprovedById,
+		&panel.ApprovalDateTime,
+		&panel.State,
 	}
-	defer rows.Close()
+}
 
-	log.Println("INFO: Building panel list")
+func scanPanels(rows *sql.Rows) ([]PanelSQL, error) {
 	panels := make([]PanelSQL, 0)
 	for rows.Next() {
 		panel := PanelSQL{}
-		err = rows.Scan(
-			&panel.Id,
-			&panel.Topic,
-			&panel.Description,
-			&panel.PanelRequestorEmail,
-			&panel.Location,
-			&panel.ScheduledTime,
-			&panel.DurationInMinutes,
-			&panel.AgeRestricted,
-			&panel.CreatorId,
-			&panel.CreationDateTime,
-			&panel.ApprovalStatus,
-			&panel.ApprovedById,
-			&panel.ApprovalDateTime,
-		)
+		err := rows.Scan(panelScanTargets(&panel)...)
 		if err != nil {
 			log.Println("ERROR: Cannot marshal the panel objects!" + string(err.Error()))
 			return nil, err
@@ -141,144 +211,86 @@ func GetPanels() ([]PanelSQL, error) {
 		panels = append(panels, panel)
 	}
 
-	log.Println("INFO: List of all panels retrieved")
-	return panels, nil
+	return panels, rows.Err()
 }
 
-func GetPanelsByLocationId(id int) ([]PanelSQL, error) {
-	log.Println("INFO: Panels by location Id requested: Location Id: " + strconv.Itoa(id))
-	// first, take the location Id and get back the location name
-	rec, err := DB.Prepare("SELECT RoomName FROM Locations WHERE Id = ?")
+func GetPanels() ([]PanelSQL, error) {
+	log.Println("INFO: List of panel objects requested")
+	rows, err := DB.Query(panelQuery + " ORDER BY p.Id")
 	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
 		return nil, err
 	}
-	defer rec.Close()
+	defer rows.Close()
 
-	var locationName string
-	record, err := rec.Query(id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			log.Println("ERROR: No such location found in DB: " + string(err.Error()))
-			return nil, nil
-		}
-		log.Println("ERROR: Cannot retrieve location from DB: " + string(err.Error()))
-		return nil, err
-	}
-	defer record.Close()
-	err = record.Scan(
-		&locationName,
-	)
+	log.Println("INFO: Building panel list")
+	panels, err := scanPanels(rows)
 	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the location object!" + string(err.Error()))
 		return nil, err
 	}
 
-	// now that we have the location name, do the real query for the panels in that location
-	rows, err := DB.Query("SELECT * FROM Panels WHERE Location = ?", locationName)
+	log.Println("INFO: List of all panels retrieved")
+	return panels, nil
+}
+
+func GetPanelsByLocationId(id int) ([]PanelSQL, error) {
+	log.Println("INFO: Panels by location Id requested: Location Id: " + strconv.Itoa(id))
+	rows, err := DB.Query(panelQuery+" WHERE p.LocationId = ? ORDER BY p.Id", id)
 	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
 		return nil, err
 	}
 	defer rows.Close()
 
-	panels := make([]PanelSQL, 0)
-	for rows.Next() {
-		panel := PanelSQL{}
-		err = rows.Scan(
-			&panel.Id,
-			&panel.Topic,
-			&panel.Description,
-			&panel.PanelRequestorEmail,
-			&panel.Location,
-			&panel.ScheduledTime,
-			&panel.DurationInMinutes,
-			&panel.AgeRestricted,
-			&panel.CreatorId,
-			&panel.CreationDateTime,
-			&panel.ApprovalStatus,
-			&panel.ApprovedById,
-			&panel.ApprovalDateTime,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		panels = append(panels, panel)
+	panels, err := scanPanels(rows)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Println("INFO: List of all panels in location '" + locationName + "' retrieved")
+	log.Println("INFO: List of all panels in location Id '" + strconv.Itoa(id) + "' retrieved")
 	return panels, nil
 }
 
-func GetPanelById(id int) (Panel, error) {
+func GetPanelById(id int) (PanelSQL, error) {
 	log.Println("INFO: Panel by Id requested: " + strconv.Itoa(id))
-	rec, err := DB.Prepare("SELECT * FROM Panels WHERE Id = ?")
-	if err != nil {
-		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
-		return Panel{}, err
-	}
-	defer rec.Close()
-
-	panel := Panel{}
-	record, err := rec.Query(id)
+	panel := PanelSQL{}
+	err := DB.QueryRow(panelQuery+" WHERE p.Id = ?", id).Scan(panelScanTargets(&panel)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Println("ERROR: No such panel found in DB: " + string(err.Error()))
-			return Panel{}, nil
+			return PanelSQL{}, nil
 		}
 		log.Println("ERROR: Cannot retrieve panel from DB: " + string(err.Error()))
-		return Panel{}, err
-	}
-	defer record.Close()
-	err = record.Scan(
-		&panel.Id,
-		&panel.Topic,
-		&panel.Description,
-		&panel.PanelRequestorEmail,
-		&panel.Location,
-		&panel.ScheduledTime,
-		&panel.DurationInMinutes,
-		&panel.AgeRestricted,
-		&panel.CreatorId,
-		&panel.CreationDateTime,
-		&panel.ApprovalStatus,
-		&panel.ApprovedById,
-		&panel.ApprovalDateTime,
-	)
-	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the panel object!" + string(err.Error()))
-		return Panel{}, err
+		return PanelSQL{}, err
 	}
 
 	log.Println("INFO: Panel by Id '" + strconv.Itoa(id) + "' retrieved")
 	return panel, nil
 }
 
-func GetPanelLocationByPanelId(id int) (Location, error) {
+func GetPanelLocationByPanelId(id int) (LocationDetail, error) {
 	log.Println("INFO: Panel location by panel Id requested: " + strconv.Itoa(id))
-	stmt, err := DB.Prepare("SELECT Location FROM Panels WHERE Id = ?")
-	if err != nil {
-		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
-		return Location{}, err
-	}
-	defer stmt.Close()
-
-	location := Location{}
-	record, err := stmt.Query(id)
+	location := LocationDetail{}
+	err := DB.QueryRow(`SELECT l.Id, l.RoomName, f.Id, f.FloorName, b.Id, b.Name
+		FROM Panels p
+		INNER JOIN Locations l ON l.Id = p.LocationId
+		INNER JOIN BuildingFloors f ON f.Id = l.FloorId
+		INNER JOIN Buildings b ON b.Id = l.BuildingId
+		WHERE p.Id = ?`, id).Scan(
+		&location.Id,
+		&location.RoomName,
+		&location.FloorId,
+		&location.FloorName,
+		&location.BuildingId,
+		&location.BuildingName,
+	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Println("ERROR: No such panel found in DB: " + string(err.Error()))
-			return Location{}, nil
+			log.Println("ERROR: No such panel or panel location found in DB: " + string(err.Error()))
+			return LocationDetail{}, nil
 		}
 		log.Println("ERROR: Cannot retrieve panel location from DB: " + string(err.Error()))
-		return Location{}, err
-	}
-	defer record.Close()
-	err = record.Scan(
-		&location.Location,
-	)
-	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the location object!" + string(err.Error()))
-		return Location{}, err
+		return LocationDetail{}, err
 	}
 
 	log.Println("INFO: Panel location by panel Id '" + strconv.Itoa(id) + "' retrieved")
@@ -318,7 +330,7 @@ func GetPanelScheduleByPanelId(id int) (Schedule, error) {
 	return schedule, nil
 }
 
-func SetPanelLocation(id int, j Location) (bool, error) {
+func SetPanelLocation(id int, j PanelLocation, userId int) (bool, error) {
 	log.Println("INFO: Set location for panel Id '" + strconv.Itoa(id) + "'")
 	t, err := DB.Begin()
 	if err != nil {
@@ -336,21 +348,78 @@ func SetPanelLocation(id int, j Location) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("UPDATE Panels SET Location = ? WHERE Id = ?")
+	var oldTime sql.NullString
+	var oldLocation sql.NullInt64
+	err = t.QueryRow("SELECT ScheduledTime, LocationId FROM Panels WHERE Id = ?", id).Scan(&oldTime, &oldLocation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such panel found in DB: " + string(err.Error()))
+			err = &NoSuchPanel{Err: err}
+			return false, err
+		}
+		log.Println("ERROR: Cannot retrieve panel from DB: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: requested location Id to assign the panel to: " + strconv.Itoa(j.LocationId))
+	exists, err := locationExists(t, j.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(j.LocationId))}
+		return false, err
+	}
+
+	conflicts, err := findRoomChangeConflicts(t, EventPanel, "Panels", id, j.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "Panels", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE Panels SET LocationId = ?, Sequence = Sequence + 1 WHERE Id = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
 		return false, err
 	}
-	// ensure the UserStatus.Status value is either 'enabled' or 'locked'
-	log.Println("INFO: panel Id to set location of: " + strconv.Itoa(id))
-	log.Println("INFO: requested location to assign the panel to: " + j.Location)
-	// TODO: Add a test for valid locations after we add the location table
 
-	result, err := q.Exec(j.Location, id)
+	result, err := q.Exec(j.LocationId, id)
 	if err != nil {
 		log.Println("ERROR: Could not execute query for panel Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
 		return false, err
 	}
+
+	if oldLocation.Int64 != int64(j.LocationId) {
+		err = recordScheduleChange(t, EventPanel, id, ScheduleRelocated)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// moving a panel that already has a slot is a reschedule for its requestor
+	if oldTime.Valid && oldTime.String != "" && oldLocation.Int64 != int64(j.LocationId) {
+		err = queuePanelEmail(t, id, EmailPanelScheduled, map[string]string{"Rescheduled": "true"})
+		if err != nil {
+			return false, err
+		}
+		err = queueWebhookEventFor(t, WebhookPanelRescheduled, "Panels", id)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = auditUpdate(t, userId, "Panels", id, before)
+	if err != nil {
+		return false, err
+	}
 	numberOfRows, err := result.RowsAffected()
 	if err != nil {
 		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
@@ -364,46 +433,18 @@ func SetPanelLocation(id int, j Location) (bool, error) {
 	}
 
 	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
-	return true, nil
+	return numberOfRows > 0, nil
 }
 
-func SetPanelScheduledTimeById(id int, json PanelScheduledTime) (bool, string, error) {
+func SetPanelScheduledTimeById(id int, json PanelScheduledTime, userId int) (bool, string, error) {
 	log.Println("INFO: Set scheduled time for panel Id '" + strconv.Itoa(id) + "'")
 
-	timeFormat := "2006-01-02 15:04:05"
-	panelParsedStartTime, err := time.Parse(timeFormat, json.ScheduledTime)
-	panelStartTimeInUnixTime := int(panelParsedStartTime.Unix())
+	start, err := time.Parse("2006-01-02 15:04:05", json.ScheduledTime)
 	if err != nil {
 		log.Println("ERROR: Could not parse scheduled time: " + string(err.Error()))
 		return false, "Could not convert from " + json.ScheduledTime + " to UNIX time", err
 	}
 
-	// first, get all panels by location Id
-	panels, err := GetPanelsByLocationId(json.LocationId)
-	if err != nil {
-		log.Println("ERROR: Could not get panels by location Id '" + strconv.Itoa(json.LocationId) + "': " + string(err.Error()))
-		return false, "Could not get panels by location Id '" + strconv.Itoa(json.LocationId) + "'", err
-	}
-
-	// collision check
-	var startTime string
-	for _, panel := range panels {
-		// now get the start time converted to UNIX time to check against
-		if panel.ScheduledTime.Valid || panel.DurationInMinutes != 0 {
-			startTime = panel.ScheduledTime.String
-			if startTime != "" {
-				parsedTime, _ := time.Parse(timeFormat, startTime)
-				startTimeUnixTime := int(parsedTime.Unix())
-				endTimeUnixTime := startTimeUnixTime + (panel.DurationInMinutes * 60)
-				if startTimeUnixTime == panelStartTimeInUnixTime ||
-					(panelStartTimeInUnixTime > startTimeUnixTime && panelStartTimeInUnixTime < endTimeUnixTime) {
-					return false, "Panel start time conflicts with existing panel in location", new(SchedulingConflict)
-				}
-			}
-		}
-	}
-
-	// assume panel time is nil or an empty string, so we should be able to assign the panel
 	t, err := DB.Begin()
 	if err != nil {
 		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
@@ -420,18 +461,124 @@ func SetPanelScheduledTimeById(id int, json PanelScheduledTime) (bool, string, e
 		}
 	}()
 
-	q, err := DB.Prepare("UPDATE Panels SET ScheduledTime = ? WHERE Id = ?")
+	// keep the panel's current length unless a new one was asked for
+	var duration int
+	var state string
+	var oldTime sql.NullString
+	var oldLocation sql.NullInt64
+	err = t.QueryRow("SELECT DurationInMinutes, State, ScheduledTime, LocationId FROM Panels WHERE Id = ?", id).Scan(&duration, &state, &oldTime, &oldLocation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such panel found in DB: " + string(err.Error()))
+			err = &NoSuchPanel{Err: err}
+			return false, "No panel with Id '" + strconv.Itoa(id) + "'", err
+		}
+		log.Println("ERROR: Cannot retrieve panel from DB: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+	if state != PanelAccepted && state != PanelScheduled {
+		err = &InvalidPanelStateTransition{Err: errors.New("panel is " + state + ", not accepted")}
+		return false, "Panel must be accepted before it is scheduled", err
+	}
+	if json.DurationInMinutes > 0 {
+		duration = json.DurationInMinutes
+	}
+
+	exists, err := locationExists(t, json.LocationId)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(json.LocationId))}
+		return false, "Location Id '" + strconv.Itoa(json.LocationId) + "' does not exist", err
+	}
+
+	conflicts, err := findScheduleConflicts(t, EventPanel, id, json.LocationId, start, duration)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, "Panel conflicts with " + strconv.Itoa(len(conflicts)) + " scheduled event(s) in location", err
+	}
+
+	emails, err := panelistEmailsByPanelId(t, id)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	conflicts, err = findPanelistConflicts(t, id, emails, start, duration)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, "Panelists are booked on " + strconv.Itoa(len(conflicts)) + " other panel(s) at that time", err
+	}
+
+	before, err := auditSnapshot(t, "Panels", id)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	q, err := t.Prepare("UPDATE Panels SET LocationId = ?, ScheduledTime = ?, DurationInMinutes = ?, Sequence = Sequence + 1 WHERE Id = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
 		return false, json.ScheduledTime, err
 	}
 
-	result, err := q.Exec(json.ScheduledTime, id)
+	result, err := q.Exec(json.LocationId, json.ScheduledTime, duration, id)
 	if err != nil {
 		log.Println("ERROR: Could not execute query for panel Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
 		return false, json.ScheduledTime, err
 	}
 
+	if state == PanelAccepted {
+		err = changePanelState(t, id, state, PanelScheduled, "", userId)
+		if err != nil {
+			return false, json.ScheduledTime, err
+		}
+	}
+
+	// the driver hands DATETIME columns back in RFC3339, so compare parsed times
+	sameTime := false
+	if oldTime.Valid && oldTime.String != "" {
+		previous, perr := ParseScheduledTime(oldTime.String)
+		sameTime = perr == nil && previous.Equal(start)
+	}
+	if !sameTime || oldLocation.Int64 != int64(json.LocationId) {
+		change := ScheduleRescheduled
+		if sameTime {
+			change = ScheduleRelocated
+		} else if !oldTime.Valid || oldTime.String == "" {
+			change = ScheduleScheduled
+		}
+		err = recordScheduleChange(t, EventPanel, id, change)
+		if err != nil {
+			return false, json.ScheduledTime, err
+		}
+
+		rescheduled := ""
+		if oldTime.Valid && oldTime.String != "" {
+			rescheduled = "true"
+		}
+		err = queuePanelEmail(t, id, EmailPanelScheduled, map[string]string{"Rescheduled": rescheduled})
+		if err != nil {
+			return false, json.ScheduledTime, err
+		}
+		// a first slot is announced as panel.scheduled by the state change
+		if rescheduled != "" {
+			err = queueWebhookEventFor(t, WebhookPanelRescheduled, "Panels", id)
+			if err != nil {
+				return false, json.ScheduledTime, err
+			}
+		}
+	}
+
+	err = auditUpdate(t, userId, "Panels", id, before)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
 	numberOfRows, err := result.RowsAffected()
 	if err != nil {
 		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
@@ -449,6 +596,10 @@ func SetPanelScheduledTimeById(id int, json PanelScheduledTime) (bool, string, e
 	return true, json.ScheduledTime, nil
 }
 
+// SetApprovalStatusPanelById Accepts or rejects a panel. It is kept for
+// clients that predate the review lifecycle; approving a panel that is
+// already accepted or scheduled, or rejecting one already rejected, changes
+// nothing.
 func SetApprovalStatusPanelById(id int, status PanelApproval, userId int) (bool, error) {
 	log.Println("INFO: Set Approval status for panel Id '" + strconv.Itoa(id) + "'")
 	t, err := DB.Begin()
@@ -467,25 +618,39 @@ func SetApprovalStatusPanelById(id int, status PanelApproval, userId int) (bool,
 		}
 	}()
 
-	q, err := DB.Prepare("UPDATE Panels SET ApprovalStatus = ?, ApprovedById = ?, ApprovalDateTime = CURRENT_TIMESTAMP WHERE Id = ?")
+	current, found, err := panelState(t, id)
 	if err != nil {
-		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+	if !found {
+		err = t.Rollback()
 		return false, err
 	}
 
-	result, err := q.Exec(status.State, userId, id)
+	target := PanelRejected
+	if status.State {
+		target = PanelAccepted
+	}
+	if current == target || (status.State && current == PanelScheduled) {
+		err = t.Rollback()
+		return true, err
+	}
+
+	before, err := auditSnapshot(t, "Panels", id)
 	if err != nil {
-		log.Println("ERROR: Could not execute query for panel Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
 		return false, err
 	}
 
-	numberOfRows, err := result.RowsAffected()
+	err = changePanelState(t, id, current, target, "", userId)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Panels", id, before)
 	if err != nil {
-		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
 		return false, err
 	}
 
-	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -496,7 +661,7 @@ func SetApprovalStatusPanelById(id int, status PanelApproval, userId int) (bool,
 	return true, nil
 }
 
-func SetPanelAgeRestrictionById(id int, status PanelAgeRestrictionState) (bool, error) {
+func SetPanelAgeRestrictionById(id int, status PanelAgeRestrictionState, userId int) (bool, error) {
 	log.Println("INFO: Set age restriction status for panel Id '" + strconv.Itoa(id) + "'")
 	t, err := DB.Begin()
 	if err != nil {
@@ -514,7 +679,12 @@ func SetPanelAgeRestrictionById(id int, status PanelAgeRestrictionState) (bool,
 		}
 	}()
 
-	q, err := DB.Prepare("UPDATE Panels SET AgeRestricted = ? WHERE Id = ?")
+	before, err := auditSnapshot(t, "Panels", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE Panels SET AgeRestricted = ? WHERE Id = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
 		return false, err
@@ -526,6 +696,11 @@ func SetPanelAgeRestrictionById(id int, status PanelAgeRestrictionState) (bool,
 		return false, err
 	}
 
+	err = auditUpdate(t, userId, "Panels", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	numberOfRows, err := result.RowsAffected()
 	if err != nil {
 		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
@@ -0,0 +1,78 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTestPanel Creates a panel and returns its Id
+func newTestPanel(t *testing.T, topic string) int {
+	t.Helper()
+	_, err := CreatePanel(ProposedPanel{Topic: topic, Description: "A test panel", PanelRequestorEmail: "requestor@example.com"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lastId(t, "Panels")
+}
+
+func TestDeleteTaggedPanel(t *testing.T) {
+	panelId := newTestPanel(t, "Tagged panel")
+	tagId := newTestTag(t, "panel-delete")
+	_, err := AssignTagToPanel(panelId, TagAssignment{TagId: tagId}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := DeletePanelById(panelId, testUserId)
+	if err != nil || !status {
+		t.Fatalf("DeletePanelById() = %v, %v, want true, nil", status, err)
+	}
+	if n := countRows(t, "Panels", "Id = ?", panelId); n != 0 {
+		t.Errorf("panel still exists after delete")
+	}
+	if n := countRows(t, "PanelTagAssignments", "PanelId = ?", panelId); n != 0 {
+		t.Errorf("%d tag assignments left after deleting the panel", n)
+	}
+	if n := countRows(t, "Tags", "Id = ?", tagId); n != 1 {
+		t.Errorf("deleting the panel removed the tag")
+	}
+}
+
+func TestSetLocationOfMissingPanel(t *testing.T) {
+	const missingId = 9999
+	locationId := newTestLocation(t, "Panel Room")
+	status, err := SetPanelLocation(missingId, PanelLocation{LocationId: locationId}, testUserId)
+	var noSuchPanel *NoSuchPanel
+	if status || !errors.As(err, &noSuchPanel) {
+		t.Errorf("SetPanelLocation() = %v, %v, want false, NoSuchPanel", status, err)
+	}
+	if n := countRows(t, "ScheduleChanges", "EventType = ? AND EventId = ?", EventPanel, missingId); n != 0 {
+		t.Errorf("%d schedule changes recorded for a missing panel", n)
+	}
+}
+
+func TestScheduleMissingPanel(t *testing.T) {
+	status, _, err := SetPanelScheduledTimeById(9999, PanelScheduledTime{LocationId: 1, ScheduledTime: "2026-10-18 10:00:00"}, testUserId)
+	var noSuchPanel *NoSuchPanel
+	if status || !errors.As(err, &noSuchPanel) {
+		t.Errorf("SetPanelScheduledTimeById() = %v, %v, want false, NoSuchPanel", status, err)
+	}
+}
@@ -0,0 +1,171 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2id parameters used for newly stored hashes. Existing hashes carry
+// their own parameters, so raising these only affects new and upgraded ones.
+const (
+	argon2Memory      uint32 = 64 * 1024
+	argon2Iterations  uint32 = 3
+	argon2Parallelism uint8  = 2
+	argon2SaltLength         = 16
+	argon2KeyLength   uint32 = 32
+)
+
+// HashPassword Returns an argon2id hash of the password in the PHC string
+// format, e.g. $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		log.Println("ERROR: Cannot generate password salt: " + string(err.Error()))
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2Iterations, argon2Memory, argon2Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Iterations, argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword Compares the password against a stored hash in constant time.
+// Besides argon2id it accepts bcrypt hashes and the legacy unsalted SHA-512
+// hex digests. needsRehash is set when the password matched but the stored
+// hash is not an argon2id hash with the current parameters.
+func VerifyPassword(password string, encodedHash string) (match bool, needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(encodedHash, "$argon2id$"):
+		return verifyArgon2id(password, encodedHash)
+	case strings.HasPrefix(encodedHash, "$2a$"), strings.HasPrefix(encodedHash, "$2b$"), strings.HasPrefix(encodedHash, "$2y$"):
+		err = bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		return true, true, nil
+	case len(encodedHash) == sha512.Size*2:
+		sha := sha512.Sum512([]byte(password))
+		digest := hex.EncodeToString(sha[:])
+		match = subtle.ConstantTimeCompare([]byte(digest), []byte(strings.ToLower(encodedHash))) == 1
+		return match, match, nil
+	default:
+		return false, false, &UnknownPasswordHashFormat{Err: errors.New("unrecognised password hash format")}
+	}
+}
+
+func verifyArgon2id(password string, encodedHash string) (bool, bool, error) {
+	// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash> splits into 6 fields
+	fields := strings.Split(encodedHash, "$")
+	if len(fields) != 6 {
+		return false, false, &UnknownPasswordHashFormat{Err: errors.New("malformed argon2id hash")}
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(fields[2], "v=%d", &version); err != nil {
+		return false, false, &UnknownPasswordHashFormat{Err: err}
+	}
+	if version != argon2.Version {
+		return false, false, &UnknownPasswordHashFormat{Err: fmt.Errorf("unsupported argon2 version %d", version)}
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	if _, err := fmt.Sscanf(fields[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, false, &UnknownPasswordHashFormat{Err: err}
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(fields[4])
+	if err != nil {
+		return false, false, &UnknownPasswordHashFormat{Err: err}
+	}
+	key, err := base64.RawStdEncoding.DecodeString(fields[5])
+	if err != nil {
+		return false, false, &UnknownPasswordHashFormat{Err: err}
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash := memory != argon2Memory || iterations != argon2Iterations ||
+		parallelism != argon2Parallelism || uint32(len(key)) != argon2KeyLength
+	return true, needsRehash, nil
+}
+
+// UpgradePasswordHash Re-hashes a verified password with the current KDF
+// settings and stores it for the user
+func UpgradePasswordHash(username string, password string) (bool, error) {
+	log.Println("INFO: Upgrading stored password hash for user '" + username + "'")
+	encodedHash, err := HashPassword(password)
+	if err != nil {
+		return false, err
+	}
+
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	// the password itself is unchanged, so LastChangedDate is left alone
+	_, err = t.Exec("UPDATE Users SET PasswordHash = ? WHERE UserName = ?", encodedHash, username)
+	if err != nil {
+		log.Println("ERROR: Cannot store upgraded password hash: " + string(err.Error()))
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Password hash upgraded")
+	return true, nil
+}
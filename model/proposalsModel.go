@@ -0,0 +1,261 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// ProposalsPerHourPerAddress, ProposalsPerHourPerEmail How many panel
+// proposals are accepted per hour from one client address and for one email
+// address. Set from the service configuration; the defaults apply when unset.
+var (
+	ProposalsPerHourPerAddress int
+	ProposalsPerHourPerEmail   int
+)
+
+const (
+	defaultProposalsPerHourPerAddress = 5
+	defaultProposalsPerHourPerEmail   = 3
+	// ProposalVerificationHours How long a verification link stays valid
+	ProposalVerificationHours = 48
+	// intakeUserName The locked account proposed panels are created by
+	intakeUserName = "panel-intake"
+)
+
+func hashProposalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func proposalsInLastHour(t *sql.Tx, column string, value string) (int, error) {
+	var count int
+	err := t.QueryRow("SELECT COUNT(*) FROM PanelProposals WHERE "+column+" = ? AND CreationDateTime > datetime('now', '-1 hour')", value).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot count recent panel proposals: " + string(err.Error()))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CreatePanelProposal Stores a panel proposed by the public until its email
+// address is verified, and queues the email with the verification link, which
+// is verifyUrl followed by the token. Only a hash of the token is kept.
+func CreatePanelProposal(p PanelProposalSubmission, remoteAddress string, verifyUrl string) (bool, error) {
+	log.Println("INFO: Panel proposal received from " + remoteAddress + ": " + p.Topic)
+	perAddress := ProposalsPerHourPerAddress
+	if perAddress <= 0 {
+		perAddress = defaultProposalsPerHourPerAddress
+	}
+	perEmail := ProposalsPerHourPerEmail
+	if perEmail <= 0 {
+		perEmail = defaultProposalsPerHourPerEmail
+	}
+	email := normalizeEmail(p.EmailAddress)
+
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	count, err := proposalsInLastHour(t, "RemoteAddress", remoteAddress)
+	if err != nil {
+		return false, err
+	}
+	if count >= perAddress {
+		err = &TooManyRequests{Err: errors.New("no more than " + strconv.Itoa(perAddress) + " proposals per hour from one address")}
+		return false, err
+	}
+	count, err = proposalsInLastHour(t, "EmailAddress", email)
+	if err != nil {
+		return false, err
+	}
+	if count >= perEmail {
+		err = &TooManyRequests{Err: errors.New("no more than " + strconv.Itoa(perEmail) + " proposals per hour for one email address")}
+		return false, err
+	}
+
+	raw := make([]byte, 32)
+	_, err = rand.Read(raw)
+	if err != nil {
+		log.Println("ERROR: Cannot generate verification token: " + string(err.Error()))
+		return false, err
+	}
+	token := hex.EncodeToString(raw)
+
+	_, err = t.Exec(`INSERT INTO PanelProposals (Topic, Description, Name, EmailAddress, PhoneNumber, RemoteAddress, TokenHash)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		strings.TrimSpace(p.Topic), strings.TrimSpace(p.Description), strings.TrimSpace(p.Name), email,
+		strings.TrimSpace(p.PhoneNumber), remoteAddress, hashProposalToken(token))
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = queueEmail(t, email, EmailProposalVerify, map[string]string{
+		"Name":       strings.TrimSpace(p.Name),
+		"Topic":      strings.TrimSpace(p.Topic),
+		"Link":       verifyUrl + token,
+		"ValidHours": strconv.Itoa(ProposalVerificationHours),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Panel proposal stored, awaiting email verification")
+	return true, nil
+}
+
+// VerifyPanelProposal Creates the submitted panel for the proposal the token
+// was issued for, and returns its Id. Following the link again returns the
+// same panel.
+func VerifyPanelProposal(token string) (int, error) {
+	log.Println("INFO: Panel proposal verification requested")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return 0, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var proposalId int
+	var topic, description, name, email string
+	var panelId sql.NullInt64
+	err = t.QueryRow(`SELECT Id, Topic, Description, Name, EmailAddress, PanelId FROM PanelProposals
+		WHERE TokenHash = ? AND CreationDateTime > datetime('now', ?)`,
+		hashProposalToken(token), "-"+strconv.Itoa(ProposalVerificationHours)+" hours").Scan(&proposalId, &topic, &description, &name, &email, &panelId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("WARN: Unknown or expired panel proposal token")
+			err = &NoSuchProposal{Err: err}
+		} else {
+			log.Println("ERROR: Cannot retrieve panel proposal: " + string(err.Error()))
+		}
+		return 0, err
+	}
+	if panelId.Valid {
+		err = t.Rollback()
+		return int(panelId.Int64), err
+	}
+
+	var intakeId int
+	err = t.QueryRow("SELECT Id FROM Users WHERE UserName = ?", intakeUserName).Scan(&intakeId)
+	if err != nil {
+		log.Println("ERROR: Cannot find the '" + intakeUserName + "' account: " + string(err.Error()))
+		return 0, err
+	}
+
+	result, err := t.Exec("INSERT INTO Panels (Topic, Description, PanelRequestorEmail, CreatorId) VALUES (?, ?, ?, ?)", topic, description, email, intakeId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return 0, err
+	}
+	err = auditInsert(t, intakeId, "Panels", result)
+	if err != nil {
+		return 0, err
+	}
+	newId, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot get Id of new panel: " + string(err.Error()))
+		return 0, err
+	}
+	_, err = logPanelReview(t, int(newId), "", PanelSubmitted, "Proposed by "+name+" through the public form", intakeId)
+	if err != nil {
+		return 0, err
+	}
+	err = recordScheduleChange(t, EventPanel, int(newId), ScheduleCreated)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = t.Exec("UPDATE PanelProposals SET VerifiedDateTime = CURRENT_TIMESTAMP, PanelId = ? WHERE Id = ?", newId, proposalId)
+	if err != nil {
+		log.Println("ERROR: Cannot mark panel proposal as verified: " + string(err.Error()))
+		return 0, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return 0, err
+	}
+
+	log.Println("INFO: Panel proposal verified as panel Id '" + strconv.FormatInt(newId, 10) + "'")
+	return int(newId), nil
+}
+
+// GetPanelProposalByPanelId Retrieves the proposer's contact details for a
+// panel that came in through the public form. Id is 0 if it did not.
+func GetPanelProposalByPanelId(id int) (PanelProposal, error) {
+	log.Println("INFO: Proposal for panel Id '" + strconv.Itoa(id) + "' requested")
+	proposal := PanelProposal{}
+	err := DB.QueryRow(`SELECT Id, PanelId, Name, EmailAddress, PhoneNumber, CreationDateTime, IFNULL(VerifiedDateTime, '')
+		FROM PanelProposals WHERE PanelId = ?`, id).Scan(
+		&proposal.Id,
+		&proposal.PanelId,
+		&proposal.Name,
+		&proposal.EmailAddress,
+		&proposal.PhoneNumber,
+		&proposal.CreationDateTime,
+		&proposal.VerifiedDateTime,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("INFO: Panel Id '" + strconv.Itoa(id) + "' was not proposed through the public form")
+			return PanelProposal{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve panel proposal: " + string(err.Error()))
+		return PanelProposal{}, err
+	}
+
+	return proposal, nil
+}
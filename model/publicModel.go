@@ -0,0 +1,141 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+)
+
+// only approved panels that have a time slot are shown to attendees
+const publishedPanelCondition = ` WHERE p.ApprovalStatus = TRUE AND p.ScheduledTime IS NOT NULL AND p.ScheduledTime != ''`
+
+const publishedScreeningCondition = ` WHERE ScheduledTime IS NOT NULL AND ScheduledTime != ''`
+
+func GetPublishedPanels() ([]PanelSQL, error) {
+	log.Println("INFO: List of published panel objects requested")
+	rows, err := DB.Query(panelQuery + publishedPanelCondition + " ORDER BY p.ScheduledTime, p.Id")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	panels, err := scanPanels(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of published panels retrieved")
+	return panels, nil
+}
+
+func GetPublishedPanelById(id int) (PanelSQL, error) {
+	log.Println("INFO: Published panel by Id requested: " + strconv.Itoa(id))
+	panel := PanelSQL{}
+	err := DB.QueryRow(panelQuery+publishedPanelCondition+" AND p.Id = ?", id).Scan(panelScanTargets(&panel)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such published panel found in DB: " + string(err.Error()))
+			return PanelSQL{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve panel from DB: " + string(err.Error()))
+		return PanelSQL{}, err
+	}
+
+	log.Println("INFO: Published panel by Id '" + strconv.Itoa(id) + "' retrieved")
+	return panel, nil
+}
+
+func GetPublishedScreenings() ([]ScreeningSQL, error) {
+	log.Println("INFO: List of published screening objects requested")
+	rows, err := DB.Query("SELECT " + screeningColumns + " FROM VideoScreenings" + publishedScreeningCondition + " ORDER BY ScheduledTime, Id")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	screenings, err := scanScreenings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of published screenings retrieved")
+	return screenings, nil
+}
+
+func GetPublishedScreeningById(id int) (ScreeningSQL, error) {
+	log.Println("INFO: Published screening by Id requested: " + strconv.Itoa(id))
+	rows, err := DB.Query("SELECT "+screeningColumns+" FROM VideoScreenings"+publishedScreeningCondition+" AND Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return ScreeningSQL{}, err
+	}
+	defer rows.Close()
+
+	screenings, err := scanScreenings(rows)
+	if err != nil {
+		return ScreeningSQL{}, err
+	}
+	if len(screenings) == 0 {
+		log.Println("ERROR: No such published screening found in DB")
+		return ScreeningSQL{}, nil
+	}
+
+	log.Println("INFO: Published screening by Id '" + strconv.Itoa(id) + "' retrieved")
+	return screenings[0], nil
+}
+
+// GetLocationDetails Lists every location with the floor and building it is in
+func GetLocationDetails() ([]LocationDetail, error) {
+	log.Println("INFO: List of location details requested")
+	rows, err := DB.Query(`SELECT l.Id, l.RoomName, l.FloorId, IFNULL(f.FloorName, ''), l.BuildingId, IFNULL(b.Name, '')
+		FROM Locations l
+		LEFT JOIN BuildingFloors f ON f.Id = l.FloorId
+		LEFT JOIN Buildings b ON b.Id = l.BuildingId
+		ORDER BY b.Name, f.FloorName, l.RoomName`)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make([]LocationDetail, 0)
+	for rows.Next() {
+		location := LocationDetail{}
+		err = rows.Scan(
+			&location.Id,
+			&location.RoomName,
+			&location.FloorId,
+			&location.FloorName,
+			&location.BuildingId,
+			&location.BuildingName,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the location objects!" + string(err.Error()))
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+
+	log.Println("INFO: List of location details retrieved")
+	return locations, rows.Err()
+}
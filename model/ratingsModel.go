@@ -0,0 +1,265 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"sort"
+	"strconv"
+)
+
+// ratingTable Describes where the ratings for an event kind live. The event
+// table keeps the average of its ratings in its Rating column.
+type ratingTable struct {
+	EventType        string
+	EventTable       string
+	TitleColumn      string
+	RatingTable      string
+	EventIdColumn    string
+	EventDescription string
+	Tags             tagAssignmentTable
+}
+
+var (
+	panelRatings = ratingTable{
+		EventType:        EventPanel,
+		EventTable:       "Panels",
+		TitleColumn:      "Topic",
+		RatingTable:      "PanelRatings",
+		EventIdColumn:    "PanelId",
+		EventDescription: "panel",
+		Tags:             panelTagAssignments,
+	}
+	screeningRatings = ratingTable{
+		EventType:        EventScreening,
+		EventTable:       "VideoScreenings",
+		TitleColumn:      "Title",
+		RatingTable:      "VideoScreeningRatings",
+		EventIdColumn:    "VideoScreeningId",
+		EventDescription: "screening",
+		Tags:             screeningTagAssignments,
+	}
+	liveEventRatings = ratingTable{
+		EventType:        EventLiveEvent,
+		EventTable:       "LiveEvents",
+		TitleColumn:      "Topic",
+		RatingTable:      "LiveEventRatings",
+		EventIdColumn:    "LiveEventId",
+		EventDescription: "live event",
+		Tags:             liveEventTagAssignments,
+	}
+)
+
+// report groupings
+const (
+	RatingsByRoom  = "room"
+	RatingsByTrack = "track"
+)
+
+// rateEvent Records a user's rating of an event, replacing any earlier rating
+// by the same user, and refreshes the event's average in the same transaction
+func rateEvent(r ratingTable, id int, userId int, j RatingSubmission) (bool, error) {
+	log.Println("INFO: Rating " + r.EventDescription + " Id '" + strconv.Itoa(id) + "' by user Id '" + strconv.Itoa(userId) + "'")
+	if j.Rating < 1 || j.Rating > 5 {
+		return false, &InvalidRating{Err: errors.New("rating out of range: " + strconv.Itoa(j.Rating))}
+	}
+
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM "+r.EventTable+" WHERE Id = ?", id).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for " + r.EventDescription + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		log.Println("ERROR: No such " + r.EventDescription + " found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	_, err = t.Exec("INSERT INTO "+r.RatingTable+" (UserId, "+r.EventIdColumn+", Rating) VALUES (?, ?, ?)"+
+		" ON CONFLICT (UserId, "+r.EventIdColumn+") DO UPDATE SET Rating = excluded.Rating", userId, id, j.Rating)
+	if err != nil {
+		log.Println("ERROR: Cannot record rating for " + r.EventDescription + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	_, err = t.Exec("UPDATE "+r.EventTable+" SET Rating = (SELECT AVG(Rating) FROM "+r.RatingTable+" WHERE "+r.EventIdColumn+" = ?) WHERE Id = ?", id, id)
+	if err != nil {
+		log.Println("ERROR: Cannot update average rating for " + r.EventDescription + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = recordSyncChange(t, r.EventTable, id, false)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Rating for " + r.EventDescription + " Id '" + strconv.Itoa(id) + "' recorded")
+	return true, nil
+}
+
+// getEventRating Returns the average rating of an event and how many ratings
+// it is based on. The Id is 0 if the event does not exist.
+func getEventRating(r ratingTable, id int) (EventRating, error) {
+	log.Println("INFO: Rating of " + r.EventDescription + " Id '" + strconv.Itoa(id) + "' requested")
+	rating := EventRating{}
+	err := DB.QueryRow("SELECT e.Id, IFNULL(AVG(r.Rating), 0), COUNT(r.Id) FROM "+r.EventTable+" e"+
+		" LEFT JOIN "+r.RatingTable+" r ON r."+r.EventIdColumn+" = e.Id"+
+		" WHERE e.Id = ? GROUP BY e.Id", id).Scan(
+		&rating.EventId,
+		&rating.Rating,
+		&rating.RatingCount,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such " + r.EventDescription + " found in DB: " + string(err.Error()))
+			return EventRating{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve rating from DB: " + string(err.Error()))
+		return EventRating{}, err
+	}
+
+	return rating, nil
+}
+
+func RateLiveEvent(id int, userId int, j RatingSubmission) (bool, error) {
+	return rateEvent(liveEventRatings, id, userId, j)
+}
+
+func GetLiveEventRating(id int) (EventRating, error) {
+	return getEventRating(liveEventRatings, id)
+}
+
+func RatePanel(id int, userId int, j RatingSubmission) (bool, error) {
+	return rateEvent(panelRatings, id, userId, j)
+}
+
+func GetPanelRating(id int) (EventRating, error) {
+	return getEventRating(panelRatings, id)
+}
+
+func RateScreening(id int, userId int, j RatingSubmission) (bool, error) {
+	return rateEvent(screeningRatings, id, userId, j)
+}
+
+func GetScreeningRating(id int) (EventRating, error) {
+	return getEventRating(screeningRatings, id)
+}
+
+// GetTopRatedEvents Lists the best rated panels, screenings and live events of
+// every room or every track (tag), best first, keeping at most limit events per
+// group. Events nobody has rated are left out.
+func GetTopRatedEvents(groupBy string, limit int) ([]RatingReportGroup, error) {
+	log.Println("INFO: Top rated events by " + groupBy + " requested")
+	groups := make(map[int]*RatingReportGroup)
+	for _, r := range []ratingTable{panelRatings, screeningRatings, liveEventRatings} {
+		var groupJoin string
+		switch groupBy {
+		case RatingsByRoom:
+			groupJoin = " INNER JOIN Locations g ON g.Id = e.LocationId"
+		case RatingsByTrack:
+			groupJoin = " INNER JOIN " + r.Tags.AssignmentTable + " ta ON ta." + r.Tags.EventIdColumn + " = e.Id" +
+				" INNER JOIN Tags g ON g.Id = ta.TagId"
+		default:
+			return nil, errors.New("cannot group ratings by '" + groupBy + "'")
+		}
+		groupName := "g.RoomName"
+		if groupBy == RatingsByTrack {
+			groupName = "g.TagName"
+		}
+
+		rows, err := DB.Query("SELECT g.Id, " + groupName + ", e.Id, e." + r.TitleColumn + ", AVG(r.Rating), COUNT(r.Id)" +
+			" FROM " + r.EventTable + " e" +
+			" INNER JOIN " + r.RatingTable + " r ON r." + r.EventIdColumn + " = e.Id" +
+			groupJoin +
+			" GROUP BY g.Id, e.Id")
+		if err != nil {
+			log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+			return nil, err
+		}
+
+		for rows.Next() {
+			var groupId int
+			var name string
+			event := RatedEvent{EventType: r.EventType}
+			err = rows.Scan(&groupId, &name, &event.Id, &event.Title, &event.Rating, &event.RatingCount)
+			if err != nil {
+				rows.Close()
+				log.Println("ERROR: Cannot marshal the rated " + r.EventDescription + " objects!" + string(err.Error()))
+				return nil, err
+			}
+			group, ok := groups[groupId]
+			if !ok {
+				group = &RatingReportGroup{GroupId: groupId, GroupName: name, Events: make([]RatedEvent, 0)}
+				groups[groupId] = group
+			}
+			group.Events = append(group.Events, event)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := make([]RatingReportGroup, 0, len(groups))
+	for _, group := range groups {
+		// more ratings break ties, as an average of many is worth more than one
+		sort.Slice(group.Events, func(a, b int) bool {
+			if group.Events[a].Rating != group.Events[b].Rating {
+				return group.Events[a].Rating > group.Events[b].Rating
+			}
+			return group.Events[a].RatingCount > group.Events[b].RatingCount
+		})
+		if limit > 0 && len(group.Events) > limit {
+			group.Events = group.Events[:limit]
+		}
+		report = append(report, *group)
+	}
+	sort.Slice(report, func(a, b int) bool {
+		return report[a].GroupName < report[b].GroupName
+	})
+
+	log.Println("INFO: Top rated events by " + groupBy + " retrieved")
+	return report, nil
+}
@@ -0,0 +1,802 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+)
+
+func getPrivilegeId(t *sql.Tx, privilege string) (int, error) {
+	var id int
+	err := t.QueryRow("SELECT Id FROM Privileges WHERE PrivShortName = ?", privilege).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return -1, &NoSuchPrivilege{Err: errors.New("invalid privilege: " + privilege)}
+		}
+		log.Println("ERROR: Cannot retrieve privilege from DB: " + string(err.Error()))
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// AdminPrivilege The privilege that lets a user manage users, roles and role
+// assignments. Somebody always has to hold it.
+const AdminPrivilege = "users.admin"
+
+// countAdministrators Returns the number of users holding the admin
+// privilege through any of their roles
+func countAdministrators(t *sql.Tx) (int, error) {
+	var count int
+	err := t.QueryRow(`SELECT COUNT(DISTINCT u.UserId) FROM UserRoleAssignments u
+		INNER JOIN PrivilegeAssignments a ON a.RoleId = u.RoleId
+		INNER JOIN Privileges p ON p.Id = a.PrivId
+		WHERE p.PrivShortName = ?`, AdminPrivilege).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot count administrators: " + string(err.Error()))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// keepAdministrator Refuses a change that left nobody holding the admin
+// privilege, given how many held it before the change
+func keepAdministrator(t *sql.Tx, before int) error {
+	after, err := countAdministrators(t)
+	if err != nil {
+		return err
+	}
+	if before > 0 && after == 0 {
+		log.Println("WARN: Refusing a change that would leave no user holding '" + AdminPrivilege + "'")
+		return &LastAdministrator{Err: errors.New("the change would leave no user holding '" + AdminPrivilege + "'")}
+	}
+
+	return nil
+}
+
+func roleExists(t *sql.Tx, id int) (bool, error) {
+	var count int
+	err := t.QueryRow("SELECT COUNT(*) FROM Roles WHERE Id = ?", id).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for role Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func getPrivilegesByRoleId(id int) ([]string, error) {
+	rows, err := DB.Query(`SELECT p.PrivShortName FROM Privileges p
+		INNER JOIN PrivilegeAssignments a ON a.PrivId = p.Id
+		WHERE a.RoleId = ? ORDER BY p.PrivShortName`, id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	privileges := make([]string, 0)
+	for rows.Next() {
+		var privilege string
+		err = rows.Scan(&privilege)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the privilege objects!" + string(err.Error()))
+			return nil, err
+		}
+		privileges = append(privileges, privilege)
+	}
+
+	return privileges, nil
+}
+
+// getRoles Retrieve roles matching the optional WHERE clause along with their privileges
+func getRoles(where string, args ...interface{}) ([]Role, error) {
+	rows, err := DB.Query("SELECT r.Id, r.RoleName, r.Description, r.CreationDate, r.RequireTwoFactor FROM Roles r "+where+" ORDER BY r.RoleName", args...)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+
+	roles := make([]Role, 0)
+	for rows.Next() {
+		role := Role{}
+		err = rows.Scan(
+			&role.Id,
+			&role.RoleName,
+			&role.Description,
+			&role.CreationDate,
+			&role.RequireTwoFactor,
+		)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot marshal the role objects!" + string(err.Error()))
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	rows.Close()
+
+	// resolve the privileges once the role cursor is closed so we don't hold
+	// two connections per request
+	for i := range roles {
+		roles[i].Privileges, err = getPrivilegesByRoleId(roles[i].Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return roles, nil
+}
+
+func GetPrivileges() ([]Privilege, error) {
+	log.Println("INFO: List of privilege objects requested")
+	rows, err := DB.Query("SELECT Id, PrivShortName, PrivDescription FROM Privileges ORDER BY PrivShortName")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	privileges := make([]Privilege, 0)
+	for rows.Next() {
+		privilege := Privilege{}
+		err = rows.Scan(
+			&privilege.Id,
+			&privilege.PrivShortName,
+			&privilege.PrivDescription,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the privilege objects!" + string(err.Error()))
+			return nil, err
+		}
+		privileges = append(privileges, privilege)
+	}
+
+	log.Println("INFO: List of all privileges retrieved")
+	return privileges, nil
+}
+
+func GetRoles() ([]Role, error) {
+	log.Println("INFO: List of role objects requested")
+	roles, err := getRoles("")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all roles retrieved")
+	return roles, nil
+}
+
+func GetRoleById(id int) (Role, error) {
+	log.Println("INFO: Role by Id requested: " + strconv.Itoa(id))
+	roles, err := getRoles("WHERE r.Id = ?", id)
+	if err != nil {
+		return Role{}, err
+	}
+	if len(roles) == 0 {
+		log.Println("ERROR: No such role found in DB")
+		return Role{}, nil
+	}
+
+	log.Println("INFO: Role by Id '" + strconv.Itoa(id) + "' retrieved")
+	return roles[0], nil
+}
+
+func GetRolesByUserName(username string) ([]Role, error) {
+	log.Println("INFO: Roles by user name requested: " + username)
+	roles, err := getRoles(`INNER JOIN UserRoleAssignments a ON a.RoleId = r.Id
+		INNER JOIN Users u ON u.Id = a.UserId
+		WHERE u.UserName = ?`, username)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: Roles for user '" + username + "' retrieved")
+	return roles, nil
+}
+
+func CreateRole(p ProposedRole, userId int) (bool, error) {
+	log.Println("INFO: Creating a role: " + p.RoleName)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	result, err := t.Exec("INSERT INTO Roles (RoleName, Description) VALUES (?, ?)", p.RoleName, p.Description)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	err = auditInsert(t, userId, "Roles", result)
+	if err != nil {
+		return false, err
+	}
+	roleId, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve the new role Id: " + string(err.Error()))
+		return false, err
+	}
+
+	for _, privilege := range p.Privileges {
+		var privId int
+		privId, err = getPrivilegeId(t, privilege)
+		if err != nil {
+			return false, err
+		}
+		result, err = t.Exec("INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (?, ?)", roleId, privId)
+		if err != nil {
+			log.Println("ERROR: Cannot assign privilege '" + privilege + "': " + string(err.Error()))
+			return false, err
+		}
+		err = auditInsert(t, userId, "PrivilegeAssignments", result)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Role entry created")
+	return true, nil
+}
+
+func UpdateRoleById(id int, r RoleUpdate, userId int) (bool, error) {
+	log.Println("INFO: Role update requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Roles", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE Roles SET RoleName = ?, Description = ? WHERE Id = ?", r.RoleName, r.Description, id)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Roles", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Role entry updated")
+	return numberOfRows > 0, nil
+}
+
+// SetRoleTwoFactorPolicy Sets whether the holders of a role must sign in with
+// a second factor. They are asked to set one up at their next login.
+func SetRoleTwoFactorPolicy(id int, p RoleTwoFactorPolicy, userId int) (bool, error) {
+	log.Println("INFO: Two-factor policy of role Id '" + strconv.Itoa(id) + "' set to '" + strconv.FormatBool(p.Required) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Roles", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE Roles SET RequireTwoFactor = ? WHERE Id = ?", p.Required, id)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Roles", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	return numberOfRows > 0, nil
+}
+
+func DeleteRoleById(id int, userId int) (bool, error) {
+	log.Println("INFO: Role deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	administrators, err := countAdministrators(t)
+	if err != nil {
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "Roles", id)
+	if err != nil {
+		return false, err
+	}
+
+	// the role's privilege and user assignments go with it
+	for _, table := range []string{"PrivilegeAssignments", "UserRoleAssignments"} {
+		err = auditDeleteWhere(t, userId, table, "RoleId = ?", id)
+		if err != nil {
+			return false, err
+		}
+		_, err = t.Exec("DELETE FROM "+table+" WHERE RoleId = ?", id)
+		if err != nil {
+			log.Println("ERROR: Cannot remove " + table + " for role Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	result, err := t.Exec("DELETE FROM Roles WHERE Id IS ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete role with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	err = keepAdministrator(t, administrators)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "Roles", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Role with id '" + strconv.Itoa(id) + "' has been deleted")
+	return numberOfRows > 0, nil
+}
+
+func AssignPrivilegeToRole(id int, j PrivilegeAssignment, userId int) (bool, error) {
+	log.Println("INFO: Assign privilege '" + j.Privilege + "' to role Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	exists, err := roleExists(t, id)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		t.Rollback()
+		return false, nil
+	}
+	privId, err := getPrivilegeId(t, j.Privilege)
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM PrivilegeAssignments WHERE RoleId = ? AND PrivId = ?", id, privId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for existing privilege assignment: " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		var result sql.Result
+		result, err = t.Exec("INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (?, ?)", id, privId)
+		if err != nil {
+			log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+			return false, err
+		}
+		err = auditInsert(t, userId, "PrivilegeAssignments", result)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Privilege assigned")
+	return true, nil
+}
+
+func UnassignPrivilegeFromRole(id int, j PrivilegeAssignment, userId int) (bool, error) {
+	log.Println("INFO: Unassign privilege '" + j.Privilege + "' from role Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	privId, err := getPrivilegeId(t, j.Privilege)
+	if err != nil {
+		return false, err
+	}
+
+	administrators, err := countAdministrators(t)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditDeleteWhere(t, userId, "PrivilegeAssignments", "RoleId = ? AND PrivId = ?", id, privId)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("DELETE FROM PrivilegeAssignments WHERE RoleId = ? AND PrivId = ?", id, privId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	err = keepAdministrator(t, administrators)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	return numberOfRows > 0, nil
+}
+
+func AssignRoleToUser(username string, j RoleAssignment, userId int) (bool, error) {
+	log.Println("INFO: Assign role Id '" + strconv.Itoa(j.RoleId) + "' to user '" + username + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	exists, err := roleExists(t, j.RoleId)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		err = &NoSuchRole{Err: errors.New("invalid role Id: " + strconv.Itoa(j.RoleId))}
+		return false, err
+	}
+
+	var assigneeId int
+	err = t.QueryRow("SELECT Id FROM Users WHERE UserName = ?", username).Scan(&assigneeId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("WARN: No such user '" + username + "'")
+			err = nil
+			t.Rollback()
+			return false, nil
+		}
+		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
+		return false, err
+	}
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM UserRoleAssignments WHERE UserId = ? AND RoleId = ?", assigneeId, j.RoleId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for existing role assignment: " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		var result sql.Result
+		result, err = t.Exec("INSERT INTO UserRoleAssignments (UserId, RoleId) VALUES (?, ?)", assigneeId, j.RoleId)
+		if err != nil {
+			log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+			return false, err
+		}
+		err = auditInsert(t, userId, "UserRoleAssignments", result)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Role assigned")
+	return true, nil
+}
+
+func UnassignRoleFromUser(username string, j RoleAssignment, userId int) (bool, error) {
+	log.Println("INFO: Unassign role Id '" + strconv.Itoa(j.RoleId) + "' from user '" + username + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	administrators, err := countAdministrators(t)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditDeleteWhere(t, userId, "UserRoleAssignments", "RoleId = ? AND UserId = (SELECT Id FROM Users WHERE UserName = ?)", j.RoleId, username)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec(`DELETE FROM UserRoleAssignments WHERE RoleId = ?
+		AND UserId = (SELECT Id FROM Users WHERE UserName = ?)`, j.RoleId, username)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	err = keepAdministrator(t, administrators)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	return numberOfRows > 0, nil
+}
+
+// UserHasPrivilege Returns whether any of the roles held by the user grants the privilege
+func UserHasPrivilege(userId int, privilege string) (bool, error) {
+	var count int
+	err := DB.QueryRow(`SELECT COUNT(*) FROM UserRoleAssignments u
+		INNER JOIN PrivilegeAssignments a ON a.RoleId = u.RoleId
+		INNER JOIN Privileges p ON p.Id = a.PrivId
+		WHERE u.UserId = ? AND p.PrivShortName = ?`, userId, privilege).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check privilege '" + privilege + "' for user Id '" + strconv.Itoa(userId) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GrantAdministrator Gives a user a role that grants the admin privilege, so
+// a fresh deployment, or one where nobody holds it any more, gets its first
+// administrator. The role is the first one granting the privilege, or a new
+// 'admin' role if no role does. A grant from the command line has no acting
+// user, so it is audited as made by the user themselves.
+func GrantAdministrator(username string) error {
+	log.Println("INFO: Granting '" + AdminPrivilege + "' to user '" + username + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	userId, err := getUserIdByUserNameTx(t, username)
+	if err != nil {
+		return err
+	}
+	if userId == 0 {
+		err = errors.New("no such user: " + username)
+		return err
+	}
+
+	privId, err := getPrivilegeId(t, AdminPrivilege)
+	if err != nil {
+		return err
+	}
+
+	var existingRole sql.NullInt64
+	err = t.QueryRow("SELECT MIN(RoleId) FROM PrivilegeAssignments WHERE PrivId = ?", privId).Scan(&existingRole)
+	if err != nil {
+		log.Println("ERROR: Cannot look up a role granting '" + AdminPrivilege + "': " + string(err.Error()))
+		return err
+	}
+	roleId := existingRole.Int64
+	if !existingRole.Valid {
+		var result sql.Result
+		result, err = t.Exec("INSERT INTO Roles (RoleName, Description) VALUES ('admin', 'Full administrative access')")
+		if err != nil {
+			log.Println("ERROR: Cannot create the admin role: " + string(err.Error()))
+			return err
+		}
+		err = auditInsert(t, userId, "Roles", result)
+		if err != nil {
+			return err
+		}
+		roleId, err = result.LastInsertId()
+		if err != nil {
+			log.Println("ERROR: Cannot retrieve the new role Id: " + string(err.Error()))
+			return err
+		}
+		result, err = t.Exec("INSERT INTO PrivilegeAssignments (RoleId, PrivId) VALUES (?, ?)", roleId, privId)
+		if err != nil {
+			log.Println("ERROR: Cannot assign privilege '" + AdminPrivilege + "': " + string(err.Error()))
+			return err
+		}
+		err = auditInsert(t, userId, "PrivilegeAssignments", result)
+		if err != nil {
+			return err
+		}
+	}
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM UserRoleAssignments WHERE UserId = ? AND RoleId = ?", userId, roleId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for existing role assignment: " + string(err.Error()))
+		return err
+	}
+	if count == 0 {
+		var result sql.Result
+		result, err = t.Exec("INSERT INTO UserRoleAssignments (UserId, RoleId) VALUES (?, ?)", userId, roleId)
+		if err != nil {
+			log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+			return err
+		}
+		err = auditInsert(t, userId, "UserRoleAssignments", result)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return err
+	}
+
+	log.Println("INFO: User '" + username + "' holds '" + AdminPrivilege + "'")
+	return nil
+}
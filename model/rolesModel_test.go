@@ -0,0 +1,139 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTestUser Creates a user without a password and returns its Id
+func newTestUser(t *testing.T, username string) int {
+	t.Helper()
+	result, err := DB.Exec("INSERT INTO Users (UserName, PasswordHash) VALUES (?, '')", username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int(id)
+}
+
+// wantLastAdministrator Fails the test unless err refuses to remove the last administrator
+func wantLastAdministrator(t *testing.T, change string, err error) {
+	t.Helper()
+	var lastAdministrator *LastAdministrator
+	if !errors.As(err, &lastAdministrator) {
+		t.Errorf("%s: err = %v, want LastAdministrator", change, err)
+	}
+}
+
+func TestGrantAdministratorUnknownUser(t *testing.T) {
+	if err := GrantAdministrator("nobody"); err == nil {
+		t.Error("GrantAdministrator() of an unknown user succeeded")
+	}
+}
+
+func TestLastAdministratorIsKept(t *testing.T) {
+	firstId := newTestUser(t, "first-admin")
+	err := GrantAdministrator("first-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	isAdmin, err := UserHasPrivilege(firstId, AdminPrivilege)
+	if err != nil || !isAdmin {
+		t.Fatalf("UserHasPrivilege() after GrantAdministrator() = %v, %v, want true, nil", isAdmin, err)
+	}
+
+	var roleId int
+	err = DB.QueryRow(`SELECT RoleId FROM UserRoleAssignments WHERE UserId = ?`, firstId).Scan(&roleId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = UnassignRoleFromUser("first-admin", RoleAssignment{RoleId: roleId}, testUserId)
+	wantLastAdministrator(t, "UnassignRoleFromUser", err)
+	_, err = UnassignPrivilegeFromRole(roleId, PrivilegeAssignment{Privilege: AdminPrivilege}, testUserId)
+	wantLastAdministrator(t, "UnassignPrivilegeFromRole", err)
+	_, err = DeleteRoleById(roleId, testUserId)
+	wantLastAdministrator(t, "DeleteRoleById", err)
+	_, err = DeleteUser("first-admin", testUserId)
+	wantLastAdministrator(t, "DeleteUser", err)
+
+	isAdmin, err = UserHasPrivilege(firstId, AdminPrivilege)
+	if err != nil || !isAdmin {
+		t.Fatalf("refused changes took the privilege away: %v, %v", isAdmin, err)
+	}
+
+	// with a second administrator, the first one can step down
+	newTestUser(t, "second-admin")
+	err = GrantAdministrator("second-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := UnassignRoleFromUser("first-admin", RoleAssignment{RoleId: roleId}, testUserId)
+	if err != nil || !status {
+		t.Errorf("UnassignRoleFromUser() with another administrator = %v, %v, want true, nil", status, err)
+	}
+}
+
+func TestRoleChangesAreAudited(t *testing.T) {
+	_, err := CreateRole(ProposedRole{RoleName: "audited", Description: "Audited role", Privileges: []string{"audit.read"}}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roleId := lastId(t, "Roles")
+	wantAudited(t, "Roles", AuditCreate, roleId)
+	privilegeAssignmentId := lastId(t, "PrivilegeAssignments")
+	wantAudited(t, "PrivilegeAssignments", AuditCreate, privilegeAssignmentId)
+
+	_, err = UpdateRoleById(roleId, RoleUpdate{RoleName: "audited", Description: "Renamed"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = SetRoleTwoFactorPolicy(roleId, RoleTwoFactorPolicy{Required: true}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, "Audit", "TableChanged = 'Roles' AND ChangeClass = ? AND RecordId = ?", AuditUpdate, roleId); n != 2 {
+		t.Errorf("%d update audit entries for the role, want 2", n)
+	}
+
+	_, err = AssignRoleToUser("tester", RoleAssignment{RoleId: roleId}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roleAssignmentId := lastId(t, "UserRoleAssignments")
+	wantAudited(t, "UserRoleAssignments", AuditCreate, roleAssignmentId)
+
+	_, err = UnassignPrivilegeFromRole(roleId, PrivilegeAssignment{Privilege: "audit.read"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "PrivilegeAssignments", AuditDelete, privilegeAssignmentId)
+
+	_, err = DeleteRoleById(roleId, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "UserRoleAssignments", AuditDelete, roleAssignmentId)
+	wantAudited(t, "Roles", AuditDelete, roleId)
+}
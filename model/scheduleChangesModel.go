@@ -0,0 +1,103 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+)
+
+// kinds of schedule changes streamed to clients
+const (
+	ScheduleCreated     = "created"
+	ScheduleScheduled   = "scheduled"
+	ScheduleRescheduled = "rescheduled"
+	ScheduleRelocated   = "relocated"
+	ScheduleApproved    = "approved"
+	ScheduleRejected    = "rejected"
+	ScheduleCancelled   = "cancelled"
+	ScheduleDeleted     = "deleted"
+)
+
+// scheduleChangeSources Reads the current state of each kind of event for the
+// change log, under the column names of ScheduleChanges
+var scheduleChangeSources = map[string]string{
+	EventPanel: `SELECT Id, Topic AS Title, State, LocationId, ScheduledTime, DurationInMinutes
+		FROM Panels WHERE Id = ?`,
+	EventScreening: `SELECT Id, Title, '' AS State, LocationId, ScheduledTime, DurationInMinutes
+		FROM VideoScreenings WHERE Id = ?`,
+}
+
+// recordScheduleChange Adds a change to the schedule change log, with the
+// event as it is now. It must be called inside the transaction making the
+// change, after the change and before a deletion.
+func recordScheduleChange(t *sql.Tx, eventType string, id int, change string) error {
+	_, err := t.Exec(`INSERT INTO ScheduleChanges (EventType, EventId, Change, Title, State, LocationId, ScheduledTime, DurationInMinutes)
+		SELECT ?, s.Id, ?, s.Title, s.State, s.LocationId, s.ScheduledTime, s.DurationInMinutes
+		FROM (`+scheduleChangeSources[eventType]+`) s`, eventType, change, id)
+	if err != nil {
+		log.Println("ERROR: Cannot record " + change + " change of " + eventType + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// GetScheduleChangesSince Lists up to limit schedule changes made after the
+// change with the given Id, oldest first
+func GetScheduleChangesSince(afterId int, limit int) ([]ScheduleChange, error) {
+	rows, err := DB.Query(`SELECT Id, EventType, EventId, Change, Title, State, IFNULL(LocationId, 0),
+			IFNULL(ScheduledTime, ''), DurationInMinutes, ChangeDateTime
+		FROM ScheduleChanges
+		WHERE Id > ?
+		ORDER BY Id LIMIT ?`, afterId, limit)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]ScheduleChange, 0)
+	for rows.Next() {
+		change := ScheduleChange{}
+		err = rows.Scan(&change.Id, &change.EventType, &change.EventId, &change.Change, &change.Title, &change.State,
+			&change.LocationId, &change.ScheduledTime, &change.DurationInMinutes, &change.ChangeDateTime)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the schedule change objects!" + string(err.Error()))
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// LatestScheduleChangeId Returns the Id of the most recent schedule change,
+// or 0 if there are none
+func LatestScheduleChangeId() (int, error) {
+	var id int
+	err := DB.QueryRow("SELECT IFNULL(MAX(Id), 0) FROM ScheduleChanges").Scan(&id)
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve the latest schedule change: " + string(err.Error()))
+		return 0, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,134 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"time"
+)
+
+// event kinds that can be booked into a room
+const (
+	EventPanel     = "panel"
+	EventScreening = "screening"
+	EventLiveEvent = "liveEvent"
+)
+
+// ChangeoverBufferMinutes Minutes a room must stay free between the end of one
+// event and the start of the next. Set from the service configuration.
+var ChangeoverBufferMinutes int
+
+// rejected and cancelled panels keep their last slot for the record but no
+// longer hold the room
+const roomBookingsQuery = `SELECT 'panel', Id, Topic, ScheduledTime, DurationInMinutes FROM Panels
+		WHERE LocationId = ? AND ScheduledTime IS NOT NULL AND ScheduledTime != ''
+		AND State NOT IN ('rejected', 'cancelled')
+	UNION ALL
+	SELECT 'screening', Id, Title, ScheduledTime, DurationInMinutes FROM VideoScreenings
+		WHERE LocationId = ? AND ScheduledTime IS NOT NULL AND ScheduledTime != ''
+	UNION ALL
+	SELECT 'liveEvent', Id, Topic, ScheduledTime, DurationInMinutes FROM LiveEvents
+		WHERE LocationId = ? AND ScheduledTime IS NOT NULL AND ScheduledTime != ''`
+
+// findScheduleConflicts Lists the events booked into a location whose time
+// slot, widened by the changeover buffer, overlaps with the requested one. The
+// event being (re)scheduled is identified by its kind and Id so it never
+// conflicts with itself. It must be called inside the transaction that writes
+// the new slot so no other booking can slip in between the check and the write.
+func findScheduleConflicts(t *sql.Tx, eventType string, id int, locationId int, start time.Time, durationInMinutes int) ([]ScheduleConflict, error) {
+	buffer := time.Duration(0)
+	if ChangeoverBufferMinutes > 0 {
+		buffer = time.Duration(ChangeoverBufferMinutes) * time.Minute
+	}
+	end := start.Add(time.Duration(durationInMinutes) * time.Minute)
+
+	rows, err := t.Query(roomBookingsQuery, locationId, locationId, locationId)
+	if err != nil {
+		log.Println("ERROR: Could not get events by location Id '" + strconv.Itoa(locationId) + "': " + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := make([]ScheduleConflict, 0)
+	for rows.Next() {
+		booking := ScheduleConflict{LocationId: locationId}
+		err = rows.Scan(
+			&booking.EventType,
+			&booking.Id,
+			&booking.Title,
+			&booking.ScheduledTime,
+			&booking.DurationInMinutes,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the room bookings: " + string(err.Error()))
+			return nil, err
+		}
+		if booking.EventType == eventType && booking.Id == id {
+			continue
+		}
+
+		bookingStart, err := ParseScheduledTime(booking.ScheduledTime)
+		if err != nil {
+			log.Println("WARN: Cannot parse scheduled time of " + booking.EventType + " Id '" + strconv.Itoa(booking.Id) + "': " + string(err.Error()))
+			continue
+		}
+		bookingEnd := bookingStart.Add(time.Duration(booking.DurationInMinutes) * time.Minute)
+		if start.Before(bookingEnd.Add(buffer)) && bookingStart.Before(end.Add(buffer)) {
+			conflicts = append(conflicts, booking)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) > 0 {
+		log.Println("WARN: Requested slot for " + eventType + " Id '" + strconv.Itoa(id) + "' conflicts with " +
+			strconv.Itoa(len(conflicts)) + " event(s) in location Id '" + strconv.Itoa(locationId) + "'")
+	}
+	return conflicts, nil
+}
+
+// findRoomChangeConflicts Checks whether an event can be moved into another
+// location at the time it is already scheduled for. Events that have not been
+// scheduled yet can go anywhere.
+func findRoomChangeConflicts(t *sql.Tx, eventType string, table string, id int, locationId int) ([]ScheduleConflict, error) {
+	var scheduledTime sql.NullString
+	var duration int
+	err := t.QueryRow("SELECT ScheduledTime, DurationInMinutes FROM "+table+" WHERE Id = ?", id).Scan(&scheduledTime, &duration)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println("ERROR: Cannot retrieve " + eventType + " schedule from DB: " + string(err.Error()))
+		return nil, err
+	}
+	if !scheduledTime.Valid || scheduledTime.String == "" {
+		return nil, nil
+	}
+
+	start, err := ParseScheduledTime(scheduledTime.String)
+	if err != nil {
+		log.Println("WARN: Cannot parse scheduled time of " + eventType + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return nil, nil
+	}
+
+	return findScheduleConflicts(t, eventType, id, locationId, start, duration)
+}
@@ -0,0 +1,572 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+)
+
+const screeningColumns = `Id, Title, Synopsis, LocationId, ScheduledTime, DurationInMinutes, AgeRestricted, Rating, CreatorId, CreationDateTime`
+
+// ParseScheduledTime Parse a scheduled time as submitted by a client or as
+// handed back by the SQLite driver, which returns DATETIME columns in RFC3339
+func ParseScheduledTime(value string) (time.Time, error) {
+	parsed, err := time.Parse("2006-01-02 15:04:05", value)
+	if err != nil {
+		return time.Parse(time.RFC3339, value)
+	}
+	return parsed, nil
+}
+
+func scanScreenings(rows *sql.Rows) ([]ScreeningSQL, error) {
+	screenings := make([]ScreeningSQL, 0)
+	for rows.Next() {
+		screening := ScreeningSQL{}
+		err := rows.Scan(
+			&screening.Id,
+			&screening.Title,
+			&screening.Synopsis,
+			&screening.LocationId,
+			&screening.ScheduledTime,
+			&screening.DurationInMinutes,
+			&screening.AgeRestricted,
+			&screening.Rating,
+			&screening.CreatorId,
+			&screening.CreationDateTime,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the screening objects!" + string(err.Error()))
+			return nil, err
+		}
+		screenings = append(screenings, screening)
+	}
+
+	return screenings, rows.Err()
+}
+
+func CreateScreening(p ProposedScreening, id int) (bool, error) {
+	log.Println("INFO: Creating a screening: " + p.Title)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	// the schema has no default duration for screenings, so fall back to the
+	// same 30 minute slot panels get
+	duration := p.DurationInMinutes
+	if duration <= 0 {
+		duration = 30
+	}
+
+	screeningInfo := `INSERT INTO VideoScreenings (Title, Synopsis, DurationInMinutes, CreatorId) VALUES (?, ?, ?, ?)`
+	q, err := t.Prepare(screeningInfo)
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(p.Title, p.Synopsis, duration, id)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, id, "VideoScreenings", result)
+	if err != nil {
+		return false, err
+	}
+
+	screeningId, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot get Id of new screening: " + string(err.Error()))
+		return false, err
+	}
+	err = recordScheduleChange(t, EventScreening, int(screeningId), ScheduleCreated)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Screening entry created")
+	return true, nil
+}
+
+func DeleteScreeningById(id int, userId int) (bool, error) {
+	log.Println("INFO: Screening deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "VideoScreenings", id)
+	if err != nil {
+		return false, err
+	}
+	if before != nil {
+		err = recordScheduleChange(t, EventScreening, id, ScheduleDeleted)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// tag assignments and ratings reference the screening, so they go first
+	for _, stmt := range []string{
+		"DELETE FROM VideoScreeningTagAssignments WHERE VideoScreeningId = ?",
+		"DELETE FROM VideoScreeningRatings WHERE VideoScreeningId = ?",
+	} {
+		_, err = t.Exec(stmt, id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete records for screening with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	q, err := t.Prepare("DELETE FROM VideoScreenings WHERE Id IS ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
+		return false, err
+	}
+
+	_, err = q.Exec(id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete screening with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = queueWebhookEvent(t, WebhookScreeningDeleted, "VideoScreenings", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "VideoScreenings", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Screening with id '" + strconv.Itoa(id) + "' has been deleted")
+	return true, nil
+}
+
+func GetScreenings() ([]ScreeningSQL, error) {
+	log.Println("INFO: List of screening objects requested")
+	rows, err := DB.Query("SELECT " + screeningColumns + " FROM VideoScreenings")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	log.Println("INFO: Building screening list")
+	screenings, err := scanScreenings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all screenings retrieved")
+	return screenings, nil
+}
+
+func GetScreeningsByLocationId(id int) ([]ScreeningSQL, error) {
+	log.Println("INFO: Screenings by location Id requested: Location Id: " + strconv.Itoa(id))
+	rows, err := DB.Query("SELECT "+screeningColumns+" FROM VideoScreenings WHERE LocationId = ?", id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	screenings, err := scanScreenings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all screenings in location Id '" + strconv.Itoa(id) + "' retrieved")
+	return screenings, nil
+}
+
+func GetScreeningById(id int) (ScreeningSQL, error) {
+	log.Println("INFO: Screening by Id requested: " + strconv.Itoa(id))
+	screening := ScreeningSQL{}
+	err := DB.QueryRow("SELECT "+screeningColumns+" FROM VideoScreenings WHERE Id = ?", id).Scan(
+		&screening.Id,
+		&screening.Title,
+		&screening.Synopsis,
+		&screening.LocationId,
+		&screening.ScheduledTime,
+		&screening.DurationInMinutes,
+		&screening.AgeRestricted,
+		&screening.Rating,
+		&screening.CreatorId,
+		&screening.CreationDateTime,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such screening found in DB: " + string(err.Error()))
+			return ScreeningSQL{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve screening from DB: " + string(err.Error()))
+		return ScreeningSQL{}, err
+	}
+
+	log.Println("INFO: Screening by Id '" + strconv.Itoa(id) + "' retrieved")
+	return screening, nil
+}
+
+func GetScreeningLocationByScreeningId(id int) (Location, error) {
+	log.Println("INFO: Screening location by screening Id requested: " + strconv.Itoa(id))
+	location := Location{}
+	err := DB.QueryRow(`SELECT l.Id, l.RoomName, l.FloorId, l.BuildingId, l.CreatorId, l.CreationDate
+		FROM VideoScreenings s INNER JOIN Locations l ON l.Id = s.LocationId
+		WHERE s.Id = ?`, id).Scan(
+		&location.Id,
+		&location.Location,
+		&location.FloorId,
+		&location.BuildingId,
+		&location.CreatorId,
+		&location.CreationDate,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such screening or screening location found in DB: " + string(err.Error()))
+			return Location{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve screening location from DB: " + string(err.Error()))
+		return Location{}, err
+	}
+
+	log.Println("INFO: Screening location by screening Id '" + strconv.Itoa(id) + "' retrieved")
+	return location, nil
+}
+
+func GetScreeningScheduleByScreeningId(id int) (Schedule, error) {
+	log.Println("INFO: Screening schedule by screening Id requested: " + strconv.Itoa(id))
+	var startTime sql.NullString
+	schedule := Schedule{}
+	err := DB.QueryRow("SELECT ScheduledTime, DurationInMinutes FROM VideoScreenings WHERE Id = ?", id).Scan(
+		&startTime,
+		&schedule.DurationInMinutes,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such screening found in DB: " + string(err.Error()))
+			return Schedule{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve screening schedule from DB: " + string(err.Error()))
+		return Schedule{}, err
+	}
+	schedule.StartTime = startTime.String
+
+	log.Println("INFO: Screening schedule by screening Id '" + strconv.Itoa(id) + "' retrieved")
+	return schedule, nil
+}
+
+func SetScreeningLocation(id int, j ScreeningLocation, userId int) (bool, error) {
+	log.Println("INFO: Set location for screening Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "VideoScreenings", id)
+	if err != nil {
+		return false, err
+	}
+	if before == nil {
+		err = &NoSuchScreening{Err: errors.New("invalid screening Id: " + strconv.Itoa(id))}
+		return false, err
+	}
+
+	log.Println("INFO: requested location Id to assign the screening to: " + strconv.Itoa(j.LocationId))
+	exists, err := locationExists(t, j.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(j.LocationId))}
+		return false, err
+	}
+
+	conflicts, err := findRoomChangeConflicts(t, EventScreening, "VideoScreenings", id, j.LocationId)
+	if err != nil {
+		return false, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE VideoScreenings SET LocationId = ?, Sequence = Sequence + 1 WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(j.LocationId, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for screening Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = recordScheduleChange(t, EventScreening, id, ScheduleRelocated)
+	if err != nil {
+		return false, err
+	}
+
+	err = queueWebhookEventFor(t, WebhookScreeningRescheduled, "VideoScreenings", id)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "VideoScreenings", id, before)
+	if err != nil {
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	return numberOfRows > 0, nil
+}
+
+func SetScreeningScheduledTimeById(id int, json ScreeningScheduledTime, userId int) (bool, string, error) {
+	log.Println("INFO: Set scheduled time for screening Id '" + strconv.Itoa(id) + "'")
+
+	start, err := time.Parse("2006-01-02 15:04:05", json.ScheduledTime)
+	if err != nil {
+		log.Println("ERROR: Could not parse scheduled time: " + string(err.Error()))
+		return false, "Could not convert from " + json.ScheduledTime + " to UNIX time", err
+	}
+
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	// keep the screening's current length unless a new one was asked for
+	var duration int
+	var oldTime sql.NullString
+	err = t.QueryRow("SELECT DurationInMinutes, ScheduledTime FROM VideoScreenings WHERE Id = ?", id).Scan(&duration, &oldTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such screening found in DB: " + string(err.Error()))
+			err = &NoSuchScreening{Err: err}
+			return false, "No screening with Id '" + strconv.Itoa(id) + "'", err
+		}
+		log.Println("ERROR: Cannot retrieve screening from DB: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+	if json.DurationInMinutes > 0 {
+		duration = json.DurationInMinutes
+	}
+
+	exists, err := locationExists(t, json.LocationId)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if !exists {
+		err = &NoSuchLocation{Err: errors.New("invalid location Id: " + strconv.Itoa(json.LocationId))}
+		return false, "Location Id '" + strconv.Itoa(json.LocationId) + "' does not exist", err
+	}
+
+	conflicts, err := findScheduleConflicts(t, EventScreening, id, json.LocationId, start, duration)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+	if len(conflicts) > 0 {
+		err = &SchedulingConflict{Conflicts: conflicts}
+		return false, "Screening conflicts with " + strconv.Itoa(len(conflicts)) + " scheduled event(s) in location", err
+	}
+
+	before, err := auditSnapshot(t, "VideoScreenings", id)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	q, err := t.Prepare("UPDATE VideoScreenings SET LocationId = ?, ScheduledTime = ?, DurationInMinutes = ?, Sequence = Sequence + 1 WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	result, err := q.Exec(json.LocationId, json.ScheduledTime, duration, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for screening Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	change := ScheduleRescheduled
+	if !oldTime.Valid || oldTime.String == "" {
+		change = ScheduleScheduled
+	}
+	err = recordScheduleChange(t, EventScreening, id, change)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	err = queueWebhookEventFor(t, WebhookScreeningRescheduled, "VideoScreenings", id)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	err = auditUpdate(t, userId, "VideoScreenings", id, before)
+	if err != nil {
+		return false, json.ScheduledTime, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, json.ScheduledTime, err
+	}
+
+	log.Println("INFO: Scheduled time for screening Id '" + strconv.Itoa(id) + "' set to '" + json.ScheduledTime + "'")
+	return true, json.ScheduledTime, nil
+}
+
+func SetScreeningAgeRestrictionById(id int, status ScreeningAgeRestrictionState, userId int) (bool, error) {
+	log.Println("INFO: Set age restriction status for screening Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "VideoScreenings", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE VideoScreenings SET AgeRestricted = ? WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(status.RestrictionState, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for screening Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "VideoScreenings", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Age restriction status for screening Id '" + strconv.Itoa(id) + "' set to '" + strconv.FormatBool(status.RestrictionState) + "'")
+	return numberOfRows > 0, nil
+}
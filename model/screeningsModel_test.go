@@ -0,0 +1,98 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTestScreening Creates a screening and returns its Id
+func newTestScreening(t *testing.T, title string) int {
+	t.Helper()
+	_, err := CreateScreening(ProposedScreening{Title: title, Synopsis: "A test screening", DurationInMinutes: 90}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lastId(t, "VideoScreenings")
+}
+
+func TestDeleteTaggedScreening(t *testing.T) {
+	screeningId := newTestScreening(t, "Tagged screening")
+	tagId := newTestTag(t, "screening-delete")
+	_, err := AssignTagToScreening(screeningId, TagAssignment{TagId: tagId}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := DeleteScreeningById(screeningId, testUserId)
+	if err != nil || !status {
+		t.Fatalf("DeleteScreeningById() = %v, %v, want true, nil", status, err)
+	}
+	if n := countRows(t, "VideoScreenings", "Id = ?", screeningId); n != 0 {
+		t.Errorf("screening still exists after delete")
+	}
+	if n := countRows(t, "VideoScreeningTagAssignments", "VideoScreeningId = ?", screeningId); n != 0 {
+		t.Errorf("%d tag assignments left after deleting the screening", n)
+	}
+	if n := countRows(t, "Tags", "Id = ?", tagId); n != 1 {
+		t.Errorf("deleting the screening removed the tag")
+	}
+}
+
+func TestScreeningChangesAreAudited(t *testing.T) {
+	screeningId := newTestScreening(t, "Audited screening")
+	wantAudited(t, "VideoScreenings", AuditCreate, screeningId)
+
+	_, err := SetScreeningAgeRestrictionById(screeningId, ScreeningAgeRestrictionState{RestrictionState: true}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "VideoScreenings", AuditUpdate, screeningId)
+
+	_, err = DeleteScreeningById(screeningId, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "VideoScreenings", AuditDelete, screeningId)
+}
+
+func TestSetLocationOfMissingScreening(t *testing.T) {
+	const missingId = 9999
+	locationId := newTestLocation(t, "Screening Room")
+	status, err := SetScreeningLocation(missingId, ScreeningLocation{LocationId: locationId}, testUserId)
+	var noSuchScreening *NoSuchScreening
+	if status || !errors.As(err, &noSuchScreening) {
+		t.Errorf("SetScreeningLocation() = %v, %v, want false, NoSuchScreening", status, err)
+	}
+	if n := countRows(t, "ScheduleChanges", "EventType = ? AND EventId = ?", EventScreening, missingId); n != 0 {
+		t.Errorf("%d schedule changes recorded for a missing screening", n)
+	}
+	if n := countRows(t, "SyncLog", "EntityType = ? AND EntityId = ?", SyncScreening, missingId); n != 0 {
+		t.Errorf("%d sync changes recorded for a missing screening", n)
+	}
+}
+
+func TestScheduleMissingScreening(t *testing.T) {
+	status, _, err := SetScreeningScheduledTimeById(9999, ScreeningScheduledTime{LocationId: 1, ScheduledTime: "2026-10-18 10:00:00"}, testUserId)
+	var noSuchScreening *NoSuchScreening
+	if status || !errors.As(err, &noSuchScreening) {
+		t.Errorf("SetScreeningScheduledTimeById() = %v, %v, want false, NoSuchScreening", status, err)
+	}
+}
@@ -0,0 +1,91 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"log"
+	"strconv"
+)
+
+// hashSessionId Keeps session Ids out of the database, so a copy of it can't
+// be used to take over sessions
+func hashSessionId(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetSession Returns the stored data of a session, or nil if there is no such
+// session or it has expired
+func GetSession(id string) ([]byte, error) {
+	var data []byte
+	err := DB.QueryRow("SELECT Data FROM Sessions WHERE Id = ? AND ExpiryDateTime > CURRENT_TIMESTAMP", hashSessionId(id)).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println("ERROR: Cannot retrieve session from DB: " + string(err.Error()))
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// SaveSession Stores a session for maxAge seconds from now. userName is the
+// user the session belongs to, if any, so their sessions can be revoked.
+func SaveSession(id string, userName string, data []byte, maxAge int) error {
+	user := sql.NullString{String: userName, Valid: userName != ""}
+	_, err := DB.Exec(`INSERT INTO Sessions (Id, UserName, Data, ExpiryDateTime)
+		VALUES (?, ?, ?, datetime('now', ?))
+		ON CONFLICT (Id) DO UPDATE SET UserName = excluded.UserName, Data = excluded.Data, ExpiryDateTime = excluded.ExpiryDateTime`,
+		hashSessionId(id), user, data, "+"+strconv.Itoa(maxAge)+" seconds")
+	if err != nil {
+		log.Println("ERROR: Cannot store session: " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func DeleteSession(id string) error {
+	_, err := DB.Exec("DELETE FROM Sessions WHERE Id = ?", hashSessionId(id))
+	if err != nil {
+		log.Println("ERROR: Cannot delete session: " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// PruneExpiredSessions Deletes the sessions that can no longer be used
+func PruneExpiredSessions() error {
+	result, err := DB.Exec("DELETE FROM Sessions WHERE ExpiryDateTime <= CURRENT_TIMESTAMP")
+	if err != nil {
+		log.Println("ERROR: Cannot prune expired sessions: " + string(err.Error()))
+		return err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err == nil && numberOfRows > 0 {
+		log.Println("INFO: Pruned " + strconv.FormatInt(numberOfRows, 10) + " expired sessions")
+	}
+
+	return nil
+}
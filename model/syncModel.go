@@ -0,0 +1,286 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+)
+
+// entity types reported to sync clients
+const (
+	SyncBuilding  = "building"
+	SyncFloor     = "floor"
+	SyncLocation  = "location"
+	SyncPanel     = "panel"
+	SyncScreening = "screening"
+	SyncTag       = "tag"
+)
+
+// syncedTables Maps the tables kept in sync with clients to their entity type
+var syncedTables = map[string]string{
+	"Buildings":       SyncBuilding,
+	"BuildingFloors":  SyncFloor,
+	"Locations":       SyncLocation,
+	"Panels":          SyncPanel,
+	"VideoScreenings": SyncScreening,
+	"Tags":            SyncTag,
+}
+
+// recordSyncChange Moves a row to the end of the sync log, or leaves a
+// tombstone if it was deleted. Tables that are not synced are ignored. It
+// must be called inside the transaction making the change.
+func recordSyncChange(t *sql.Tx, table string, id int, deleted bool) error {
+	entityType, ok := syncedTables[table]
+	if !ok {
+		return nil
+	}
+
+	// replacing the entity's row gives it a new, higher Id
+	_, err := t.Exec("INSERT OR REPLACE INTO SyncLog (EntityType, EntityId, Deleted) VALUES (?, ?, ?)", entityType, id, deleted)
+	if err != nil {
+		log.Println("ERROR: Cannot record sync change of " + entityType + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// recordTaggedSyncChanges Records a change to every panel and screening
+// carrying a tag, as their tag names change with it
+func recordTaggedSyncChanges(t *sql.Tx, tagId int) error {
+	for _, a := range []tagAssignmentTable{panelTagAssignments, screeningTagAssignments} {
+		_, err := t.Exec(`INSERT OR REPLACE INTO SyncLog (EntityType, EntityId)
+			SELECT DISTINCT ?, `+a.EventIdColumn+` FROM `+a.AssignmentTable+` WHERE TagId = ?`, syncedTables[a.EventTable], tagId)
+		if err != nil {
+			log.Println("ERROR: Cannot record sync changes of " + a.EventDescription + "s tagged with tag Id '" + strconv.Itoa(tagId) + "': " + string(err.Error()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncWindow Selects the Ids of the entities of one type that changed within
+// the window of the sync log being sent
+const syncWindow = `SELECT EntityId FROM SyncLog
+	WHERE EntityType = ? AND Deleted = FALSE AND Id > ? AND Id <= ?`
+
+// unpublishedInWindow Selects the panels and screenings within the window of
+// the sync log being sent that attendees may not see, or no longer see
+const unpublishedInWindow = `(EntityType = '` + SyncPanel + `' AND EntityId NOT IN (SELECT p.Id FROM Panels p` + publishedPanelCondition + `))
+	OR (EntityType = '` + SyncScreening + `' AND EntityId NOT IN (SELECT Id FROM VideoScreenings` + publishedScreeningCondition + `))`
+
+// GetSyncChanges Collects the entities changed after the sync log entry with
+// Id since, covering at most limit entries. Deleted entities are only
+// reported when since is not 0, as a client starting afresh has nothing to
+// delete.
+func GetSyncChanges(since int, limit int) (SyncChangeSet, error) {
+	log.Println("INFO: Sync changes after '" + strconv.Itoa(since) + "' requested")
+	return getSyncChanges(since, limit, false)
+}
+
+// GetPublishedSyncChanges Collects the changes like GetSyncChanges, but only
+// sends the panels and screenings attendees may see. The ones that changed
+// and are not published (anymore) are reported as deleted.
+func GetPublishedSyncChanges(since int, limit int) (SyncChangeSet, error) {
+	log.Println("INFO: Published sync changes after '" + strconv.Itoa(since) + "' requested")
+	return getSyncChanges(since, limit, true)
+}
+
+func getSyncChanges(since int, limit int, publishedOnly bool) (SyncChangeSet, error) {
+	panelCondition := " WHERE p.Id IN (" + syncWindow + ")"
+	screeningCondition := " WHERE Id IN (" + syncWindow + ")"
+	tombstoneCondition := "Deleted = TRUE"
+	if publishedOnly {
+		panelCondition = publishedPanelCondition + " AND p.Id IN (" + syncWindow + ")"
+		screeningCondition = publishedScreeningCondition + " AND Id IN (" + syncWindow + ")"
+		tombstoneCondition = "(Deleted = TRUE OR " + unpublishedInWindow + ")"
+	}
+
+	changes := SyncChangeSet{
+		Buildings:     make([]Building, 0),
+		Floors:        make([]BuildingFloor, 0),
+		Locations:     make([]Location, 0),
+		Panels:        make([]PanelSQL, 0),
+		PanelTags:     make(map[int][]string),
+		Screenings:    make([]ScreeningSQL, 0),
+		ScreeningTags: make(map[int][]string),
+		Tags:          make([]Tag, 0),
+		Deleted:       make([]SyncTombstone, 0),
+		LastChangeId:  since,
+	}
+
+	// look one entry past the window to tell whether there is more to come
+	rows, err := DB.Query("SELECT Id FROM SyncLog WHERE Id > ? ORDER BY Id LIMIT ?", since, limit+1)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	count := 0
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot marshal the sync log!" + string(err.Error()))
+			return SyncChangeSet{}, err
+		}
+		count++
+		if count > limit {
+			changes.HasMore = true
+			break
+		}
+		changes.LastChangeId = id
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return SyncChangeSet{}, err
+	}
+	if changes.LastChangeId == since {
+		return changes, nil
+	}
+	upTo := changes.LastChangeId
+
+	rows, err = DB.Query(`SELECT Id, Name, City, Region, CreatorId, CreationDate FROM Buildings
+		WHERE Id IN (`+syncWindow+`) ORDER BY Id`, SyncBuilding, since, upTo)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	for rows.Next() {
+		building := Building{}
+		err = rows.Scan(&building.Id, &building.Name, &building.City, &building.Region, &building.CreatorId, &building.CreationDate)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot marshal the building objects!" + string(err.Error()))
+			return SyncChangeSet{}, err
+		}
+		changes.Buildings = append(changes.Buildings, building)
+	}
+	rows.Close()
+
+	rows, err = DB.Query(`SELECT Id, FloorName, BuildingId, CreatorId, CreationDate FROM BuildingFloors
+		WHERE Id IN (`+syncWindow+`) ORDER BY Id`, SyncFloor, since, upTo)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	for rows.Next() {
+		floor := BuildingFloor{}
+		err = rows.Scan(&floor.Id, &floor.FloorName, &floor.BuildingId, &floor.CreatorId, &floor.CreationDate)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot marshal the floor objects!" + string(err.Error()))
+			return SyncChangeSet{}, err
+		}
+		changes.Floors = append(changes.Floors, floor)
+	}
+	rows.Close()
+
+	rows, err = DB.Query(`SELECT Id, RoomName, FloorId, BuildingId, CreatorId, CreationDate FROM Locations
+		WHERE Id IN (`+syncWindow+`) ORDER BY Id`, SyncLocation, since, upTo)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	for rows.Next() {
+		location := Location{}
+		err = rows.Scan(&location.Id, &location.Location, &location.FloorId, &location.BuildingId, &location.CreatorId, &location.CreationDate)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot marshal the location objects!" + string(err.Error()))
+			return SyncChangeSet{}, err
+		}
+		changes.Locations = append(changes.Locations, location)
+	}
+	rows.Close()
+
+	rows, err = DB.Query(`SELECT Id, TagName FROM Tags
+		WHERE Id IN (`+syncWindow+`) ORDER BY Id`, SyncTag, since, upTo)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	for rows.Next() {
+		tag := Tag{}
+		err = rows.Scan(&tag.Id, &tag.TagName)
+		if err != nil {
+			rows.Close()
+			log.Println("ERROR: Cannot marshal the tag objects!" + string(err.Error()))
+			return SyncChangeSet{}, err
+		}
+		changes.Tags = append(changes.Tags, tag)
+	}
+	rows.Close()
+
+	rows, err = DB.Query(panelQuery+panelCondition+" ORDER BY p.Id", SyncPanel, since, upTo)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	changes.Panels, err = scanPanels(rows)
+	rows.Close()
+	if err != nil {
+		return SyncChangeSet{}, err
+	}
+	changes.PanelTags, err = getTagNamesByEvent(panelTagAssignments, syncWindow, SyncPanel, since, upTo)
+	if err != nil {
+		return SyncChangeSet{}, err
+	}
+
+	rows, err = DB.Query("SELECT "+screeningColumns+" FROM VideoScreenings"+screeningCondition+" ORDER BY Id", SyncScreening, since, upTo)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return SyncChangeSet{}, err
+	}
+	changes.Screenings, err = scanScreenings(rows)
+	rows.Close()
+	if err != nil {
+		return SyncChangeSet{}, err
+	}
+	changes.ScreeningTags, err = getTagNamesByEvent(screeningTagAssignments, syncWindow, SyncScreening, since, upTo)
+	if err != nil {
+		return SyncChangeSet{}, err
+	}
+
+	if since > 0 {
+		rows, err = DB.Query(`SELECT EntityType, EntityId, ChangeDateTime FROM SyncLog
+			WHERE `+tombstoneCondition+` AND Id > ? AND Id <= ? ORDER BY Id`, since, upTo)
+		if err != nil {
+			log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+			return SyncChangeSet{}, err
+		}
+		for rows.Next() {
+			tombstone := SyncTombstone{}
+			err = rows.Scan(&tombstone.EntityType, &tombstone.Id, &tombstone.DeletionDateTime)
+			if err != nil {
+				rows.Close()
+				log.Println("ERROR: Cannot marshal the sync tombstones!" + string(err.Error()))
+				return SyncChangeSet{}, err
+			}
+			changes.Deleted = append(changes.Deleted, tombstone)
+		}
+		rows.Close()
+	}
+
+	log.Println("INFO: Sync changes up to '" + strconv.Itoa(upTo) + "' retrieved")
+	return changes, nil
+}
@@ -0,0 +1,568 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// tagAssignmentTable Describes where the tag assignments for an event kind live
+type tagAssignmentTable struct {
+	EventTable       string
+	AssignmentTable  string
+	EventIdColumn    string
+	EventDescription string
+}
+
+var (
+	panelTagAssignments = tagAssignmentTable{
+		EventTable:       "Panels",
+		AssignmentTable:  "PanelTagAssignments",
+		EventIdColumn:    "PanelId",
+		EventDescription: "panel",
+	}
+	screeningTagAssignments = tagAssignmentTable{
+		EventTable:       "VideoScreenings",
+		AssignmentTable:  "VideoScreeningTagAssignments",
+		EventIdColumn:    "VideoScreeningId",
+		EventDescription: "screening",
+	}
+	liveEventTagAssignments = tagAssignmentTable{
+		EventTable:       "LiveEvents",
+		AssignmentTable:  "LiveEventTagAssignments",
+		EventIdColumn:    "LiveEventId",
+		EventDescription: "live event",
+	}
+)
+
+func CreateTag(p ProposedTag, userId int) (bool, error) {
+	log.Println("INFO: Creating a tag: " + p.TagName)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	q, err := t.Prepare("INSERT INTO Tags (TagName) VALUES (?)")
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.TagName))
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Tags", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Tag entry created")
+	return true, nil
+}
+
+func DeleteTagById(id int, userId int) (bool, error) {
+	log.Println("INFO: Tag deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Tags", id)
+	if err != nil {
+		return false, err
+	}
+
+	// the tagged panels and screenings lose the tag too
+	err = recordTaggedSyncChanges(t, id)
+	if err != nil {
+		return false, err
+	}
+
+	// drop any assignments first, otherwise the foreign keys will refuse the delete
+	for _, assignments := range []tagAssignmentTable{panelTagAssignments, screeningTagAssignments, liveEventTagAssignments} {
+		err = auditDeleteWhere(t, userId, assignments.AssignmentTable, "TagId = ?", id)
+		if err != nil {
+			return false, err
+		}
+		_, err = t.Exec("DELETE FROM "+assignments.AssignmentTable+" WHERE TagId = ?", id)
+		if err != nil {
+			log.Println("ERROR: Cannot remove " + assignments.EventDescription + " assignments for tag Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	q, err := t.Prepare("DELETE FROM Tags WHERE Id IS ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete tag with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	err = auditDelete(t, userId, "Tags", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Tag with id '" + strconv.Itoa(id) + "' has been deleted")
+	return numberOfRows > 0, nil
+}
+
+func GetTags() ([]Tag, error) {
+	log.Println("INFO: List of tag objects requested")
+	rows, err := DB.Query("SELECT Id, TagName FROM Tags ORDER BY TagName")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]Tag, 0)
+	for rows.Next() {
+		tag := Tag{}
+		err = rows.Scan(
+			&tag.Id,
+			&tag.TagName,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the tag objects!" + string(err.Error()))
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	log.Println("INFO: List of all tags retrieved")
+	return tags, nil
+}
+
+func GetTagById(id int) (Tag, error) {
+	log.Println("INFO: Tag by Id requested: " + strconv.Itoa(id))
+	tag := Tag{}
+	err := DB.QueryRow("SELECT Id, TagName FROM Tags WHERE Id = ?", id).Scan(
+		&tag.Id,
+		&tag.TagName,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such tag found in DB: " + string(err.Error()))
+			return Tag{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve tag from DB: " + string(err.Error()))
+		return Tag{}, err
+	}
+
+	log.Println("INFO: Tag by Id '" + strconv.Itoa(id) + "' retrieved")
+	return tag, nil
+}
+
+func UpdateTagById(id int, p ProposedTag, userId int) (bool, error) {
+	log.Println("INFO: Tag update requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Tags", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE Tags SET TagName = ? WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.TagName), id)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	err = auditUpdate(t, userId, "Tags", id, before)
+	if err != nil {
+		return false, err
+	}
+	if numberOfRows > 0 {
+		// the tag name is part of every panel and screening carrying it
+		err = recordTaggedSyncChanges(t, id)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Tag entry updated")
+	return numberOfRows > 0, nil
+}
+
+func getTagsByEventId(a tagAssignmentTable, id int) ([]Tag, error) {
+	log.Println("INFO: Tags by " + a.EventDescription + " Id requested: " + strconv.Itoa(id))
+	rows, err := DB.Query(`SELECT DISTINCT t.Id, t.TagName FROM Tags t
+		INNER JOIN `+a.AssignmentTable+` a ON a.TagId = t.Id
+		WHERE a.`+a.EventIdColumn+` = ? ORDER BY t.TagName`, id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]Tag, 0)
+	for rows.Next() {
+		tag := Tag{}
+		err = rows.Scan(
+			&tag.Id,
+			&tag.TagName,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the tag objects!" + string(err.Error()))
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	log.Println("INFO: Tags for " + a.EventDescription + " Id '" + strconv.Itoa(id) + "' retrieved")
+	return tags, nil
+}
+
+func assignTag(a tagAssignmentTable, id int, j TagAssignment, userId int) (bool, error) {
+	log.Println("INFO: Assign tag Id '" + strconv.Itoa(j.TagId) + "' to " + a.EventDescription + " Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM Tags WHERE Id = ?", j.TagId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for tag: " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		err = &NoSuchTag{Err: errors.New("invalid tag Id: " + strconv.Itoa(j.TagId))}
+		return false, err
+	}
+
+	err = t.QueryRow("SELECT COUNT(*) FROM "+a.EventTable+" WHERE Id = ?", id).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for " + a.EventDescription + ": " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		log.Println("WARN: No such " + a.EventDescription + " Id '" + strconv.Itoa(id) + "'")
+		t.Rollback()
+		return false, nil
+	}
+
+	// assigning a tag twice is a no-op rather than a duplicate row
+	err = t.QueryRow("SELECT COUNT(*) FROM "+a.AssignmentTable+" WHERE TagId = ? AND "+a.EventIdColumn+" = ?", j.TagId, id).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot check for existing tag assignment: " + string(err.Error()))
+		return false, err
+	}
+	if count == 0 {
+		var result sql.Result
+		result, err = t.Exec("INSERT INTO "+a.AssignmentTable+" (TagId, "+a.EventIdColumn+") VALUES (?, ?)", j.TagId, id)
+		if err != nil {
+			log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+			return false, err
+		}
+		err = auditInsert(t, userId, a.AssignmentTable, result)
+		if err != nil {
+			return false, err
+		}
+		err = recordSyncChange(t, a.EventTable, id, false)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Tag assigned")
+	return true, nil
+}
+
+func unassignTag(a tagAssignmentTable, id int, j TagAssignment, userId int) (bool, error) {
+	log.Println("INFO: Unassign tag Id '" + strconv.Itoa(j.TagId) + "' from " + a.EventDescription + " Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	err = auditDeleteWhere(t, userId, a.AssignmentTable, "TagId = ? AND "+a.EventIdColumn+" = ?", j.TagId, id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("DELETE FROM "+a.AssignmentTable+" WHERE TagId = ? AND "+a.EventIdColumn+" = ?", j.TagId, id)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB query: " + string(err.Error()))
+		return false, err
+	}
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows > 0 {
+		err = recordSyncChange(t, a.EventTable, id, false)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: SQL result: Rows: " + strconv.Itoa(int(numberOfRows)))
+	return numberOfRows > 0, nil
+}
+
+// getEventIdsByTagNames Returns the set of event Ids that carry every one of the given tags
+func getEventIdsByTagNames(a tagAssignmentTable, tagNames []string) (map[int]bool, error) {
+	log.Println("INFO: " + a.EventDescription + " Ids by tag names requested: " + strings.Join(tagNames, ", "))
+	placeholders := make([]string, 0)
+	args := make([]interface{}, 0)
+	for _, tagName := range tagNames {
+		placeholders = append(placeholders, "?")
+		args = append(args, tagName)
+	}
+	args = append(args, len(tagNames))
+
+	rows, err := DB.Query(`SELECT a.`+a.EventIdColumn+` FROM `+a.AssignmentTable+` a
+		INNER JOIN Tags t ON t.Id = a.TagId
+		WHERE t.TagName IN (`+strings.Join(placeholders, ", ")+`)
+		GROUP BY a.`+a.EventIdColumn+`
+		HAVING COUNT(DISTINCT t.Id) = ?`, args...)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the " + a.EventDescription + " Ids!" + string(err.Error()))
+			return nil, err
+		}
+		ids[id] = true
+	}
+
+	return ids, nil
+}
+
+// getTagNamesByEvent Maps the tagged events of a kind to their tag names.
+// eventIds is a query selecting the Ids of the events to look up, run with
+// args.
+func getTagNamesByEvent(a tagAssignmentTable, eventIds string, args ...interface{}) (map[int][]string, error) {
+	rows, err := DB.Query(`SELECT DISTINCT a.`+a.EventIdColumn+`, t.TagName FROM `+a.AssignmentTable+` a
+		INNER JOIN Tags t ON t.Id = a.TagId
+		WHERE a.`+a.EventIdColumn+` IN (`+eventIds+`)
+		ORDER BY t.TagName`, args...)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tagNames := make(map[int][]string)
+	for rows.Next() {
+		var id int
+		var tagName string
+		err = rows.Scan(&id, &tagName)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the " + a.EventDescription + " tags!" + string(err.Error()))
+			return nil, err
+		}
+		tagNames[id] = append(tagNames[id], tagName)
+	}
+
+	return tagNames, rows.Err()
+}
+
+func GetTagsByPanelId(id int) ([]Tag, error) {
+	return getTagsByEventId(panelTagAssignments, id)
+}
+
+func AssignTagToPanel(id int, j TagAssignment, userId int) (bool, error) {
+	return assignTag(panelTagAssignments, id, j, userId)
+}
+
+func UnassignTagFromPanel(id int, j TagAssignment, userId int) (bool, error) {
+	return unassignTag(panelTagAssignments, id, j, userId)
+}
+
+func GetPanelIdsByTagNames(tagNames []string) (map[int]bool, error) {
+	return getEventIdsByTagNames(panelTagAssignments, tagNames)
+}
+
+// GetPanelTagNamesById Maps a panel to its tag names
+func GetPanelTagNamesById(id int) (map[int][]string, error) {
+	return getTagNamesByEvent(panelTagAssignments, "?", id)
+}
+
+// GetPublishedPanelTagNames Maps the published panels to their tag names
+func GetPublishedPanelTagNames() (map[int][]string, error) {
+	return getTagNamesByEvent(panelTagAssignments, "SELECT p.Id FROM Panels p"+publishedPanelCondition)
+}
+
+func GetTagsByScreeningId(id int) ([]Tag, error) {
+	return getTagsByEventId(screeningTagAssignments, id)
+}
+
+func AssignTagToScreening(id int, j TagAssignment, userId int) (bool, error) {
+	return assignTag(screeningTagAssignments, id, j, userId)
+}
+
+func UnassignTagFromScreening(id int, j TagAssignment, userId int) (bool, error) {
+	return unassignTag(screeningTagAssignments, id, j, userId)
+}
+
+func GetScreeningIdsByTagNames(tagNames []string) (map[int]bool, error) {
+	return getEventIdsByTagNames(screeningTagAssignments, tagNames)
+}
+
+// GetScreeningTagNamesById Maps a screening to its tag names
+func GetScreeningTagNamesById(id int) (map[int][]string, error) {
+	return getTagNamesByEvent(screeningTagAssignments, "?", id)
+}
+
+// GetPublishedScreeningTagNames Maps the published screenings to their tag
+// names
+func GetPublishedScreeningTagNames() (map[int][]string, error) {
+	return getTagNamesByEvent(screeningTagAssignments, "SELECT Id FROM VideoScreenings"+publishedScreeningCondition)
+}
+
+func GetTagsByLiveEventId(id int) ([]Tag, error) {
+	return getTagsByEventId(liveEventTagAssignments, id)
+}
+
+func AssignTagToLiveEvent(id int, j TagAssignment, userId int) (bool, error) {
+	return assignTag(liveEventTagAssignments, id, j, userId)
+}
+
+func UnassignTagFromLiveEvent(id int, j TagAssignment, userId int) (bool, error) {
+	return unassignTag(liveEventTagAssignments, id, j, userId)
+}
+
+func GetLiveEventIdsByTagNames(tagNames []string) (map[int]bool, error) {
+	return getEventIdsByTagNames(liveEventTagAssignments, tagNames)
+}
@@ -0,0 +1,54 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"testing"
+)
+
+func TestTagChangesAreAudited(t *testing.T) {
+	tagId := newTestTag(t, "audited")
+	wantAudited(t, "Tags", AuditCreate, tagId)
+
+	_, err := UpdateTagById(tagId, ProposedTag{TagName: "audited-renamed"}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "Tags", AuditUpdate, tagId)
+
+	panelId := newTestPanel(t, "Audited tagging")
+	_, err = AssignTagToPanel(panelId, TagAssignment{TagId: tagId}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignmentId := lastId(t, "PanelTagAssignments")
+	wantAudited(t, "PanelTagAssignments", AuditCreate, assignmentId)
+
+	_, err = UnassignTagFromPanel(panelId, TagAssignment{TagId: tagId}, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "PanelTagAssignments", AuditDelete, assignmentId)
+
+	_, err = DeleteTagById(tagId, testUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAudited(t, "Tags", AuditDelete, tagId)
+}
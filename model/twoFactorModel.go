@@ -0,0 +1,458 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RFC 6238 parameters, which are also the defaults of authenticator apps
+const (
+	// TotpIssuer Names the service in authenticator apps
+	TotpIssuer       = "Giron"
+	totpPeriod       = 30
+	totpDigits       = 6
+	totpSecretLength = 20
+	// totpSkew How many time steps either side of now a code is accepted
+	// for, to allow for clocks that drift
+	totpSkew = 1
+	// recoveryCodeCount How many recovery codes a user is given at a time
+	recoveryCodeCount = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// totpCode Computes the code of a secret for a time step
+func totpCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// matchTotpStep Returns the time step near now that a code was generated
+// for, or 0 if it matches none
+func matchTotpStep(encodedSecret string, code string, now time.Time) (int64, error) {
+	secret, err := totpEncoding.DecodeString(encodedSecret)
+	if err != nil {
+		log.Println("ERROR: Cannot decode TOTP secret: " + string(err.Error()))
+		return 0, err
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step)), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+	return 0, nil
+}
+
+// TotpUri Returns the otpauth URI authenticator apps are set up with
+func TotpUri(userName string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", TotpIssuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", strconv.Itoa(totpDigits))
+	params.Set("period", strconv.Itoa(totpPeriod))
+	return "otpauth://totp/" + url.PathEscape(TotpIssuer+":"+userName) + "?" + params.Encode()
+}
+
+// normalizeRecoveryCode Lets recovery codes be typed in any case, with or
+// without the dash
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRecoveryCodes Replaces a user's recovery codes and returns the new ones,
+// formatted as xxxxx-xxxxx
+func newRecoveryCodes(t *sql.Tx, userId int) ([]string, error) {
+	_, err := t.Exec("DELETE FROM RecoveryCodes WHERE UserId = ?", userId)
+	if err != nil {
+		log.Println("ERROR: Cannot clear recovery codes of user Id '" + strconv.Itoa(userId) + "': " + string(err.Error()))
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 7)
+		_, err = rand.Read(raw)
+		if err != nil {
+			log.Println("ERROR: Cannot generate recovery code: " + string(err.Error()))
+			return nil, err
+		}
+		encoded := strings.ToLower(totpEncoding.EncodeToString(raw))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+
+		_, err = t.Exec("INSERT INTO RecoveryCodes (UserId, CodeHash) VALUES (?, ?)", userId, hashRecoveryCode(code))
+		if err != nil {
+			log.Println("ERROR: Cannot store recovery code: " + string(err.Error()))
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+func GetTwoFactorStatus(userId int) (TwoFactorStatus, error) {
+	status := TwoFactorStatus{}
+	var confirmed sql.NullBool
+	err := DB.QueryRow(`SELECT
+			(SELECT Confirmed FROM UserTwoFactor WHERE UserId = ?),
+			EXISTS (SELECT 1 FROM UserRoleAssignments a
+				INNER JOIN Roles r ON r.Id = a.RoleId
+				WHERE a.UserId = ? AND r.RequireTwoFactor = TRUE),
+			(SELECT COUNT(*) FROM RecoveryCodes WHERE UserId = ? AND UsedDateTime IS NULL)`,
+		userId, userId, userId).Scan(&confirmed, &status.Required, &status.RecoveryCodesLeft)
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve two-factor status of user Id '" + strconv.Itoa(userId) + "': " + string(err.Error()))
+		return TwoFactorStatus{}, err
+	}
+	status.Enabled = confirmed.Valid && confirmed.Bool
+	status.Pending = confirmed.Valid && !confirmed.Bool
+
+	return status, nil
+}
+
+// BeginTwoFactorEnrollment Generates a TOTP secret for a user who has none
+// yet. A pending enrollment keeps its secret, so reloading the page doesn't
+// invalidate an app that was already set up.
+func BeginTwoFactorEnrollment(userId int, userName string) (TwoFactorEnrollment, error) {
+	log.Println("INFO: Two-factor enrollment requested for user '" + userName + "'")
+	var secret string
+	var confirmed bool
+	err := DB.QueryRow("SELECT Secret, Confirmed FROM UserTwoFactor WHERE UserId = ?", userId).Scan(&secret, &confirmed)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("ERROR: Cannot retrieve two-factor secret: " + string(err.Error()))
+		return TwoFactorEnrollment{}, err
+	}
+	if confirmed {
+		return TwoFactorEnrollment{}, &TwoFactorState{Err: errors.New("already enabled")}
+	}
+
+	if err == sql.ErrNoRows {
+		raw := make([]byte, totpSecretLength)
+		_, err = rand.Read(raw)
+		if err != nil {
+			log.Println("ERROR: Cannot generate TOTP secret: " + string(err.Error()))
+			return TwoFactorEnrollment{}, err
+		}
+		secret = totpEncoding.EncodeToString(raw)
+
+		_, err = DB.Exec("INSERT INTO UserTwoFactor (UserId, Secret) VALUES (?, ?)", userId, secret)
+		if err != nil {
+			log.Println("ERROR: Cannot store TOTP secret: " + string(err.Error()))
+			return TwoFactorEnrollment{}, err
+		}
+	}
+
+	return TwoFactorEnrollment{Secret: secret, Uri: TotpUri(userName, secret)}, nil
+}
+
+// ConfirmTwoFactorEnrollment Enables two-factor authentication once the user
+// proves their app is set up, and returns their recovery codes
+func ConfirmTwoFactorEnrollment(userId int, code string) ([]string, error) {
+	log.Println("INFO: Two-factor enrollment confirmation for user Id '" + strconv.Itoa(userId) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var id int
+	var secret string
+	var confirmed bool
+	err = t.QueryRow("SELECT Id, Secret, Confirmed FROM UserTwoFactor WHERE UserId = ?", userId).Scan(&id, &secret, &confirmed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = &TwoFactorState{Err: errors.New("enrollment has not been started")}
+			return nil, err
+		}
+		log.Println("ERROR: Cannot retrieve two-factor secret: " + string(err.Error()))
+		return nil, err
+	}
+	if confirmed {
+		err = &TwoFactorState{Err: errors.New("already enabled")}
+		return nil, err
+	}
+
+	step, err := matchTotpStep(secret, strings.TrimSpace(code), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if step == 0 {
+		err = &InvalidTwoFactorCode{Err: errors.New("code does not match, check the clock of the device")}
+		return nil, err
+	}
+
+	before, err := auditSnapshot(t, "UserTwoFactor", id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = t.Exec("UPDATE UserTwoFactor SET Confirmed = TRUE, LastUsedStep = ? WHERE Id = ?", step, id)
+	if err != nil {
+		log.Println("ERROR: Cannot enable two-factor authentication: " + string(err.Error()))
+		return nil, err
+	}
+
+	codes, err := newRecoveryCodes(t, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = auditUpdate(t, userId, "UserTwoFactor", id, before)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return nil, err
+	}
+
+	log.Println("INFO: Two-factor authentication enabled for user Id '" + strconv.Itoa(userId) + "'")
+	return codes, nil
+}
+
+// VerifyTwoFactorCode Checks a TOTP code, or uses up a recovery code, of a
+// user with two-factor authentication enabled. A TOTP code is only accepted
+// once.
+func VerifyTwoFactorCode(userId int, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var id int
+	var secret string
+	var lastUsedStep int64
+	err = t.QueryRow("SELECT Id, Secret, LastUsedStep FROM UserTwoFactor WHERE UserId = ? AND Confirmed = TRUE", userId).Scan(&id, &secret, &lastUsedStep)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = t.Rollback()
+			return false, err
+		}
+		log.Println("ERROR: Cannot retrieve two-factor secret: " + string(err.Error()))
+		return false, err
+	}
+
+	var result sql.Result
+	if len(code) == totpDigits {
+		step, merr := matchTotpStep(secret, code, time.Now())
+		if merr != nil {
+			err = merr
+			return false, err
+		}
+		if step <= lastUsedStep {
+			log.Println("WARN: Rejected a stale or reused TOTP code for user Id '" + strconv.Itoa(userId) + "'")
+			err = t.Rollback()
+			return false, err
+		}
+		result, err = t.Exec("UPDATE UserTwoFactor SET LastUsedStep = ? WHERE Id = ?", step, id)
+	} else {
+		result, err = t.Exec("UPDATE RecoveryCodes SET UsedDateTime = CURRENT_TIMESTAMP WHERE UserId = ? AND CodeHash = ? AND UsedDateTime IS NULL",
+			userId, hashRecoveryCode(code))
+	}
+	if err != nil {
+		log.Println("ERROR: Cannot record use of two-factor code: " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	if len(code) != totpDigits {
+		log.Println("WARN: Recovery code used by user Id '" + strconv.Itoa(userId) + "'")
+	}
+	return true, nil
+}
+
+// RegenerateRecoveryCodes Replaces the recovery codes of a user with
+// two-factor authentication enabled
+func RegenerateRecoveryCodes(userId int) ([]string, error) {
+	log.Println("INFO: New recovery codes requested for user Id '" + strconv.Itoa(userId) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var count int
+	err = t.QueryRow("SELECT COUNT(*) FROM UserTwoFactor WHERE UserId = ? AND Confirmed = TRUE", userId).Scan(&count)
+	if err != nil {
+		log.Println("ERROR: Cannot retrieve two-factor status: " + string(err.Error()))
+		return nil, err
+	}
+	if count == 0 {
+		err = &TwoFactorState{Err: errors.New("not enabled")}
+		return nil, err
+	}
+
+	codes, err := newRecoveryCodes(t, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return nil, err
+	}
+
+	log.Println("INFO: Recovery codes replaced for user Id '" + strconv.Itoa(userId) + "'")
+	return codes, nil
+}
+
+// DisableTwoFactor Removes the TOTP secret and recovery codes of a user,
+// whether enrollment was finished or not
+func DisableTwoFactor(id int, userId int) (bool, error) {
+	log.Println("INFO: Two-factor authentication removal requested for user Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var rowId int
+	err = t.QueryRow("SELECT Id FROM UserTwoFactor WHERE UserId = ?", id).Scan(&rowId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: User Id '" + strconv.Itoa(id) + "' has no two-factor authentication")
+			err = t.Rollback()
+			return false, err
+		}
+		log.Println("ERROR: Cannot retrieve two-factor secret: " + string(err.Error()))
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, "UserTwoFactor", rowId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, stmt := range []string{
+		"DELETE FROM RecoveryCodes WHERE UserId = ?",
+		"DELETE FROM UserTwoFactor WHERE UserId = ?",
+	} {
+		_, err = t.Exec(stmt, id)
+		if err != nil {
+			log.Println("ERROR: Cannot remove two-factor records of user Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	err = auditDelete(t, userId, "UserTwoFactor", rowId, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Two-factor authentication removed for user Id '" + strconv.Itoa(id) + "'")
+	return true, nil
+}
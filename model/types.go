@@ -20,10 +20,86 @@ package model
 
 import (
 	"database/sql"
+	"time"
 )
 
 // primary object structs
 
+// ApiToken A named token a user authenticates scripts and kiosks with. Empty
+// scopes mean the token may use every privilege of its user. The token itself
+// is only returned once, when it is created.
+type ApiToken struct {
+	Id               int      `json:"Id"`
+	UserId           int      `json:"userId"`
+	Name             string   `json:"name"`
+	Scopes           []string `json:"scopes"`
+	ExpiryDateTime   string   `json:"expiryDateTime"`
+	LastUsedDateTime string   `json:"lastUsedDateTime"`
+	CreationDateTime string   `json:"creationDateTime"`
+}
+
+// NewApiToken A token that has just been created, with the value to send as
+// "Authorization: Bearer <token>"
+type NewApiToken struct {
+	ApiToken
+	Token string `json:"token"`
+}
+
+type AuditEntry struct {
+	Id           int                    `json:"Id"`
+	ChangedById  int                    `json:"changedById"`
+	TableChanged string                 `json:"tableChanged"`
+	ChangeClass  string                 `json:"changeClass"`
+	RecordId     int                    `json:"recordId"`
+	Before       map[string]interface{} `json:"before"`
+	After        map[string]interface{} `json:"after"`
+	ChangeDate   string                 `json:"changeDate"`
+}
+
+type AuditFilter struct {
+	TableChanged string
+	ChangedById  int
+	ChangeClass  string
+	RecordId     int
+	Since        string
+	Until        string
+	Limit        int
+}
+
+type Artist struct {
+	Id               int    `json:"Id"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	MailingAddress   string `json:"mailingAddress"`
+	EmailAddress     string `json:"emailAddress"`
+	PhoneNumber      string `json:"phoneNumber"`
+	BoothId          int    `json:"boothId"`
+	Invoiced         bool   `json:"invoiced"`
+	InvoiceFulfilled bool   `json:"invoiceFulfilled"`
+	CreatorId        int    `json:"creatorId"`
+	CreationDate     string `json:"creationDate"`
+	ApprovalState    bool   `json:"approvalState"`
+	ApprovedById     int    `json:"approvedById"`
+	ApprovalDateTime string `json:"approvalDateTime"`
+}
+
+// Booth A booth in the vendor hall, with the vendor or artist holding it, if any
+type Booth struct {
+	Id           int    `json:"Id"`
+	Label        string `json:"label"`
+	LocationId   int    `json:"locationId"`
+	RoomName     string `json:"roomName"`
+	HolderType   string `json:"holderType"`
+	HolderId     int    `json:"holderId"`
+	HolderName   string `json:"holderName"`
+	CreatorId    int    `json:"creatorId"`
+	CreationDate string `json:"creationDate"`
+}
+
+type BoothAssignment struct {
+	BoothId int `json:"boothId"`
+}
+
 type Building struct {
 	Id           int    `json:"Id"`
 	Name         string `json:"name"`
@@ -55,6 +131,30 @@ type BuildingUpdate struct {
 	Region string `json:"region"`
 }
 
+// CalendarEvent A scheduled event as published in the iCalendar feeds
+type CalendarEvent struct {
+	EventType         string
+	Id                int
+	Title             string
+	Description       string
+	LocationId        int
+	RoomName          string
+	FloorName         string
+	BuildingName      string
+	ScheduledTime     string
+	DurationInMinutes int
+	Sequence          int
+	Tags              []string
+	LastChanged       time.Time
+}
+
+// CalendarFilter Narrows the iCalendar feeds down. Zero values match everything.
+type CalendarFilter struct {
+	LocationId int
+	BuildingId int
+	TagNames   []string
+}
+
 type FloorUpdate struct {
 	FloorName  string `json:"name"`
 	BuildingId int    `json:"buildingId"`
@@ -74,20 +174,135 @@ type Location struct {
 	CreationDate string `json:"creationDateTime"`
 }
 
+type Exhibitor struct {
+	Id               int    `json:"Id"`
+	Name             string `json:"name"`
+	MailingAddress   string `json:"mailingAddress"`
+	PhoneNumber      string `json:"phoneNumber"`
+	EmailAddress     string `json:"emailAddress"`
+	EventId          int    `json:"eventId"`
+	Invoiced         bool   `json:"invoiced"`
+	InvoiceFulfilled bool   `json:"invoiceFulfilled"`
+	CreatorId        int    `json:"creatorId"`
+	CreationDate     string `json:"creationDate"`
+	ApprovalState    bool   `json:"approvalState"`
+	ApprovedById     int    `json:"approvedById"`
+	ApprovalDateTime string `json:"approvalDateTime"`
+}
+
+// EventRating The aggregate rating of an event
+type EventRating struct {
+	EventId     int     `json:"eventId"`
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"ratingCount"`
+}
+
+type LiveEvent struct {
+	Id                int             `json:"Id"`
+	Topic             string          `json:"topic"`
+	Description       string          `json:"description"`
+	LocationId        int             `json:"locationId"`
+	Location          *LocationDetail `json:"location"`
+	ScheduledTime     string          `json:"scheduledTime"`
+	DurationInMinutes int             `json:"durationInMinutes"`
+	Rating            float64         `json:"rating"`
+	AgeRestricted     bool            `json:"ageRestricted"`
+	CreatorId         int             `json:"creatorId"`
+	CreationDateTime  string          `json:"creationDateTime"`
+	ApprovalStatus    bool            `json:"approvalStatus"`
+	ApprovedById      int             `json:"approvedById"`
+	ApprovalDateTime  string          `json:"approvalDateTime"`
+}
+
+type LiveEventSQL struct {
+	Id                int             `json:"Id"`
+	Topic             string          `json:"topic"`
+	Description       string          `json:"description"`
+	LocationId        sql.NullInt64   `json:"locationId"`
+	RoomName          sql.NullString  `json:"roomName"`
+	FloorId           sql.NullInt64   `json:"floorId"`
+	FloorName         sql.NullString  `json:"floorName"`
+	BuildingId        sql.NullInt64   `json:"buildingId"`
+	BuildingName      sql.NullString  `json:"buildingName"`
+	ScheduledTime     sql.NullString  `json:"scheduledTime"`
+	DurationInMinutes int             `json:"durationInMinutes"`
+	Rating            sql.NullFloat64 `json:"rating"`
+	AgeRestricted     bool            `json:"ageRestricted"`
+	CreatorId         int             `json:"creatorId"`
+	CreationDateTime  string          `json:"creationDateTime"`
+	ApprovalStatus    bool            `json:"approvalStatus"`
+	ApprovedById      sql.NullInt64   `json:"approvedById"`
+	ApprovalDateTime  sql.NullString  `json:"approvalDateTime"`
+}
+
+type LiveEventAgeRestrictionState struct {
+	RestrictionState bool `json:"restrictionState"`
+}
+
+type LiveEventApproval struct {
+	State bool `json:"state"`
+}
+
+type LiveEventLocation struct {
+	LocationId int `json:"locationId"`
+}
+
+type LiveEventScheduledTime struct {
+	LocationId        int    `json:"locationId"`
+	ScheduledTime     string `json:"scheduledTime"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+}
+
+type LiveEventUpdate struct {
+	Topic       string `json:"topic"`
+	Description string `json:"description"`
+}
+
+// LocationDetail A location together with the floor and building it is in,
+// as nested into the events held there
+type LocationDetail struct {
+	Id           int    `json:"Id"`
+	RoomName     string `json:"roomName"`
+	FloorId      int    `json:"floorId"`
+	FloorName    string `json:"floorName"`
+	BuildingId   int    `json:"buildingId"`
+	BuildingName string `json:"buildingName"`
+}
+
+type MigrationStatus struct {
+	Version     int    `json:"version"`
+	Name        string `json:"name"`
+	Applied     bool   `json:"applied"`
+	AppliedDate string `json:"appliedDate"`
+}
+
 type Panel struct {
-	Id                  int    `json:"Id"`
-	Topic               string `json:"topic"`
-	Description         string `json:"description"`
-	PanelRequestorEmail string `json:"panelRequestorEmail"`
-	Location            string `json:"location"`
-	ScheduledTime       string `json:"scheduledTime"`
-	DurationInMinutes   int    `json:"durationInMinutes"`
-	AgeRestricted       bool   `json:"ageRestricted"`
-	CreatorId           int    `json:"creatorId"`
-	CreationDateTime    string `json:"creationDateTime"`
-	ApprovalStatus      bool   `json:"approvalStatus"`
-	ApprovedById        int    `json:"approvedById"`
-	ApprovalDateTime    string `json:"approvalDateTime"`
+	Id                  int             `json:"Id"`
+	Topic               string          `json:"topic"`
+	Description         string          `json:"description"`
+	PanelRequestorEmail string          `json:"panelRequestorEmail"`
+	LocationId          int             `json:"locationId"`
+	Location            *LocationDetail `json:"location"`
+	ScheduledTime       string          `json:"scheduledTime"`
+	DurationInMinutes   int             `json:"durationInMinutes"`
+	Rating              float64         `json:"rating"`
+	AgeRestricted       bool            `json:"ageRestricted"`
+	CreatorId           int             `json:"creatorId"`
+	CreationDateTime    string          `json:"creationDateTime"`
+	ApprovalStatus      bool            `json:"approvalStatus"`
+	ApprovedById        int             `json:"approvedById"`
+	ApprovalDateTime    string          `json:"approvalDateTime"`
+	State               string          `json:"state"`
+}
+
+type Panelist struct {
+	Id           int    `json:"Id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	PhoneNumber  string `json:"phoneNumber"`
+	PanelId      int    `json:"panelId"`
+	CreatorId    int    `json:"creatorId"`
+	CreationDate string `json:"creationDate"`
 }
 
 type PanelSQL struct {
@@ -95,15 +310,22 @@ type PanelSQL struct {
 	Topic               string         `json:"topic"`
 	Description         string         `json:"description"`
 	PanelRequestorEmail string         `json:"panelRequestorEmail"`
-	Location            string         `json:"location"`
+	LocationId          sql.NullInt64  `json:"locationId"`
+	RoomName            sql.NullString `json:"roomName"`
+	FloorId             sql.NullInt64  `json:"floorId"`
+	FloorName           sql.NullString `json:"floorName"`
+	BuildingId          sql.NullInt64  `json:"buildingId"`
+	BuildingName        sql.NullString `json:"buildingName"`
 	ScheduledTime       sql.NullString `json:"scheduledTime"`
 	DurationInMinutes   int            `json:"durationInMinutes"`
+	Rating              float64        `json:"rating"`
 	AgeRestricted       bool           `json:"ageRestricted"`
 	CreatorId           int            `json:"creatorId"`
 	CreationDateTime    string         `json:"creationDateTime"`
 	ApprovalStatus      bool           `json:"approvalStatus"`
 	ApprovedById        sql.NullInt64  `json:"approvedById"`
 	ApprovalDateTime    sql.NullString `json:"approvalDateTime"`
+	State               string         `json:"state"`
 }
 
 type PanelAgeRestrictionState struct {
@@ -114,17 +336,268 @@ type PanelApproval struct {
 	State bool `json:"state"`
 }
 
+// OutboxMessage An email waiting in the outbox, with the values for its
+// template
+type OutboxMessage struct {
+	Id        int               `json:"Id"`
+	Recipient string            `json:"recipient"`
+	Template  string            `json:"template"`
+	Data      map[string]string `json:"data"`
+	Attempts  int               `json:"attempts"`
+}
+
+// DueWebhookDelivery A queued delivery with what is needed to post and sign it
+type DueWebhookDelivery struct {
+	Id        int
+	EventType string
+	Payload   string
+	Attempts  int
+	Url       string
+	Secret    string
+}
+
+// PanelProposal The contact details of whoever proposed a panel through the
+// public form
+type PanelProposal struct {
+	Id               int    `json:"Id"`
+	PanelId          int    `json:"panelId"`
+	Name             string `json:"name"`
+	EmailAddress     string `json:"emailAddress"`
+	PhoneNumber      string `json:"phoneNumber"`
+	CreationDateTime string `json:"creationDateTime"`
+	VerifiedDateTime string `json:"verifiedDateTime"`
+}
+
+// PanelProposalSubmission A panel proposed by the public. Website is a
+// honeypot: it is hidden on the form, so only bots fill it in.
+type PanelProposalSubmission struct {
+	Topic        string `json:"topic" form:"topic"`
+	Description  string `json:"description" form:"description"`
+	Name         string `json:"name" form:"name"`
+	EmailAddress string `json:"emailAddress" form:"emailAddress"`
+	PhoneNumber  string `json:"phoneNumber" form:"phoneNumber"`
+	Website      string `json:"website" form:"website"`
+}
+
+// PanelReviewComment A reviewer's note on a panel that leaves its state as is
+type PanelReviewComment struct {
+	Comment string `json:"comment"`
+}
+
+// PanelReviewEntry A panel state change, or a reviewer comment when FromState
+// and ToState are the same
+type PanelReviewEntry struct {
+	Id             int    `json:"Id"`
+	PanelId        int    `json:"panelId"`
+	FromState      string `json:"fromState"`
+	ToState        string `json:"toState"`
+	Comment        string `json:"comment"`
+	ReviewerId     int    `json:"reviewerId"`
+	ReviewerName   string `json:"reviewerName"`
+	ChangeDateTime string `json:"changeDateTime"`
+}
+
+type PanelLocation struct {
+	LocationId int `json:"locationId"`
+}
+
+// PanelStateChange Moves a panel to another state of its review lifecycle,
+// with an optional reviewer comment
+type PanelStateChange struct {
+	State   string `json:"state" enum:"submitted,under_review,accepted,rejected,waitlisted,scheduled,cancelled"`
+	Comment string `json:"comment"`
+}
+
 type PanelScheduledTime struct {
 	LocationId        int    `json:"locationId"`
 	ScheduledTime     string `json:"scheduledTime"`
 	DurationInMinutes int    `json:"durationInMinutes"`
 }
 
+// InvoiceStateChange Moves a vendor, artist or exhibitor to the "invoiced" or
+// "fulfilled" invoice state
+type InvoiceStateChange struct {
+	State string `json:"state"`
+}
+
+// RatedEvent An event with its average rating, as listed in rating reports
+type RatedEvent struct {
+	EventType   string  `json:"eventType"`
+	Id          int     `json:"Id"`
+	Title       string  `json:"title"`
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"ratingCount"`
+}
+
+// RatingReportGroup The top-rated events of one room or track
+type RatingReportGroup struct {
+	GroupId   int          `json:"groupId"`
+	GroupName string       `json:"groupName"`
+	Events    []RatedEvent `json:"events"`
+}
+
+// RatingSubmission A user's rating of an event, from 1 to 5
+type RatingSubmission struct {
+	Rating int `json:"rating"`
+}
+
 type PasswordChange struct {
 	OldPassword string `json:"oldPassword"`
 	NewPassword string `json:"newPassword"`
 }
 
+type Privilege struct {
+	Id              int    `json:"Id"`
+	PrivShortName   string `json:"privilege"`
+	PrivDescription string `json:"description"`
+}
+
+type PrivilegeAssignment struct {
+	Privilege string `json:"privilege"`
+}
+
+// PublicBuilding A building as shown to attendees
+type PublicBuilding struct {
+	Id     int    `json:"Id"`
+	Name   string `json:"name"`
+	City   string `json:"city"`
+	Region string `json:"region"`
+}
+
+// PublicFloor A floor as shown to attendees
+type PublicFloor struct {
+	Id         int    `json:"Id"`
+	FloorName  string `json:"floorName"`
+	BuildingId int    `json:"buildingId"`
+}
+
+// PublicLocation A room as shown to attendees
+type PublicLocation struct {
+	Id         int    `json:"Id"`
+	RoomName   string `json:"roomName"`
+	FloorId    int    `json:"floorId"`
+	BuildingId int    `json:"buildingId"`
+}
+
+// PublicPanel An approved, scheduled panel as shown to attendees, without any
+// staff or requestor details
+type PublicPanel struct {
+	Id                int             `json:"Id"`
+	Topic             string          `json:"topic"`
+	Description       string          `json:"description"`
+	LocationId        int             `json:"locationId"`
+	Location          *LocationDetail `json:"location"`
+	ScheduledTime     string          `json:"scheduledTime"`
+	DurationInMinutes int             `json:"durationInMinutes"`
+	AgeRestricted     bool            `json:"ageRestricted"`
+	Tags              []string        `json:"tags"`
+}
+
+// PublicScreening A scheduled screening as shown to attendees
+type PublicScreening struct {
+	Id                int             `json:"Id"`
+	Title             string          `json:"title"`
+	Synopsis          string          `json:"synopsis"`
+	LocationId        int             `json:"locationId"`
+	Location          *LocationDetail `json:"location"`
+	ScheduledTime     string          `json:"scheduledTime"`
+	DurationInMinutes int             `json:"durationInMinutes"`
+	AgeRestricted     bool            `json:"ageRestricted"`
+	Tags              []string        `json:"tags"`
+}
+
+type Role struct {
+	Id               int      `json:"Id"`
+	RoleName         string   `json:"roleName"`
+	Description      string   `json:"description"`
+	CreationDate     string   `json:"creationDate"`
+	Privileges       []string `json:"privileges"`
+	RequireTwoFactor bool     `json:"requireTwoFactor"`
+}
+
+type RoleAssignment struct {
+	RoleId int `json:"roleId"`
+}
+
+// RoleTwoFactorPolicy Sets whether holders of a role must sign in with a
+// second factor
+type RoleTwoFactorPolicy struct {
+	Required bool `json:"required"`
+}
+
+type RoleUpdate struct {
+	RoleName    string `json:"roleName"`
+	Description string `json:"description"`
+}
+
+type Screening struct {
+	Id                int     `json:"Id"`
+	Title             string  `json:"title"`
+	Synopsis          string  `json:"synopsis"`
+	LocationId        int     `json:"locationId"`
+	ScheduledTime     string  `json:"scheduledTime"`
+	DurationInMinutes int     `json:"durationInMinutes"`
+	AgeRestricted     bool    `json:"ageRestricted"`
+	Rating            float64 `json:"rating"`
+	CreatorId         int     `json:"creatorId"`
+	CreationDateTime  string  `json:"creationDateTime"`
+}
+
+type ScreeningSQL struct {
+	Id                int            `json:"Id"`
+	Title             string         `json:"title"`
+	Synopsis          string         `json:"synopsis"`
+	LocationId        sql.NullInt64  `json:"locationId"`
+	ScheduledTime     sql.NullString `json:"scheduledTime"`
+	DurationInMinutes int            `json:"durationInMinutes"`
+	AgeRestricted     bool           `json:"ageRestricted"`
+	Rating            float64        `json:"rating"`
+	CreatorId         int            `json:"creatorId"`
+	CreationDateTime  string         `json:"creationDateTime"`
+}
+
+type ScreeningAgeRestrictionState struct {
+	RestrictionState bool `json:"restrictionState"`
+}
+
+type ScreeningLocation struct {
+	LocationId int `json:"locationId"`
+}
+
+type ScreeningScheduledTime struct {
+	LocationId        int    `json:"locationId"`
+	ScheduledTime     string `json:"scheduledTime"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+}
+
+// ScheduleChange A change to the schedule of a panel or screening, with the
+// event as it looked right after it
+type ScheduleChange struct {
+	Id                int    `json:"Id"`
+	EventType         string `json:"eventType"`
+	EventId           int    `json:"eventId"`
+	Change            string `json:"change"`
+	Title             string `json:"title"`
+	State             string `json:"state,omitempty"`
+	LocationId        int    `json:"locationId"`
+	ScheduledTime     string `json:"scheduledTime"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+	ChangeDateTime    string `json:"changeDateTime"`
+}
+
+// ScheduleConflict An event already booked into a room that a requested time
+// slot overlaps with
+type ScheduleConflict struct {
+	EventType         string `json:"eventType"`
+	Id                int    `json:"Id"`
+	Title             string `json:"title"`
+	LocationId        int    `json:"locationId"`
+	ScheduledTime     string `json:"scheduledTime"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+	// set when the conflict is a panelist who is booked elsewhere
+	Panelist string `json:"panelist,omitempty"`
+}
+
 type ScheduledEvent struct {
 	Id                int    `json:"Id"`
 	LocationId        int    `json:"locationId"`
@@ -138,11 +611,109 @@ type Schedule struct {
 	DurationInMinutes int    `json:"durationInMinutes"`
 }
 
+// SyncChanges Everything that changed after a sync cursor. Deleted lists the
+// entities that are gone; HasMore means another request, starting from this
+// one's cursor, has more changes to send.
+type SyncChanges struct {
+	Cursor     string            `json:"cursor"`
+	HasMore    bool              `json:"hasMore"`
+	Buildings  []Building        `json:"buildings"`
+	Floors     []BuildingFloor   `json:"floors"`
+	Locations  []Location        `json:"locations"`
+	Panels     []SyncedPanel     `json:"panels"`
+	Screenings []SyncedScreening `json:"screenings"`
+	Tags       []Tag             `json:"tags"`
+	Deleted    []SyncTombstone   `json:"deleted"`
+}
+
+// PublicSyncChanges The published schedule changed since a sync cursor, in
+// the same shapes as the rest of the public API. Panels and screenings that
+// were unpublished are in Deleted, so attendee apps drop them.
+type PublicSyncChanges struct {
+	Cursor     string            `json:"cursor"`
+	HasMore    bool              `json:"hasMore"`
+	Buildings  []PublicBuilding  `json:"buildings"`
+	Floors     []PublicFloor     `json:"floors"`
+	Locations  []PublicLocation  `json:"locations"`
+	Panels     []PublicPanel     `json:"panels"`
+	Screenings []PublicScreening `json:"screenings"`
+	Tags       []Tag             `json:"tags"`
+	Deleted    []SyncTombstone   `json:"deleted"`
+}
+
+// SyncChangeSet The rows changed within a window of the sync log, up to and
+// including the entry with Id LastChangeId
+type SyncChangeSet struct {
+	LastChangeId  int
+	HasMore       bool
+	Buildings     []Building
+	Floors        []BuildingFloor
+	Locations     []Location
+	Panels        []PanelSQL
+	PanelTags     map[int][]string
+	Screenings    []ScreeningSQL
+	ScreeningTags map[int][]string
+	Tags          []Tag
+	Deleted       []SyncTombstone
+}
+
+// SyncedPanel A panel as sent to sync clients, with the names of its tags
+type SyncedPanel struct {
+	Panel
+	Tags []string `json:"tags"`
+}
+
+// SyncedScreening A screening as sent to sync clients, with the names of its
+// tags
+type SyncedScreening struct {
+	Screening
+	Tags []string `json:"tags"`
+}
+
+// SyncTombstone An entity deleted after a sync cursor
+type SyncTombstone struct {
+	EntityType       string `json:"entityType" enum:"building,floor,location,panel,screening,tag"`
+	Id               int    `json:"Id"`
+	DeletionDateTime string `json:"deletionDateTime"`
+}
+
+type Tag struct {
+	Id      int    `json:"Id"`
+	TagName string `json:"tagName"`
+}
+
+type TagAssignment struct {
+	TagId int `json:"tagId"`
+}
+
+// TwoFactorCode A TOTP code, or a recovery code, entered by a user
+type TwoFactorCode struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorEnrollment The TOTP secret to add to an authenticator app, as a
+// base32 string for typing in and as an otpauth URI
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+// TwoFactorStatus Whether a user signs in with a second factor. Pending is
+// set while an enrollment waits for its first code; Required when one of the
+// user's roles demands a second factor.
+type TwoFactorStatus struct {
+	Enabled           bool `json:"enabled"`
+	Pending           bool `json:"pending"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
+}
+
+// User A user account. The password hash is never sent to clients.
 type User struct {
 	Id              int    `json:"Id"`
 	UserName        string `json:"userName"`
 	Status          string `json:"status"`
-	PasswordHash    string `json:"passwordHash"`
+	PasswordHash    string `json:"-"`
 	CreationDate    string `json:"creationDate"`
 	LastChangedDate string `json:"lastChangedDate"`
 }
@@ -156,14 +727,96 @@ type UserStatusMsg struct {
 	UserStatus string `json:"userStatus" enum:"enabled,disabled"`
 }
 
+type Vendor struct {
+	Id               int    `json:"Id"`
+	CompanyName      string `json:"companyName"`
+	TaxId            string `json:"taxId"`
+	BusinessAddress  string `json:"businessAddress"`
+	EmailAddress     string `json:"emailAddress"`
+	PhoneNumber      string `json:"phoneNumber"`
+	BoothId          int    `json:"boothId"`
+	Invoiced         bool   `json:"invoiced"`
+	InvoiceFulfilled bool   `json:"invoiceFulfilled"`
+	CreatorId        int    `json:"creatorId"`
+	CreationDate     string `json:"creationDate"`
+	ApprovalState    bool   `json:"approvalState"`
+	ApprovedById     int    `json:"approvedById"`
+	ApprovalDateTime string `json:"approvalDateTime"`
+}
+
+// Webhook A subscription to event notifications. Its secret is write only.
+type Webhook struct {
+	Id           int      `json:"Id"`
+	Url          string   `json:"url"`
+	EventTypes   []string `json:"eventTypes"`
+	Active       bool     `json:"active"`
+	CreatorId    int      `json:"creatorId"`
+	CreationDate string   `json:"creationDate"`
+}
+
+// WebhookDelivery One event posted, or still to be posted, to a webhook
+type WebhookDelivery struct {
+	Id                  int    `json:"Id"`
+	WebhookId           int    `json:"webhookId"`
+	EventType           string `json:"eventType"`
+	Payload             string `json:"payload"`
+	Attempts            int    `json:"attempts"`
+	LastStatusCode      int    `json:"lastStatusCode"`
+	LastError           string `json:"lastError"`
+	NextAttemptDateTime string `json:"nextAttemptDateTime"`
+	CreationDateTime    string `json:"creationDateTime"`
+	DeliveredDateTime   string `json:"deliveredDateTime"`
+}
+
+// WebhookUpdate Changes a webhook. An empty secret keeps the current one.
+type WebhookUpdate struct {
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+	Active     bool     `json:"active"`
+}
+
+type VendorHallApproval struct {
+	State bool `json:"state"`
+}
+
 // proposed object structs. Normally used when creating new DB entries
 
+// ProposedApiToken A token to create. Scopes are privilege names; a token
+// without an expiry in days never expires.
+type ProposedApiToken struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expiresInDays"`
+}
+
+type ProposedArtist struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	MailingAddress string `json:"mailingAddress"`
+	EmailAddress   string `json:"emailAddress"`
+	PhoneNumber    string `json:"phoneNumber"`
+}
+
+type ProposedBooth struct {
+	Label      string `json:"label"`
+	LocationId int    `json:"locationId"`
+}
+
 type ProposedBuilding struct {
 	Name   string `json:"name"`
 	City   string `json:"city"`
 	Region string `json:"region"`
 }
 
+type ProposedExhibitor struct {
+	Name           string `json:"name"`
+	MailingAddress string `json:"mailingAddress"`
+	PhoneNumber    string `json:"phoneNumber"`
+	EmailAddress   string `json:"emailAddress"`
+	EventId        int    `json:"eventId"`
+}
+
 type ProposedFloor struct {
 	Name         string `json:"name"`
 	BuildingName string `json:"buildingName"`
@@ -175,12 +828,40 @@ type ProposedLocation struct {
 	BuildingId int    `json:"buildingId"`
 }
 
+type ProposedLiveEvent struct {
+	Topic             string `json:"topic"`
+	Description       string `json:"description"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+}
+
+type ProposedPanelist struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	PhoneNumber  string `json:"phoneNumber"`
+}
+
 type ProposedPanel struct {
 	Topic               string `json:"topic"`
 	Description         string `json:"description"`
 	PanelRequestorEmail string `json:"panelRequestorEmail"`
 }
 
+type ProposedRole struct {
+	RoleName    string   `json:"roleName"`
+	Description string   `json:"description"`
+	Privileges  []string `json:"privileges"`
+}
+
+type ProposedScreening struct {
+	Title             string `json:"title"`
+	Synopsis          string `json:"synopsis"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+}
+
+type ProposedTag struct {
+	TagName string `json:"tagName"`
+}
+
 type ProposedUser struct {
 	Id       int    `json:"Id"`
 	UserName string `json:"userName"`
@@ -188,24 +869,123 @@ type ProposedUser struct {
 	Password string `json:"password"`
 }
 
+type ProposedVendor struct {
+	CompanyName     string `json:"companyName"`
+	TaxId           string `json:"taxId"`
+	BusinessAddress string `json:"businessAddress"`
+	EmailAddress    string `json:"emailAddress"`
+	PhoneNumber     string `json:"phoneNumber"`
+}
+
+type ProposedWebhook struct {
+	Url        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+}
+
 // list object structs
 
+type ApiTokenList struct {
+	Data []ApiToken `json:"data"`
+}
+
+type ArtistList struct {
+	Data []Artist `json:"data"`
+}
+
+type AuditList struct {
+	Data []AuditEntry `json:"data"`
+}
+
+type BoothList struct {
+	Data []Booth `json:"data"`
+}
+
 type BuildingList struct {
 	Data []Building `json:"data"`
 }
 
+type ExhibitorList struct {
+	Data []Exhibitor `json:"data"`
+}
+
 type FloorList struct {
 	Data []BuildingFloor `json:"data"`
 }
 
+type LiveEventList struct {
+	Data []LiveEvent `json:"data"`
+}
+
 type LocationList struct {
 	Data []Location `json:"data"`
 }
 
+type LocationDetailList struct {
+	Data []LocationDetail `json:"data"`
+}
+
 type PanelList struct {
 	Data []Panel `json:"data"`
 }
 
+type PanelReviewLog struct {
+	Data []PanelReviewEntry `json:"data"`
+}
+
+type RatingReport struct {
+	GroupBy string              `json:"groupBy"`
+	Data    []RatingReportGroup `json:"data"`
+}
+
+type PanelistList struct {
+	Data []Panelist `json:"data"`
+}
+
+type PublicBuildingList struct {
+	Data []PublicBuilding `json:"data"`
+}
+
+type PublicPanelList struct {
+	Data []PublicPanel `json:"data"`
+}
+
+type PublicScreeningList struct {
+	Data []PublicScreening `json:"data"`
+}
+
+type PrivilegeList struct {
+	Data []Privilege `json:"data"`
+}
+
+type RecoveryCodeList struct {
+	Data []string `json:"data"`
+}
+
+type RoleList struct {
+	Data []Role `json:"data"`
+}
+
+type ScreeningList struct {
+	Data []Screening `json:"data"`
+}
+
+type TagList struct {
+	Data []Tag `json:"data"`
+}
+
+type VendorList struct {
+	Data []Vendor `json:"data"`
+}
+
+type WebhookList struct {
+	Data []Webhook `json:"data"`
+}
+
+type WebhookDeliveryList struct {
+	Data []WebhookDelivery `json:"data"`
+}
+
 type UsersList struct {
 	Data []User `json:"data"`
 }
@@ -216,6 +996,11 @@ type FailureMsg struct {
 	Error string `json:"error"`
 }
 
+type ScheduleConflictMsg struct {
+	Error     string             `json:"error"`
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
+
 type SuccessMsg struct {
 	Message string `json:"message"`
 }
@@ -19,9 +19,7 @@ package model
 */
 
 import (
-	"crypto/sha512"
 	"database/sql"
-	"encoding/hex"
 	"errors"
 	"log"
 	"strconv"
@@ -29,28 +27,13 @@ import (
 )
 
 func getStoredPasswordHash(username string) (string, error) {
-	stmt, err := DB.Prepare("SELECT PasswordHash FROM Users WHERE UserName = ?")
-	if err != nil {
-		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
-		return "", err
-	}
-	defer stmt.Close()
-
 	passwordHash := ""
-	record, err := stmt.Query(username)
+	err := DB.QueryRow("SELECT PasswordHash FROM Users WHERE UserName = ?", username).Scan(&passwordHash)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Println("ERROR: No such user found in DB: " + string(err.Error()))
 			return "", nil
 		}
-		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
-		return "", err
-	}
-	defer record.Close()
-	err = record.Scan(
-		&passwordHash,
-	)
-	if err != nil {
 		log.Println("ERROR: Cannot retrieve password hash from DB: " + string(err.Error()))
 		return "", err
 	}
@@ -59,7 +42,23 @@ func getStoredPasswordHash(username string) (string, error) {
 	return passwordHash, nil
 }
 
-func storeNewPassword(hashedPassword string, username string) (bool, error) {
+// getUserIdByUserNameTx Looks up a user's Id inside a transaction. Returns 0 if
+// no such user exists
+func getUserIdByUserNameTx(t *sql.Tx, username string) (int, error) {
+	var id int
+	err := t.QueryRow("SELECT Id FROM Users WHERE UserName = ?", username).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func storeNewPassword(hashedPassword string, username string, userId int) (bool, error) {
 	t, err := DB.Begin()
 	if err != nil {
 		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
@@ -76,8 +75,17 @@ func storeNewPassword(hashedPassword string, username string) (bool, error) {
 		}
 	}()
 
+	id, err := getUserIdByUserNameTx(t, username)
+	if err != nil {
+		return false, err
+	}
+	before, err := auditSnapshot(t, "Users", id)
+	if err != nil {
+		return false, err
+	}
+
 	// now we need to create a new transaction to SET the password hash into the DB
-	q, err := DB.Prepare("UPDATE Users SET PasswordHash = ?, LastChangedDate = ? WHERE UserName = ?")
+	q, err := t.Prepare("UPDATE Users SET PasswordHash = ?, LastChangedDate = ? WHERE UserName = ?")
 	if err != nil {
 		return false, err
 	}
@@ -90,6 +98,11 @@ func storeNewPassword(hashedPassword string, username string) (bool, error) {
 		return false, err
 	}
 
+	err = auditUpdate(t, userId, "Users", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -100,29 +113,36 @@ func storeNewPassword(hashedPassword string, username string) (bool, error) {
 	return true, nil
 }
 
-func ChangeAccountPassword(username string, oldPassword string, newPassword string) (bool, error) {
+func ChangeAccountPassword(username string, oldPassword string, newPassword string, userId int) (bool, error) {
 	log.Println("INFO: Password change requested")
-	hashedOldPassword := sha512.Sum512([]byte(oldPassword))
-	encodedHashedOldPassword := hex.EncodeToString(hashedOldPassword[:])
-
 	storedHash, err := getStoredPasswordHash(username)
 	if err != nil {
 		log.Println("ERROR: Cannot retrieve stored password hash from DB: " + string(err.Error()))
 		return false, err
 	}
+	if storedHash == "" {
+		log.Println("ERROR: No stored password hash for user '" + username + "'")
+		return false, new(PasswordHashMismatch)
+	}
 	log.Println("INFO: Retrieved stored hash for comparison")
 
-	// now get password hash from the db
-	if storedHash != encodedHashedOldPassword {
-		log.Println("ERROR: Hashed value of old password does not match stored hashed value")
+	match, _, err := VerifyPassword(oldPassword, storedHash)
+	if err != nil {
+		log.Println("ERROR: Cannot verify old password: " + string(err.Error()))
+		return false, err
+	}
+	if !match {
+		log.Println("ERROR: Old password does not match stored hash")
 		p := new(PasswordHashMismatch)
 		return false, p
 	}
 
 	// matches, so hash new password
-	hashedNewPassword := sha512.Sum512([]byte(newPassword))
-	encodedHashedNewPassword := hex.EncodeToString(hashedNewPassword[:])
-	_, err = storeNewPassword(encodedHashedNewPassword, username)
+	encodedHashedNewPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return false, err
+	}
+	_, err = storeNewPassword(encodedHashedNewPassword, username, userId)
 	if err != nil {
 		log.Println("ERROR: Cannot store updated password hash in DB: " + string(err.Error()))
 		return false, err
@@ -134,25 +154,8 @@ func ChangeAccountPassword(username string, oldPassword string, newPassword stri
 
 func GetUserById(id int) (User, error) {
 	log.Println("INFO: User by Id requested: " + strconv.Itoa(id))
-	stmt, err := DB.Prepare("SELECT * FROM Users WHERE Id = ?")
-	if err != nil {
-		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
-		return User{}, err
-	}
-	defer stmt.Close()
-
 	user := User{}
-	record, err := stmt.Query(id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			log.Println("ERROR: No such user found in DB: " + string(err.Error()))
-			return User{}, nil
-		}
-		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
-		return User{}, err
-	}
-	defer record.Close()
-	err = record.Scan(
+	err := DB.QueryRow("SELECT Id, UserName, Status, PasswordHash, CreationDate, LastChangedDate FROM Users WHERE Id = ?", id).Scan(
 		&user.Id,
 		&user.UserName,
 		&user.Status,
@@ -161,7 +164,11 @@ func GetUserById(id int) (User, error) {
 		&user.LastChangedDate,
 	)
 	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the user object!" + string(err.Error()))
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such user found in DB: " + string(err.Error()))
+			return User{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
 		return User{}, err
 	}
 
@@ -171,25 +178,8 @@ func GetUserById(id int) (User, error) {
 
 func GetUserByUserName(username string) (User, error) {
 	log.Println("INFO: User by username requested: " + username)
-	stmt, err := DB.Prepare("SELECT * FROM Users WHERE UserName = ?")
-	if err != nil {
-		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
-		return User{}, err
-	}
-	defer stmt.Close()
-
 	user := User{}
-	record, err := stmt.Query(username)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			log.Println("ERROR: No such user found in DB: " + string(err.Error()))
-			return User{}, nil
-		}
-		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
-		return User{}, err
-	}
-	defer record.Close()
-	err = record.Scan(
+	err := DB.QueryRow("SELECT Id, UserName, Status, PasswordHash, CreationDate, LastChangedDate FROM Users WHERE UserName = ?", username).Scan(
 		&user.Id,
 		&user.UserName,
 		&user.Status,
@@ -198,7 +188,11 @@ func GetUserByUserName(username string) (User, error) {
 		&user.LastChangedDate,
 	)
 	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the user object!" + string(err.Error()))
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such user found in DB: " + string(err.Error()))
+			return User{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
 		return User{}, err
 	}
 
@@ -206,7 +200,7 @@ func GetUserByUserName(username string) (User, error) {
 	return user, nil
 }
 
-func CreateUser(p ProposedUser) (bool, error) {
+func CreateUser(p ProposedUser, userId int) (bool, error) {
 	log.Println("INFO: User creation requested: " + p.UserName)
 	t, err := DB.Begin()
 	if err != nil {
@@ -231,15 +225,22 @@ func CreateUser(p ProposedUser) (bool, error) {
 	}
 
 	// take password and hash it
-	hash := sha512.Sum512([]byte(p.Password))
-	passwdHash := hex.EncodeToString(hash[:])
+	passwdHash, err := HashPassword(p.Password)
+	if err != nil {
+		return false, err
+	}
 
-	_, err = q.Exec(p.UserName, passwdHash)
+	result, err := q.Exec(p.UserName, passwdHash)
 	if err != nil {
 		log.Println("ERROR: Cannot create user '" + p.UserName + "': " + string(err.Error()))
 		return false, err
 	}
 
+	err = auditInsert(t, userId, "Users", result)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -250,7 +251,7 @@ func CreateUser(p ProposedUser) (bool, error) {
 	return true, nil
 }
 
-func DeleteUser(username string) (bool, error) {
+func DeleteUser(username string, userId int) (bool, error) {
 	log.Println("INFO: User deletion requested: " + username)
 	t, err := DB.Begin()
 	if err != nil {
@@ -268,7 +269,42 @@ func DeleteUser(username string) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("DELETE FROM Users WHERE UserName IS ?")
+	id, err := getUserIdByUserNameTx(t, username)
+	if err != nil {
+		return false, err
+	}
+	before, err := auditSnapshot(t, "Users", id)
+	if err != nil {
+		return false, err
+	}
+	administrators, err := countAdministrators(t)
+	if err != nil {
+		return false, err
+	}
+
+	// drop the user's role assignments, API tokens and second factor along
+	// with the account
+	for _, stmt := range []string{
+		"DELETE FROM UserRoleAssignments WHERE UserId = ?",
+		"DELETE FROM ApiTokenScopes WHERE TokenId IN (SELECT Id FROM ApiTokens WHERE UserId = ?)",
+		"DELETE FROM ApiTokens WHERE UserId = ?",
+		"DELETE FROM LoginLockouts WHERE UserId = ?",
+		"DELETE FROM RecoveryCodes WHERE UserId = ?",
+		"DELETE FROM UserTwoFactor WHERE UserId = ?",
+		"DELETE FROM Sessions WHERE UserName = (SELECT UserName FROM Users WHERE Id = ?)",
+	} {
+		_, err = t.Exec(stmt, id)
+		if err != nil {
+			log.Println("ERROR: Cannot remove records for user '" + username + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+	err = keepAdministrator(t, administrators)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("DELETE FROM Users WHERE UserName IS ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
 		return false, err
@@ -280,6 +316,11 @@ func DeleteUser(username string) (bool, error) {
 		return false, err
 	}
 
+	err = auditDelete(t, userId, "Users", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
@@ -323,15 +364,8 @@ func GetUsers() ([]User, error) {
 
 func GetUserStatus(username string) (string, error) {
 	log.Println("INFO: User status requested for user '" + username + "'")
-	stmt, err := DB.Prepare("SELECT Status FROM Users WHERE UserName IS ?")
-	if err != nil {
-		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
-		return "", err
-	}
-	defer stmt.Close()
-
 	status := ""
-	record, err := stmt.Query(username)
+	err := DB.QueryRow("SELECT Status FROM Users WHERE UserName IS ?", username).Scan(&status)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Println("ERROR: No such user found in DB: " + string(err.Error()))
@@ -340,20 +374,12 @@ func GetUserStatus(username string) (string, error) {
 		log.Println("ERROR: Cannot retrieve user from DB: " + string(err.Error()))
 		return "", err
 	}
-	defer record.Close()
-	err = record.Scan(
-		&status,
-	)
-	if err != nil {
-		log.Println("ERROR: Cannot unmarshal the user object!" + string(err.Error()))
-		return "", err
-	}
 
 	log.Println("INFO: User '" + username + "' status: " + status)
 	return status, nil
 }
 
-func SetUserStatus(username string, j UserStatus) (bool, error) {
+func SetUserStatus(username string, j UserStatus, userId int) (bool, error) {
 	log.Println("INFO: Set user status for user '" + username + "'")
 	t, err := DB.Begin()
 	if err != nil {
@@ -371,7 +397,16 @@ func SetUserStatus(username string, j UserStatus) (bool, error) {
 		}
 	}()
 
-	q, err := DB.Prepare("UPDATE Users SET Status = ? WHERE UserName = ?")
+	id, err := getUserIdByUserNameTx(t, username)
+	if err != nil {
+		return false, err
+	}
+	before, err := auditSnapshot(t, "Users", id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE Users SET Status = ? WHERE UserName = ?")
 	if err != nil {
 		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
 		return false, err
@@ -380,7 +415,8 @@ func SetUserStatus(username string, j UserStatus) (bool, error) {
 	log.Println("INFO: user to set status of: " + username)
 	log.Println("INFO: requested state to set user to: " + j.Status)
 	if j.Status != "enabled" && j.Status != "locked" {
-		return false, &InvalidStatusValue{Err: errors.New("invalid value: " + j.Status)}
+		err = &InvalidStatusValue{Err: errors.New("invalid value: " + j.Status)}
+		return false, err
 	}
 
 	result, err := q.Exec(j.Status, username)
@@ -394,6 +430,34 @@ func SetUserStatus(username string, j UserStatus) (bool, error) {
 		return false, err
 	}
 
+	// an administrator's choice replaces any automatic lockout: a locked
+	// account stays locked, and an unlocked one starts with a clean slate
+	_, err = t.Exec("DELETE FROM LoginLockouts WHERE UserId = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot clear lockout of user '" + username + "': " + string(err.Error()))
+		return false, err
+	}
+	if j.Status == "enabled" {
+		_, err = t.Exec("DELETE FROM LoginFailures WHERE UserName = ?", username)
+		if err != nil {
+			log.Println("ERROR: Cannot clear failed logins of user '" + username + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+	if j.Status == "locked" {
+		// sign the user out everywhere, when sessions are kept in the database
+		_, err = t.Exec("DELETE FROM Sessions WHERE UserName = ?", username)
+		if err != nil {
+			log.Println("ERROR: Cannot revoke sessions of user '" + username + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	err = auditUpdate(t, userId, "Users", id, before)
+	if err != nil {
+		return false, err
+	}
+
 	err = t.Commit()
 	if err != nil {
 		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
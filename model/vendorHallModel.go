@@ -0,0 +1,351 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+)
+
+// invoice states a vendor, artist or exhibitor moves through, in order
+const (
+	InvoiceNone      = "none"
+	InvoiceInvoiced  = "invoiced"
+	InvoiceFulfilled = "fulfilled"
+)
+
+// vendorHallTable Describes a table of vendor hall participants. They share
+// the approval and invoicing columns; vendors and artists also hold booths.
+type vendorHallTable struct {
+	Table       string
+	Description string
+}
+
+var (
+	vendorTable    = vendorHallTable{Table: "Vendors", Description: "vendor"}
+	artistTable    = vendorHallTable{Table: "Artists", Description: "artist"}
+	exhibitorTable = vendorHallTable{Table: "Exhibitors", Description: "exhibitor"}
+)
+
+// booth holders, as reported on booths
+const (
+	BoothHolderVendor = "vendor"
+	BoothHolderArtist = "artist"
+)
+
+func deleteVendorHallEntry(v vendorHallTable, id int, userId int) (bool, error) {
+	log.Println("INFO: " + v.Description + " deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, v.Table, id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("DELETE FROM " + v.Table + " WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare the DB query!" + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete " + v.Description + " with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such " + v.Description + " found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditDelete(t, userId, v.Table, id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: " + v.Description + " with id '" + strconv.Itoa(id) + "' has been deleted")
+	return true, nil
+}
+
+func setVendorHallApproval(v vendorHallTable, id int, status VendorHallApproval, userId int) (bool, error) {
+	log.Println("INFO: Set approval state for " + v.Description + " Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, v.Table, id)
+	if err != nil {
+		return false, err
+	}
+
+	q, err := t.Prepare("UPDATE " + v.Table + " SET ApprovalState = ?, ApprovedById = ?, ApprovalDateTime = CURRENT_TIMESTAMP WHERE Id = ?")
+	if err != nil {
+		log.Println("ERROR: Could not prepare DB query! " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(status.State, userId, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for " + v.Description + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, v.Table, id, before)
+	if err != nil {
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Approval state for " + v.Description + " Id '" + strconv.Itoa(id) + "' set to '" + strconv.FormatBool(status.State) + "'")
+	return numberOfRows > 0, nil
+}
+
+// setVendorHallInvoiceState Moves an approved entry from not invoiced to
+// invoiced, or from invoiced to fulfilled. Any other change is refused.
+func setVendorHallInvoiceState(v vendorHallTable, id int, change InvoiceStateChange, userId int) (bool, error) {
+	log.Println("INFO: Set invoice state for " + v.Description + " Id '" + strconv.Itoa(id) + "' to '" + change.State + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	var approved, invoiced, fulfilled bool
+	err = t.QueryRow("SELECT ApprovalState, Invoiced, InvoiceFulfilled FROM "+v.Table+" WHERE Id = ?", id).Scan(&approved, &invoiced, &fulfilled)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such " + v.Description + " found in DB: " + string(err.Error()))
+			err = t.Rollback()
+			return false, err
+		}
+		log.Println("ERROR: Cannot retrieve " + v.Description + " from DB: " + string(err.Error()))
+		return false, err
+	}
+
+	var update string
+	switch change.State {
+	case InvoiceInvoiced:
+		if !approved {
+			err = &InvalidInvoiceTransition{Err: errors.New(v.Description + " has not been approved")}
+		} else if invoiced {
+			err = &InvalidInvoiceTransition{Err: errors.New(v.Description + " has already been invoiced")}
+		}
+		update = "UPDATE " + v.Table + " SET Invoiced = TRUE WHERE Id = ?"
+	case InvoiceFulfilled:
+		if !invoiced {
+			err = &InvalidInvoiceTransition{Err: errors.New(v.Description + " has not been invoiced")}
+		} else if fulfilled {
+			err = &InvalidInvoiceTransition{Err: errors.New(v.Description + " invoice has already been fulfilled")}
+		}
+		update = "UPDATE " + v.Table + " SET InvoiceFulfilled = TRUE WHERE Id = ?"
+	default:
+		err = &InvalidInvoiceTransition{Err: errors.New("state must be either '" + InvoiceInvoiced + "' or '" + InvoiceFulfilled + "'")}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	before, err := auditSnapshot(t, v.Table, id)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = t.Exec(update, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for " + v.Description + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, v.Table, id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Invoice state for " + v.Description + " Id '" + strconv.Itoa(id) + "' set to '" + change.State + "'")
+	return true, nil
+}
+
+// boothHolder Returns the kind and Id of the vendor or artist holding a booth,
+// or an empty kind if it is free
+func boothHolder(t *sql.Tx, boothId int) (string, int, error) {
+	var holderType string
+	var holderId int
+	err := t.QueryRow(`SELECT 'vendor', Id FROM Vendors WHERE BoothId = ?
+		UNION ALL
+		SELECT 'artist', Id FROM Artists WHERE BoothId = ?`, boothId, boothId).Scan(&holderType, &holderId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", 0, nil
+		}
+		log.Println("ERROR: Cannot check the holder of booth Id '" + strconv.Itoa(boothId) + "': " + string(err.Error()))
+		return "", 0, err
+	}
+
+	return holderType, holderId, nil
+}
+
+// setBooth Gives a vendor or artist a booth, or takes it away when boothId is
+// 0. A booth can only be held by one vendor or artist at a time.
+func setBooth(v vendorHallTable, holderType string, id int, boothId int, userId int) (bool, error) {
+	log.Println("INFO: Set booth for " + v.Description + " Id '" + strconv.Itoa(id) + "' to booth Id '" + strconv.Itoa(boothId) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	booth := sql.NullInt64{}
+	if boothId != 0 {
+		var count int
+		err = t.QueryRow("SELECT COUNT(*) FROM Booths WHERE Id = ?", boothId).Scan(&count)
+		if err != nil {
+			log.Println("ERROR: Cannot check for booth Id '" + strconv.Itoa(boothId) + "': " + string(err.Error()))
+			return false, err
+		}
+		if count == 0 {
+			err = &NoSuchBooth{Err: errors.New("invalid booth Id: " + strconv.Itoa(boothId))}
+			return false, err
+		}
+
+		currentType, currentId, herr := boothHolder(t, boothId)
+		if herr != nil {
+			err = herr
+			return false, err
+		}
+		if currentType != "" && (currentType != holderType || currentId != id) {
+			err = &BoothInUse{Err: errors.New("booth Id '" + strconv.Itoa(boothId) + "' is held by " + currentType + " Id '" + strconv.Itoa(currentId) + "'")}
+			return false, err
+		}
+		booth = sql.NullInt64{Int64: int64(boothId), Valid: true}
+	}
+
+	before, err := auditSnapshot(t, v.Table, id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE "+v.Table+" SET BoothId = ? WHERE Id = ?", booth, id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for " + v.Description + " Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such " + v.Description + " found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, v.Table, id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Booth for " + v.Description + " Id '" + strconv.Itoa(id) + "' updated")
+	return true, nil
+}
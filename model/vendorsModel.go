@@ -0,0 +1,215 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const vendorColumns = `Id, CompanyName, TaxId, BusinessAddress, EmailAddress, PhoneNumber, IFNULL(BoothId, 0), Invoiced, InvoiceFulfilled, CreatorId, CreationDate, ApprovalState, IFNULL(ApprovedById, 0), IFNULL(ApprovalDateTime, '')`
+
+func CreateVendor(p ProposedVendor, userId int) (bool, error) {
+	log.Println("INFO: Creating a vendor: " + p.CompanyName)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	q, err := t.Prepare("INSERT INTO Vendors (CompanyName, TaxId, BusinessAddress, EmailAddress, PhoneNumber, CreatorId) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		log.Println("ERROR: Cannot prepare DB query: " + string(err.Error()))
+		return false, err
+	}
+
+	result, err := q.Exec(strings.TrimSpace(p.CompanyName), strings.TrimSpace(p.TaxId), strings.TrimSpace(p.BusinessAddress), strings.TrimSpace(p.EmailAddress), strings.TrimSpace(p.PhoneNumber), userId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Vendors", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Vendor entry created")
+	return true, nil
+}
+
+func scanVendors(rows *sql.Rows) ([]Vendor, error) {
+	vendors := make([]Vendor, 0)
+	for rows.Next() {
+		vendor := Vendor{}
+		err := rows.Scan(
+			&vendor.Id,
+			&vendor.CompanyName,
+			&vendor.TaxId,
+			&vendor.BusinessAddress,
+			&vendor.EmailAddress,
+			&vendor.PhoneNumber,
+			&vendor.BoothId,
+			&vendor.Invoiced,
+			&vendor.InvoiceFulfilled,
+			&vendor.CreatorId,
+			&vendor.CreationDate,
+			&vendor.ApprovalState,
+			&vendor.ApprovedById,
+			&vendor.ApprovalDateTime,
+		)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the vendor objects!" + string(err.Error()))
+			return nil, err
+		}
+		vendors = append(vendors, vendor)
+	}
+
+	return vendors, rows.Err()
+}
+
+func GetVendors() ([]Vendor, error) {
+	log.Println("INFO: List of vendor objects requested")
+	rows, err := DB.Query("SELECT " + vendorColumns + " FROM Vendors ORDER BY CompanyName")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	vendors, err := scanVendors(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("INFO: List of all vendors retrieved")
+	return vendors, nil
+}
+
+func GetVendorById(id int) (Vendor, error) {
+	log.Println("INFO: Vendor by Id requested: " + strconv.Itoa(id))
+	rows, err := DB.Query("SELECT "+vendorColumns+" FROM Vendors WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return Vendor{}, err
+	}
+	defer rows.Close()
+
+	vendors, err := scanVendors(rows)
+	if err != nil {
+		return Vendor{}, err
+	}
+	if len(vendors) == 0 {
+		log.Println("ERROR: No such vendor found in DB")
+		return Vendor{}, nil
+	}
+
+	log.Println("INFO: Vendor by Id '" + strconv.Itoa(id) + "' retrieved")
+	return vendors[0], nil
+}
+
+func UpdateVendorById(id int, p ProposedVendor, userId int) (bool, error) {
+	log.Println("INFO: Update vendor Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Vendors", id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := t.Exec("UPDATE Vendors SET CompanyName = ?, TaxId = ?, BusinessAddress = ?, EmailAddress = ?, PhoneNumber = ? WHERE Id = ?", strings.TrimSpace(p.CompanyName), strings.TrimSpace(p.TaxId), strings.TrimSpace(p.BusinessAddress), strings.TrimSpace(p.EmailAddress), strings.TrimSpace(p.PhoneNumber), id)
+	if err != nil {
+		log.Println("ERROR: Could not execute query for vendor Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such vendor found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Vendors", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Vendor entry updated")
+	return true, nil
+}
+
+func DeleteVendorById(id int, userId int) (bool, error) {
+	return deleteVendorHallEntry(vendorTable, id, userId)
+}
+
+func SetApprovalStateVendorById(id int, status VendorHallApproval, userId int) (bool, error) {
+	return setVendorHallApproval(vendorTable, id, status, userId)
+}
+
+func SetVendorInvoiceState(id int, change InvoiceStateChange, userId int) (bool, error) {
+	return setVendorHallInvoiceState(vendorTable, id, change, userId)
+}
+
+// SetVendorBooth Gives a vendor a booth, or takes it away when the booth Id is 0
+func SetVendorBooth(id int, j BoothAssignment, userId int) (bool, error) {
+	return setBooth(vendorTable, BoothHolderVendor, id, j.BoothId, userId)
+}
@@ -0,0 +1,545 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// event types webhooks can subscribe to
+const (
+	WebhookPanelApproved        = "panel.approved"
+	WebhookPanelRejected        = "panel.rejected"
+	WebhookPanelCancelled       = "panel.cancelled"
+	WebhookPanelScheduled       = "panel.scheduled"
+	WebhookPanelRescheduled     = "panel.rescheduled"
+	WebhookPanelDeleted         = "panel.deleted"
+	WebhookScreeningRescheduled = "screening.rescheduled"
+	WebhookScreeningDeleted     = "screening.deleted"
+	WebhookLiveEventApproved    = "liveevent.approved"
+	WebhookLiveEventUnapproved  = "liveevent.unapproved"
+	WebhookLiveEventRescheduled = "liveevent.rescheduled"
+	WebhookLiveEventDeleted     = "liveevent.deleted"
+)
+
+// WebhookEventTypes Every event type a webhook can subscribe to
+var WebhookEventTypes = []string{
+	WebhookPanelApproved,
+	WebhookPanelRejected,
+	WebhookPanelCancelled,
+	WebhookPanelScheduled,
+	WebhookPanelRescheduled,
+	WebhookPanelDeleted,
+	WebhookScreeningRescheduled,
+	WebhookScreeningDeleted,
+	WebhookLiveEventApproved,
+	WebhookLiveEventUnapproved,
+	WebhookLiveEventRescheduled,
+	WebhookLiveEventDeleted,
+}
+
+func IsWebhookEventType(eventType string) bool {
+	for _, known := range WebhookEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookField A column sent to webhook receivers and the key it is sent
+// under
+type webhookField struct {
+	Column  string
+	Key     string
+	Boolean bool
+}
+
+// webhookFields The columns of each table that are sent to webhook
+// receivers. Receivers are outside the service, so only what the public
+// schedule already shows goes out, under the same keys as PublicPanel and
+// PublicScreening; requestor details, reviewers and approval state never do.
+var webhookFields = map[string][]webhookField{
+	"Panels": {
+		{Column: "Id", Key: "Id"},
+		{Column: "Topic", Key: "topic"},
+		{Column: "Description", Key: "description"},
+		{Column: "LocationId", Key: "locationId"},
+		{Column: "ScheduledTime", Key: "scheduledTime"},
+		{Column: "DurationInMinutes", Key: "durationInMinutes"},
+		{Column: "AgeRestricted", Key: "ageRestricted", Boolean: true},
+	},
+	"VideoScreenings": {
+		{Column: "Id", Key: "Id"},
+		{Column: "Title", Key: "title"},
+		{Column: "Synopsis", Key: "synopsis"},
+		{Column: "LocationId", Key: "locationId"},
+		{Column: "ScheduledTime", Key: "scheduledTime"},
+		{Column: "DurationInMinutes", Key: "durationInMinutes"},
+		{Column: "AgeRestricted", Key: "ageRestricted", Boolean: true},
+	},
+	"LiveEvents": {
+		{Column: "Id", Key: "Id"},
+		{Column: "Topic", Key: "topic"},
+		{Column: "Description", Key: "description"},
+		{Column: "LocationId", Key: "locationId"},
+		{Column: "ScheduledTime", Key: "scheduledTime"},
+		{Column: "DurationInMinutes", Key: "durationInMinutes"},
+		{Column: "AgeRestricted", Key: "ageRestricted", Boolean: true},
+	},
+}
+
+// webhookData Returns the public part of a row read by auditSnapshot, or nil
+// when there is no row
+func webhookData(table string, snapshot map[string]interface{}) map[string]interface{} {
+	if snapshot == nil {
+		return nil
+	}
+
+	data := make(map[string]interface{}, len(webhookFields[table]))
+	for _, field := range webhookFields[table] {
+		value := snapshot[field.Column]
+		if field.Boolean {
+			switch v := value.(type) {
+			case int64:
+				value = v != 0
+			case nil:
+				value = false
+			}
+		}
+		data[field.Key] = value
+	}
+	return data
+}
+
+// queueWebhookEvent Queues a delivery of an event to every active webhook
+// subscribed to it. Like queueEmail, it must be called inside the transaction
+// making the change, so deliveries are only kept if the change is committed.
+// snapshot is the affected row of table, as read by auditSnapshot, and only
+// its webhookFields are sent; nothing is queued when the row does not exist.
+func queueWebhookEvent(t *sql.Tx, eventType string, table string, id int, snapshot map[string]interface{}) error {
+	data := webhookData(table, snapshot)
+	if data == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":      eventType,
+		"id":         id,
+		"occurredAt": time.Now().UTC().Format(time.RFC3339),
+		"data":       data,
+	})
+	if err != nil {
+		log.Println("ERROR: Cannot encode webhook payload: " + string(err.Error()))
+		return err
+	}
+
+	result, err := t.Exec(`INSERT INTO WebhookDeliveries (WebhookId, EventType, Payload)
+		SELECT w.Id, e.EventType, ?
+		FROM Webhooks w
+		INNER JOIN WebhookEvents e ON e.WebhookId = w.Id
+		WHERE w.Active = TRUE AND e.EventType = ?`, string(payload), eventType)
+	if err != nil {
+		log.Println("ERROR: Cannot queue '" + eventType + "' webhook deliveries: " + string(err.Error()))
+		return err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return err
+	}
+	if numberOfRows > 0 {
+		log.Println("INFO: Queued " + strconv.Itoa(int(numberOfRows)) + " '" + eventType + "' webhook deliveries")
+	}
+	return nil
+}
+
+// queueWebhookEventFor Queues an event carrying the row as it is now. The
+// table name must be a constant, never user input.
+func queueWebhookEventFor(t *sql.Tx, eventType string, table string, id int) error {
+	snapshot, err := auditSnapshot(t, table, id)
+	if err != nil {
+		return err
+	}
+
+	return queueWebhookEvent(t, eventType, table, id, snapshot)
+}
+
+func setWebhookEvents(t *sql.Tx, id int, eventTypes []string) error {
+	_, err := t.Exec("DELETE FROM WebhookEvents WHERE WebhookId = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot clear event types of webhook Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return err
+	}
+
+	for _, eventType := range eventTypes {
+		_, err = t.Exec("INSERT OR IGNORE INTO WebhookEvents (WebhookId, EventType) VALUES (?, ?)", id, eventType)
+		if err != nil {
+			log.Println("ERROR: Cannot subscribe webhook Id '" + strconv.Itoa(id) + "' to '" + eventType + "': " + string(err.Error()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func CreateWebhook(p ProposedWebhook, userId int) (bool, error) {
+	log.Println("INFO: Creating a webhook: " + p.Url)
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Cannot start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	result, err := t.Exec("INSERT INTO Webhooks (Url, Secret, CreatorId) VALUES (?, ?, ?)", strings.TrimSpace(p.Url), p.Secret, userId)
+	if err != nil {
+		log.Println("ERROR: Cannot execute DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Println("ERROR: Cannot get the Id of the new webhook: " + string(err.Error()))
+		return false, err
+	}
+
+	err = setWebhookEvents(t, int(id), p.EventTypes)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditInsert(t, userId, "Webhooks", result)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Webhook entry created")
+	return true, nil
+}
+
+func DeleteWebhookById(id int, userId int) (bool, error) {
+	log.Println("INFO: Webhook deletion requested: " + strconv.Itoa(id))
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction!" + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Webhooks", id)
+	if err != nil {
+		return false, err
+	}
+
+	// the subscriptions and the delivery log only exist as part of their webhook
+	for _, stmt := range []string{
+		"DELETE FROM WebhookEvents WHERE WebhookId = ?",
+		"DELETE FROM WebhookDeliveries WHERE WebhookId = ?",
+	} {
+		_, err = t.Exec(stmt, id)
+		if err != nil {
+			log.Println("ERROR: Cannot delete records for webhook with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+			return false, err
+		}
+	}
+
+	result, err := t.Exec("DELETE FROM Webhooks WHERE Id = ?", id)
+	if err != nil {
+		log.Println("ERROR: Cannot delete webhook with id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	numberOfRows, err := result.RowsAffected()
+	if err != nil {
+		log.Println("ERROR: Could not get number of rows affected: " + string(err.Error()))
+		return false, err
+	}
+	if numberOfRows == 0 {
+		log.Println("ERROR: No such webhook found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	err = auditDelete(t, userId, "Webhooks", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Webhook with id '" + strconv.Itoa(id) + "' has been deleted")
+	return true, nil
+}
+
+// webhookEventTypes Maps webhook Ids to the event types they are subscribed to
+func webhookEventTypes() (map[int][]string, error) {
+	rows, err := DB.Query("SELECT WebhookId, EventType FROM WebhookEvents ORDER BY WebhookId, EventType")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventTypes := make(map[int][]string)
+	for rows.Next() {
+		var id int
+		var eventType string
+		err = rows.Scan(&id, &eventType)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the webhook event types!" + string(err.Error()))
+			return nil, err
+		}
+		eventTypes[id] = append(eventTypes[id], eventType)
+	}
+
+	return eventTypes, rows.Err()
+}
+
+func GetWebhooks() ([]Webhook, error) {
+	log.Println("INFO: List of webhook objects requested")
+	eventTypes, err := webhookEventTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := DB.Query("SELECT Id, Url, Active, CreatorId, CreationDate FROM Webhooks ORDER BY Id")
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]Webhook, 0)
+	for rows.Next() {
+		webhook := Webhook{}
+		err = rows.Scan(&webhook.Id, &webhook.Url, &webhook.Active, &webhook.CreatorId, &webhook.CreationDate)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the webhook objects!" + string(err.Error()))
+			return nil, err
+		}
+		webhook.EventTypes = eventTypes[webhook.Id]
+		if webhook.EventTypes == nil {
+			webhook.EventTypes = make([]string, 0)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	log.Println("INFO: List of all webhooks retrieved")
+	return webhooks, rows.Err()
+}
+
+func GetWebhookById(id int) (Webhook, error) {
+	log.Println("INFO: Webhook by Id requested: " + strconv.Itoa(id))
+	webhook := Webhook{}
+	err := DB.QueryRow("SELECT Id, Url, Active, CreatorId, CreationDate FROM Webhooks WHERE Id = ?", id).Scan(&webhook.Id, &webhook.Url, &webhook.Active, &webhook.CreatorId, &webhook.CreationDate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println("ERROR: No such webhook found in DB: " + string(err.Error()))
+			return Webhook{}, nil
+		}
+		log.Println("ERROR: Cannot retrieve webhook from DB: " + string(err.Error()))
+		return Webhook{}, err
+	}
+
+	eventTypes, err := webhookEventTypes()
+	if err != nil {
+		return Webhook{}, err
+	}
+	webhook.EventTypes = eventTypes[webhook.Id]
+	if webhook.EventTypes == nil {
+		webhook.EventTypes = make([]string, 0)
+	}
+
+	log.Println("INFO: Webhook by Id '" + strconv.Itoa(id) + "' retrieved")
+	return webhook, nil
+}
+
+func UpdateWebhookById(id int, u WebhookUpdate, userId int) (bool, error) {
+	log.Println("INFO: Update webhook Id '" + strconv.Itoa(id) + "'")
+	t, err := DB.Begin()
+	if err != nil {
+		log.Println("ERROR: Could not start DB transaction: " + string(err.Error()))
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+		if err != nil {
+			log.Println("ERROR: DB transaction failed: " + string(err.Error()))
+			t.Rollback()
+		}
+	}()
+
+	before, err := auditSnapshot(t, "Webhooks", id)
+	if err != nil {
+		return false, err
+	}
+	if before == nil {
+		log.Println("ERROR: No such webhook found in DB")
+		err = t.Rollback()
+		return false, err
+	}
+
+	if u.Secret != "" {
+		_, err = t.Exec("UPDATE Webhooks SET Url = ?, Secret = ?, Active = ? WHERE Id = ?", strings.TrimSpace(u.Url), u.Secret, u.Active, id)
+	} else {
+		_, err = t.Exec("UPDATE Webhooks SET Url = ?, Active = ? WHERE Id = ?", strings.TrimSpace(u.Url), u.Active, id)
+	}
+	if err != nil {
+		log.Println("ERROR: Could not execute query for webhook Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+		return false, err
+	}
+
+	err = setWebhookEvents(t, id, u.EventTypes)
+	if err != nil {
+		return false, err
+	}
+
+	err = auditUpdate(t, userId, "Webhooks", id, before)
+	if err != nil {
+		return false, err
+	}
+
+	err = t.Commit()
+	if err != nil {
+		log.Println("ERROR: Cannot commit DB transaction: " + string(err.Error()))
+		return false, err
+	}
+
+	log.Println("INFO: Webhook entry updated")
+	return true, nil
+}
+
+// GetWebhookDeliveries Lists the deliveries of a webhook, newest first
+func GetWebhookDeliveries(id int, limit int, offset int) ([]WebhookDelivery, error) {
+	log.Println("INFO: Deliveries of webhook Id '" + strconv.Itoa(id) + "' requested")
+	rows, err := DB.Query(`SELECT Id, WebhookId, EventType, Payload, Attempts, LastStatusCode, LastError,
+			NextAttemptDateTime, CreationDateTime, IFNULL(DeliveredDateTime, '')
+		FROM WebhookDeliveries
+		WHERE WebhookId = ?
+		ORDER BY Id DESC LIMIT ? OFFSET ?`, id, limit, offset)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]WebhookDelivery, 0)
+	for rows.Next() {
+		delivery := WebhookDelivery{}
+		err = rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventType, &delivery.Payload,
+			&delivery.Attempts, &delivery.LastStatusCode, &delivery.LastError,
+			&delivery.NextAttemptDateTime, &delivery.CreationDateTime, &delivery.DeliveredDateTime)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the webhook delivery objects!" + string(err.Error()))
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// GetDueWebhookDeliveries Lists up to limit undelivered events for active
+// webhooks whose next attempt is due and that have been tried fewer than
+// maxAttempts times, oldest first
+func GetDueWebhookDeliveries(limit int, maxAttempts int) ([]DueWebhookDelivery, error) {
+	rows, err := DB.Query(`SELECT d.Id, d.EventType, d.Payload, d.Attempts, w.Url, w.Secret
+		FROM WebhookDeliveries d
+		INNER JOIN Webhooks w ON w.Id = d.WebhookId
+		WHERE d.DeliveredDateTime IS NULL AND d.Attempts < ? AND d.NextAttemptDateTime <= CURRENT_TIMESTAMP
+		  AND w.Active = TRUE
+		ORDER BY d.Id LIMIT ?`, maxAttempts, limit)
+	if err != nil {
+		log.Println("ERROR: Could not run the DB query!" + string(err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]DueWebhookDelivery, 0)
+	for rows.Next() {
+		delivery := DueWebhookDelivery{}
+		err = rows.Scan(&delivery.Id, &delivery.EventType, &delivery.Payload, &delivery.Attempts, &delivery.Url, &delivery.Secret)
+		if err != nil {
+			log.Println("ERROR: Cannot marshal the webhook delivery objects!" + string(err.Error()))
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func MarkWebhookDelivered(id int, statusCode int) error {
+	_, err := DB.Exec(`UPDATE WebhookDeliveries SET DeliveredDateTime = CURRENT_TIMESTAMP, Attempts = Attempts + 1,
+		LastStatusCode = ?, LastError = '' WHERE Id = ?`, statusCode, id)
+	if err != nil {
+		log.Println("ERROR: Cannot mark webhook delivery Id '" + strconv.Itoa(id) + "' as delivered: " + string(err.Error()))
+	}
+	return err
+}
+
+// MarkWebhookFailed Records a failed attempt and when to try again. statusCode
+// is 0 when no response was received.
+func MarkWebhookFailed(id int, statusCode int, reason string, retryIn time.Duration) error {
+	_, err := DB.Exec(`UPDATE WebhookDeliveries SET Attempts = Attempts + 1, LastStatusCode = ?, LastError = ?,
+		NextAttemptDateTime = datetime('now', ?) WHERE Id = ?`,
+		statusCode, reason, "+"+strconv.Itoa(int(retryIn.Seconds()))+" seconds", id)
+	if err != nil {
+		log.Println("ERROR: Cannot record failed attempt for webhook delivery Id '" + strconv.Itoa(id) + "': " + string(err.Error()))
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package model
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"testing"
+)
+
+func TestWebhookDataSendsOnlyPublicFields(t *testing.T) {
+	snapshot := map[string]interface{}{
+		"Id":                  int64(7),
+		"Topic":               "Worldbuilding 101",
+		"Description":         "An introduction",
+		"LocationId":          int64(3),
+		"ScheduledTime":       "2026-10-18T10:00:00Z",
+		"DurationInMinutes":   int64(50),
+		"AgeRestricted":       int64(1),
+		"PanelRequestorEmail": "requestor@example.com",
+		"CreatorId":           int64(2),
+		"ApprovedById":        int64(2),
+		"ApprovalStatus":      "approved",
+	}
+
+	data := webhookData("Panels", snapshot)
+	for _, column := range []string{"PanelRequestorEmail", "CreatorId", "ApprovedById", "ApprovalStatus"} {
+		if _, ok := data[column]; ok {
+			t.Errorf("webhook data contains %s", column)
+		}
+	}
+	if data["topic"] != "Worldbuilding 101" || data["locationId"] != int64(3) || data["ageRestricted"] != true {
+		t.Errorf("webhook data = %v, want the public fields of the panel", data)
+	}
+	if len(data) != len(webhookFields["Panels"]) {
+		t.Errorf("webhook data has %d fields, want %d", len(data), len(webhookFields["Panels"]))
+	}
+}
+
+func TestWebhookDataWithoutRow(t *testing.T) {
+	if data := webhookData("Panels", nil); data != nil {
+		t.Errorf("webhookData(nil) = %v, want nil", data)
+	}
+}
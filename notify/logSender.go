@@ -0,0 +1,55 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// LogSender Writes emails to the log, or to one .eml file each in Dir when it
+// is set, instead of sending them. Meant for development.
+type LogSender struct {
+	Dir  string
+	From string
+}
+
+func (s *LogSender) Send(m Message) error {
+	body, err := formatMessage(s.From, m)
+	if err != nil {
+		return err
+	}
+
+	if s.Dir == "" {
+		log.Println("INFO: Email not sent, mailSender is 'log':\n" + string(body))
+		return nil
+	}
+
+	name := filepath.Join(s.Dir, strconv.FormatInt(time.Now().UnixNano(), 10)+".eml")
+	err = os.WriteFile(name, body, 0o640)
+	if err != nil {
+		return err
+	}
+
+	log.Println("INFO: Email to '" + m.To + "' written to " + name)
+	return nil
+}
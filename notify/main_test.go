@@ -0,0 +1,54 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+// TestMain Runs the notify tests against a freshly migrated database in a
+// temporary directory
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+
+	dir, err := os.MkdirTemp("", "giron-notify-test")
+	if err != nil {
+		panic(err)
+	}
+
+	err = model.ConnectDatabase(filepath.Join(dir, "giron.db"))
+	if err != nil {
+		panic(err)
+	}
+	_, err = model.MigrateUp()
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	model.DB.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,92 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+const (
+	outboxBatchSize = 50
+	maxRetryDelay   = 6 * time.Hour
+)
+
+// Dispatcher Sends the emails queued in the outbox. Failed sends are retried
+// with exponential backoff, starting at a minute, until MaxAttempts is
+// reached; the last error stays on the outbox entry.
+type Dispatcher struct {
+	Sender      Sender
+	Templates   *Templates
+	Interval    time.Duration
+	MaxAttempts int
+}
+
+// Run Flushes the outbox every Interval, forever
+func (d *Dispatcher) Run() {
+	for {
+		d.Flush()
+		time.Sleep(d.Interval)
+	}
+}
+
+// Flush Sends the emails that are due
+func (d *Dispatcher) Flush() {
+	messages, err := model.GetDueEmails(outboxBatchSize, d.MaxAttempts)
+	if err != nil {
+		log.Println("ERROR: Cannot read the email outbox: " + string(err.Error()))
+		return
+	}
+
+	for _, queued := range messages {
+		err = d.send(queued)
+		if err != nil {
+			delay := retryDelay(queued.Attempts)
+			log.Println("WARN: Cannot send email Id '" + strconv.Itoa(queued.Id) + "', retrying in " + delay.String() + ": " + string(err.Error()))
+			model.MarkEmailFailed(queued.Id, string(err.Error()), delay)
+			continue
+		}
+		model.MarkEmailSent(queued.Id)
+	}
+}
+
+func (d *Dispatcher) send(queued model.OutboxMessage) error {
+	m, err := d.Templates.Render(queued.Template, queued.Recipient, queued.Data)
+	if err != nil {
+		return err
+	}
+
+	return d.Sender.Send(m)
+}
+
+// retryDelay One minute after the first failure, doubling with every attempt
+// after that
+func retryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 0; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,215 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+// fakeSender Records the messages it is given, failing the first Failures
+// sends
+type fakeSender struct {
+	Failures int
+	Calls    int
+	Sent     []Message
+}
+
+func (s *fakeSender) Send(m Message) error {
+	s.Calls++
+	if s.Calls <= s.Failures {
+		return errors.New("mail server unavailable")
+	}
+	s.Sent = append(s.Sent, m)
+	return nil
+}
+
+func newTestDispatcher(t *testing.T, sender Sender, maxAttempts int) *Dispatcher {
+	t.Helper()
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "greeting.txt"), []byte("Subject: Hello {{.name}}\n\nYour panel is {{.state}}.\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Dispatcher{Sender: sender, Templates: templates, Interval: time.Second, MaxAttempts: maxAttempts}
+}
+
+// queueTestEmail Empties the outbox and queues a single email
+func queueTestEmail(t *testing.T) int {
+	t.Helper()
+	_, err := model.DB.Exec("DELETE FROM EmailOutbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := model.DB.Exec("INSERT INTO EmailOutbox (Recipient, Template, Data) VALUES (?, ?, ?)",
+		"panelist@example.com", "greeting", `{"name":"Sam","state":"accepted"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := result.LastInsertId()
+	return int(id)
+}
+
+type testEmailState struct {
+	Attempts  int
+	LastError string
+	Sent      bool
+	// seconds from now until the next attempt
+	NextAttemptIn int
+}
+
+func readTestEmail(t *testing.T, id int) testEmailState {
+	t.Helper()
+	var state testEmailState
+	var sent sql.NullString
+	err := model.DB.QueryRow(`SELECT Attempts, LastError, SentDateTime,
+		CAST(strftime('%s', NextAttemptDateTime) AS INTEGER) - CAST(strftime('%s', 'now') AS INTEGER)
+		FROM EmailOutbox WHERE Id = ?`, id).Scan(&state.Attempts, &state.LastError, &sent, &state.NextAttemptIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Sent = sent.Valid
+	return state
+}
+
+// makeTestEmailDue Moves the next attempt into the past, as if the backoff
+// had run out
+func makeTestEmailDue(t *testing.T, id int) {
+	t.Helper()
+	_, err := model.DB.Exec("UPDATE EmailOutbox SET NextAttemptDateTime = datetime('now', '-1 seconds') WHERE Id = ?", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDispatcherSendsQueuedEmail(t *testing.T) {
+	sender := &fakeSender{}
+	dispatcher := newTestDispatcher(t, sender, 3)
+	id := queueTestEmail(t)
+
+	dispatcher.Flush()
+
+	if len(sender.Sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sender.Sent))
+	}
+	want := Message{To: "panelist@example.com", Subject: "Hello Sam", Body: "Your panel is accepted.\n"}
+	if sender.Sent[0] != want {
+		t.Errorf("sent %+v, want %+v", sender.Sent[0], want)
+	}
+	state := readTestEmail(t, id)
+	if !state.Sent || state.Attempts != 1 || state.LastError != "" {
+		t.Errorf("outbox entry = %+v, want sent after 1 attempt", state)
+	}
+
+	// sent emails are not sent again
+	dispatcher.Flush()
+	if sender.Calls != 1 {
+		t.Errorf("sender called %d times after a second flush, want 1", sender.Calls)
+	}
+}
+
+func TestDispatcherRetriesAfterRetryDelay(t *testing.T) {
+	sender := &fakeSender{Failures: 1}
+	dispatcher := newTestDispatcher(t, sender, 3)
+	id := queueTestEmail(t)
+
+	dispatcher.Flush()
+	state := readTestEmail(t, id)
+	if state.Sent || state.Attempts != 1 || state.LastError != "mail server unavailable" {
+		t.Fatalf("outbox entry = %+v, want unsent after 1 failed attempt", state)
+	}
+	wantDelay := int(retryDelay(0).Seconds())
+	if state.NextAttemptIn < wantDelay-5 || state.NextAttemptIn > wantDelay {
+		t.Errorf("next attempt in %ds, want about %ds", state.NextAttemptIn, wantDelay)
+	}
+
+	// not retried before the delay has passed
+	dispatcher.Flush()
+	if sender.Calls != 1 {
+		t.Fatalf("sender called %d times before the retry delay passed, want 1", sender.Calls)
+	}
+
+	makeTestEmailDue(t, id)
+	dispatcher.Flush()
+	state = readTestEmail(t, id)
+	if !state.Sent || state.Attempts != 2 || state.LastError != "" || len(sender.Sent) != 1 {
+		t.Errorf("outbox entry = %+v, want sent on the second attempt", state)
+	}
+}
+
+func TestDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	sender := &fakeSender{Failures: 100}
+	dispatcher := newTestDispatcher(t, sender, 3)
+	id := queueTestEmail(t)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		dispatcher.Flush()
+		state := readTestEmail(t, id)
+		if state.Sent || state.Attempts != attempt {
+			t.Fatalf("outbox entry = %+v, want unsent after %d attempts", state, attempt)
+		}
+		wantDelay := int(retryDelay(attempt - 1).Seconds())
+		if state.NextAttemptIn < wantDelay-5 || state.NextAttemptIn > wantDelay {
+			t.Errorf("attempt %d: next attempt in %ds, want about %ds", attempt, state.NextAttemptIn, wantDelay)
+		}
+		makeTestEmailDue(t, id)
+	}
+
+	dispatcher.Flush()
+	if sender.Calls != 3 {
+		t.Errorf("sender called %d times, want 3 before giving up", sender.Calls)
+	}
+	if state := readTestEmail(t, id); state.Attempts != 3 || state.LastError == "" {
+		t.Errorf("outbox entry = %+v, want 3 attempts and the last error kept", state)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{8, 256 * time.Minute},
+		{9, maxRetryDelay},
+		{50, maxRetryDelay},
+	}
+
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.attempts), func(t *testing.T) {
+			if got := retryDelay(test.attempts); got != test.want {
+				t.Errorf("retryDelay(%d) = %s, want %s", test.attempts, got, test.want)
+			}
+		})
+	}
+}
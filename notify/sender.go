@@ -0,0 +1,95 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/JAFAX/giron-service/globals"
+)
+
+// Message A rendered email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender Delivers rendered emails
+type Sender interface {
+	Send(m Message) error
+}
+
+// NewSender Returns the sender chosen by mailSender in the configuration:
+// "smtp", or "log" (the default) for development
+func NewSender(config globals.Config) (Sender, error) {
+	from := config.MailFrom
+	if from == "" {
+		from = "giron@localhost"
+	}
+
+	switch config.MailSender {
+	case "smtp":
+		if config.SmtpHost == "" {
+			return nil, errors.New("smtpHost must be set to send mail over SMTP")
+		}
+		port := config.SmtpPort
+		if port == 0 {
+			port = 25
+		}
+		return &SMTPSender{
+			Host:     config.SmtpHost,
+			Port:     port,
+			Username: config.SmtpUsername,
+			Password: config.SmtpPassword,
+			From:     from,
+		}, nil
+	case "", "log":
+		return &LogSender{Dir: config.MailDir, From: from}, nil
+	default:
+		return nil, errors.New("unknown mailSender '" + config.MailSender + "'")
+	}
+}
+
+// formatMessage Builds the RFC 5322 text of a message. The recipient must be
+// a valid address, which also keeps header injection out.
+func formatMessage(from string, m Message) ([]byte, error) {
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + to.String() + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", strings.TrimSpace(m.Subject)) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,147 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpSession What the fake SMTP server received in one session
+type smtpSession struct {
+	MailFrom string
+	RcptTo   []string
+	Data     string
+}
+
+// startFakeSMTPServer Listens on a local port and answers a single SMTP
+// session, without STARTTLS or AUTH, handing back what it received
+func startFakeSMTPServer(t *testing.T) (int, <-chan smtpSession) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		var session smtpSession
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP fake")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				text.PrintfLine("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				session.MailFrom = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				text.PrintfLine("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				session.RcptTo = append(session.RcptTo, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				text.PrintfLine("250 OK")
+			case command == "DATA":
+				text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				session.Data = string(data)
+				text.PrintfLine("250 OK")
+			case command == "QUIT":
+				text.PrintfLine("221 Bye")
+				sessions <- session
+				return
+			default:
+				text.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, sessions
+}
+
+func TestSMTPSenderSendsMessage(t *testing.T) {
+	port, sessions := startFakeSMTPServer(t)
+	sender := &SMTPSender{Host: "127.0.0.1", Port: port, From: "Giron <giron@example.com>"}
+
+	err := sender.Send(Message{
+		To:      "Jane Doe <jane@example.com>",
+		Subject: "Café panel accepted",
+		Body:    "Hello Jane,\nyour panel was accepted.",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var session smtpSession
+	select {
+	case session = <-sessions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the fake SMTP server did not see the session end")
+	}
+
+	if session.MailFrom != "giron@example.com" {
+		t.Errorf("MAIL FROM = %q, want giron@example.com", session.MailFrom)
+	}
+	if len(session.RcptTo) != 1 || session.RcptTo[0] != "jane@example.com" {
+		t.Errorf("RCPT TO = %q, want [jane@example.com]", session.RcptTo)
+	}
+	headers, body, found := strings.Cut(session.Data, "\n\n")
+	if !found {
+		t.Fatalf("message has no blank line between headers and body: %q", session.Data)
+	}
+	for _, header := range []string{
+		"From: Giron <giron@example.com>",
+		`To: "Jane Doe" <jane@example.com>`,
+		"Subject: =?utf-8?q?Caf=C3=A9_panel_accepted?=",
+		"Content-Type: text/plain; charset=utf-8",
+	} {
+		if !strings.Contains(headers, header+"\n") {
+			t.Errorf("headers are missing %q:\n%s", header, headers)
+		}
+	}
+	if body != "Hello Jane,\nyour panel was accepted.\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestSMTPSenderRejectsBadRecipient(t *testing.T) {
+	port, _ := startFakeSMTPServer(t)
+	sender := &SMTPSender{Host: "127.0.0.1", Port: port, From: "giron@example.com"}
+
+	err := sender.Send(Message{To: "jane@example.com\r\nBcc: everyone@example.com", Subject: "Hi", Body: "Hi"})
+	if err == nil {
+		t.Error("Send() to a recipient with an injected header succeeded")
+	}
+}
@@ -0,0 +1,62 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Templates The email templates, one <name>.txt file each. A template starts
+// with a "Subject: " line and a blank line, followed by the body.
+type Templates struct {
+	set *template.Template
+}
+
+func LoadTemplates(dir string) (*Templates, error) {
+	set, err := template.ParseGlob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Templates{set: set}, nil
+}
+
+// Render Fills in the named template for the given recipient
+func (t *Templates) Render(name string, to string, data map[string]string) (Message, error) {
+	var buf bytes.Buffer
+	err := t.set.ExecuteTemplate(&buf, name+".txt", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	header, body, found := strings.Cut(buf.String(), "\n\n")
+	if !found || !strings.HasPrefix(header, "Subject: ") || strings.Contains(header, "\n") {
+		return Message{}, errors.New("template '" + name + "' does not start with a single Subject line")
+	}
+
+	return Message{
+		To:      to,
+		Subject: strings.TrimPrefix(header, "Subject: "),
+		Body:    body,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+const (
+	webhookBatchSize = 50
+	webhookTimeout   = 10 * time.Second
+	// bytes of a failed response body kept as the delivery's last error
+	webhookErrorBodyLimit = 512
+)
+
+// WebhookDispatcher Posts the deliveries queued for webhooks. Each request
+// carries the event type and delivery Id in headers, and an HMAC-SHA256 of
+// the body keyed with the webhook's secret in X-Giron-Signature, so
+// receivers can check it came from us. Any 2xx response counts as delivered;
+// anything else is retried with the same backoff as email until MaxAttempts
+// is reached.
+type WebhookDispatcher struct {
+	Client      *http.Client
+	Interval    time.Duration
+	MaxAttempts int
+}
+
+func NewWebhookDispatcher(interval time.Duration, maxAttempts int) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		Client:      &http.Client{Timeout: webhookTimeout},
+		Interval:    interval,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+// Run Posts due deliveries every Interval, forever
+func (d *WebhookDispatcher) Run() {
+	for {
+		d.Flush()
+		time.Sleep(d.Interval)
+	}
+}
+
+// Flush Posts the deliveries that are due
+func (d *WebhookDispatcher) Flush() {
+	deliveries, err := model.GetDueWebhookDeliveries(webhookBatchSize, d.MaxAttempts)
+	if err != nil {
+		log.Println("ERROR: Cannot read the webhook delivery queue: " + string(err.Error()))
+		return
+	}
+
+	for _, delivery := range deliveries {
+		statusCode, err := d.post(delivery)
+		if err != nil {
+			delay := retryDelay(delivery.Attempts)
+			log.Println("WARN: Cannot deliver webhook delivery Id '" + strconv.Itoa(delivery.Id) + "', retrying in " + delay.String() + ": " + string(err.Error()))
+			model.MarkWebhookFailed(delivery.Id, statusCode, string(err.Error()), delay)
+			continue
+		}
+		model.MarkWebhookDelivered(delivery.Id, statusCode)
+	}
+}
+
+// SignWebhookPayload Returns the X-Giron-Signature header value for a body
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *WebhookDispatcher) post(delivery model.DueWebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "giron-service-webhooks")
+	req.Header.Set("X-Giron-Event", delivery.EventType)
+	req.Header.Set("X-Giron-Delivery", strconv.Itoa(delivery.Id))
+	req.Header.Set("X-Giron-Signature", SignWebhookPayload(delivery.Secret, body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+		return resp.StatusCode, errors.New(resp.Status + ": " + string(excerpt))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,180 @@
+package notify
+
+/*
+
+  Copyright 2024, JAFAX, Inc.
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JAFAX/giron-service/model"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// queueTestDelivery Replaces every webhook with one posting to url and queues
+// a single delivery for it
+func queueTestDelivery(t *testing.T, url string) int {
+	t.Helper()
+	for _, statement := range []string{"DELETE FROM WebhookDeliveries", "DELETE FROM WebhookEvents", "DELETE FROM Webhooks"} {
+		_, err := model.DB.Exec(statement)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := model.DB.Exec(`INSERT INTO Webhooks (Url, Secret, CreatorId)
+		SELECT ?, ?, Id FROM Users WHERE UserName = 'panel-intake'`, url, testWebhookSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhookId, _ := result.LastInsertId()
+
+	result, err = model.DB.Exec("INSERT INTO WebhookDeliveries (WebhookId, EventType, Payload) VALUES (?, ?, ?)",
+		webhookId, model.WebhookPanelScheduled, `{"event":"panel.scheduled","id":1,"data":{"Id":1}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := result.LastInsertId()
+	return int(id)
+}
+
+type testDeliveryState struct {
+	Attempts       int
+	LastStatusCode int
+	Delivered      bool
+	// seconds from now until the next attempt
+	NextAttemptIn int
+}
+
+func readTestDelivery(t *testing.T, id int) testDeliveryState {
+	t.Helper()
+	var state testDeliveryState
+	var delivered sql.NullString
+	err := model.DB.QueryRow(`SELECT Attempts, LastStatusCode, DeliveredDateTime,
+		CAST(strftime('%s', NextAttemptDateTime) AS INTEGER) - CAST(strftime('%s', 'now') AS INTEGER)
+		FROM WebhookDeliveries WHERE Id = ?`, id).Scan(&state.Attempts, &state.LastStatusCode, &delivered, &state.NextAttemptIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Delivered = delivered.Valid
+	return state
+}
+
+// makeTestDeliveryDue Moves the next attempt into the past, as if the backoff
+// had run out
+func makeTestDeliveryDue(t *testing.T, id int) {
+	t.Helper()
+	_, err := model.DB.Exec("UPDATE WebhookDeliveries SET NextAttemptDateTime = datetime('now', '-1 seconds') WHERE Id = ?", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookDispatcherSignsDeliveries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body, _ := io.ReadAll(r.Body)
+
+		if got, want := r.Header.Get("X-Giron-Signature"), SignWebhookPayload(testWebhookSecret, body); got != want {
+			t.Errorf("X-Giron-Signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get("X-Giron-Event"); got != model.WebhookPanelScheduled {
+			t.Errorf("X-Giron-Event = %q, want %q", got, model.WebhookPanelScheduled)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	id := queueTestDelivery(t, server.URL)
+	dispatcher := NewWebhookDispatcher(time.Second, 3)
+	dispatcher.Flush()
+
+	if requests != 1 {
+		t.Fatalf("receiver got %d requests, want 1", requests)
+	}
+	state := readTestDelivery(t, id)
+	if !state.Delivered || state.Attempts != 1 || state.LastStatusCode != http.StatusNoContent {
+		t.Errorf("delivery = %+v, want delivered after 1 attempt with status 204", state)
+	}
+
+	// delivered deliveries are not posted again
+	dispatcher.Flush()
+	if requests != 1 {
+		t.Errorf("receiver got %d requests after a second flush, want 1", requests)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '{"event":"panel.deleted"}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	want := "sha256=12093e5499b17497a6e3ea3bff2aeefce9f72b4183affa789ebf300a9dd58897"
+	if got := SignWebhookPayload(testWebhookSecret, []byte(`{"event":"panel.deleted"}`)); got != want {
+		t.Errorf("SignWebhookPayload = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookDispatcherRetriesWithBackoff(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.Error(w, "receiver is down", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	id := queueTestDelivery(t, server.URL)
+	dispatcher := NewWebhookDispatcher(time.Second, 3)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		dispatcher.Flush()
+		if int(requests) != attempt {
+			t.Fatalf("receiver got %d requests, want %d", requests, attempt)
+		}
+
+		state := readTestDelivery(t, id)
+		if state.Delivered || state.Attempts != attempt || state.LastStatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("delivery = %+v, want undelivered after %d attempts with status 503", state, attempt)
+		}
+		// one minute after the first failure, doubling after that
+		wantDelay := int(retryDelay(attempt - 1).Seconds())
+		if state.NextAttemptIn < wantDelay-5 || state.NextAttemptIn > wantDelay {
+			t.Errorf("attempt %d: next attempt in %ds, want about %ds", attempt, state.NextAttemptIn, wantDelay)
+		}
+
+		// not due again until the backoff has passed
+		dispatcher.Flush()
+		if int(requests) != attempt {
+			t.Fatalf("attempt %d: delivery was retried before its backoff passed", attempt)
+		}
+		makeTestDeliveryDue(t, id)
+	}
+
+	// MaxAttempts reached: the delivery is given up on
+	dispatcher.Flush()
+	if requests != 3 {
+		t.Errorf("receiver got %d requests, want 3 after giving up", requests)
+	}
+}
@@ -22,13 +22,19 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/JAFAX/giron-service/controllers"
+	"github.com/JAFAX/giron-service/middleware"
 )
 
 func FePublicRoutes(g *gin.RouterGroup, i *controllers.GironService) {
 	// login page
-	g.GET("/", i.LoginUI)           // login UI
-	g.POST("/login", i.LoginUIPost) // the actual action of logging a person in
-	g.GET("/logout")                // log out UI
+	g.GET("/", i.LoginUI)                            // login UI
+	g.POST("/login", i.LoginUIPost)                  // the actual action of logging a person in
+	g.POST("/login/twoFactor", i.LoginTwoFactorPost) // second step of a login for users with two-factor authentication
+	g.GET("/logout")                                 // log out UI
+	// panel proposals from the public
+	g.GET("/propose", i.ProposalUI)              // panel proposal form
+	g.POST("/propose", i.ProposalUIPost)         // send a panel proposal
+	g.GET("/propose/verify", i.VerifyProposalUI) // confirm a proposal from the emailed link
 }
 
 func FePrivateRoutes(g *gin.RouterGroup, i *controllers.GironService) {
@@ -38,73 +44,188 @@ func FePrivateRoutes(g *gin.RouterGroup, i *controllers.GironService) {
 
 func PublicRoutes(g *gin.RouterGroup, i *controllers.GironService) {
 	g.GET("/health", i.GetHealth) // service health
+
+	// read-only schedule for attendees, cacheable by devices and proxies
+	cache := middleware.PublicCache(i.ConfStruct.PublicCacheSeconds)
+	g.GET("/schedule.ics", cache, i.GetScheduleCalendar)            // iCalendar feed of the schedule
+	g.GET("/public/panels", cache, i.GetPublicPanels)               // get all published panels
+	g.GET("/public/panel/:id", cache, i.GetPublicPanelById)         // get a published panel
+	g.GET("/public/screenings", cache, i.GetPublicScreenings)       // get all published screenings
+	g.GET("/public/screening/:id", cache, i.GetPublicScreeningById) // get a published screening
+	g.GET("/public/locations", cache, i.GetPublicLocations)         // get all locations
+	g.GET("/public/buildings", cache, i.GetPublicBuildings)         // get all buildings
+	g.GET("/public/tags", cache, i.GetPublicTags)                   // get all tags
+	g.GET("/public/sync", cache, i.GetPublicSyncChanges)            // get what changed in the published schedule since a sync cursor
+
+	g.POST("/public/proposal", i.SubmitPanelProposal) // propose a panel
 }
 
 func PrivateRoutes(g *gin.RouterGroup, i *controllers.GironService) {
+	// audit trail
+	g.GET("/audit", middleware.RequirePrivilege("audit.read"), i.GetAuditEntries) // get audit entries
+	// reports
+	g.GET("/reports/topRated", middleware.RequirePrivilege("reports.read"), i.GetTopRatedEvents) // get the top-rated events per room or track
+	// live schedule changes for room displays and apps
+	g.GET("/schedule/stream", middleware.AllowApiTokens, i.GetScheduleStream) // stream schedule changes as Server-Sent Events
+	// delta sync for offline clients
+	g.GET("/sync", middleware.AllowApiTokens, i.GetSyncChanges) // get what changed since a sync cursor
 	// building related routes
-	g.GET("/buildings", i.GetBuildings)             // get all buildings
-	g.GET("/building/:id", i.GetBuildingById)       // get building by Id
-	g.POST("/building", i.CreateBuilding)           // create a new building
-	g.PATCH("/building/:id", i.UpdateBuildingById)  // update a building
-	g.DELETE("/building/:id", i.DeleteBuildingById) // delete a building
+	g.GET("/buildings", middleware.AllowApiTokens, i.GetBuildings)                               // get all buildings
+	g.GET("/building/:id", middleware.AllowApiTokens, i.GetBuildingById)                         // get building by Id
+	g.POST("/building", middleware.RequirePrivilege("venue.manage"), i.CreateBuilding)           // create a new building
+	g.PATCH("/building/:id", middleware.RequirePrivilege("venue.manage"), i.UpdateBuildingById)  // update a building
+	g.DELETE("/building/:id", middleware.RequirePrivilege("venue.manage"), i.DeleteBuildingById) // delete a building
 	// floor related routes
-	g.GET("/floors", i.GetAllFloors)                         // get all floor records
-	g.GET("/floors/buildingId/:id", i.GetFloorsByBuildingId) // get all floors in a building
-	g.GET("/floor/:id", i.GetFloorById)                      // get floor by Id
-	g.POST("/floor", i.CreateFloor)                          // create a new floor in a building
-	g.PATCH("/floor/:id", i.UpdateFloorById)                 // update a floor by its Id
-	g.DELETE("/floor/:id", i.DeleteFloorById)                // delete a floor by its Id
+	g.GET("/floors", middleware.AllowApiTokens, i.GetAllFloors)                            // get all floor records
+	g.GET("/floors/buildingId/:id", middleware.AllowApiTokens, i.GetFloorsByBuildingId)    // get all floors in a building
+	g.GET("/floor/:id", middleware.AllowApiTokens, i.GetFloorById)                         // get floor by Id
+	g.POST("/floor", middleware.RequirePrivilege("venue.manage"), i.CreateFloor)           // create a new floor in a building
+	g.PATCH("/floor/:id", middleware.RequirePrivilege("venue.manage"), i.UpdateFloorById)  // update a floor by its Id
+	g.DELETE("/floor/:id", middleware.RequirePrivilege("venue.manage"), i.DeleteFloorById) // delete a floor by its Id
 	// location related routes
-	g.GET("/locations", i.GetAllLocations)                           // get all locations at the event
-	g.GET("/locations/byFloorId/:id", i.GetLocationsByFloorId)       // get locations by the floor id
-	g.GET("/locations/byBuildingId/:id", i.GetLocationsByBuildingId) // get locations by building id
-	g.GET("/location/:id", i.GetLocationById)                        // get location by id
-	g.POST("/location", i.CreateLocation)                            // create locations in the building
-	g.PATCH("/location/:id", i.UpdateLocationById)                   // update locations in the building by id
-	g.DELETE("/location/:id", i.DeleteLocationById)                  // delete a location by id
+	g.GET("/locations", middleware.AllowApiTokens, i.GetAllLocations)                            // get all locations at the event
+	g.GET("/locations/byFloorId/:id", middleware.AllowApiTokens, i.GetLocationsByFloorId)        // get locations by the floor id
+	g.GET("/locations/byBuildingId/:id", middleware.AllowApiTokens, i.GetLocationsByBuildingId)  // get locations by building id
+	g.GET("/location/:id", middleware.AllowApiTokens, i.GetLocationById)                         // get location by id
+	g.POST("/location", middleware.RequirePrivilege("venue.manage"), i.CreateLocation)           // create locations in the building
+	g.PATCH("/location/:id", middleware.RequirePrivilege("venue.manage"), i.UpdateLocationById)  // update locations in the building by id
+	g.DELETE("/location/:id", middleware.RequirePrivilege("venue.manage"), i.DeleteLocationById) // delete a location by id
 	// panel related routes
-	g.GET("/panels", i.GetApprovedPanels)                         // get all approved panels
-	g.GET("/panels/ByLocationId/:id", i.GetPanelsByLocationId)    // get all approved panels by location ID
-	g.GET("/panel/:id", i.GetPanelById)                           // get panel details
-	g.GET("/panel/:id/location", i.GetPanelLocationByPanelId)     // get the location of a panel
-	g.GET("/panel/:id/schedule", i.GetPanelScheduleByPanelId)     // get the time and date of a panel
-	g.GET("/panel/:id/tags")                                      // get a list of tags associated with a panel
-	g.GET("/panels/all", i.GetPanels)                             // get all panels
-	g.POST("/panel", i.CreatePanel)                               // create a new panel event
-	g.POST("/panel/:id/location", i.SetPanelLocation)             // set/update the location of a panel
-	g.POST("/panel/:id/schedule", i.SetPanelScheduledTimeById)    // set/update the time and date of a panel
-	g.POST("/panel/:id/approve", i.SetApprovalStatusPanelById)    // approve a panel
-	g.POST("/panel/:id/restricted", i.SetPanelAgeRestrictionById) // set whether the panel is age restricted
-	g.POST("/panel/:id/assignTag")                                // assign a tag to a panel
-	g.PATCH("/panel/:id/unassignTag")                             // unassign a tag to a panel
-	g.DELETE("/panel/:id", i.DeletePanelById)                     // delete a panel
+	g.GET("/panels", middleware.AllowApiTokens, i.GetApprovedPanels)                                                     // get all approved panels
+	g.GET("/panels/ByLocationId/:id", middleware.AllowApiTokens, i.GetPanelsByLocationId)                                // get all approved panels by location ID
+	g.GET("/panel/:id", middleware.AllowApiTokens, i.GetPanelById)                                                       // get panel details
+	g.GET("/panel/:id/location", middleware.AllowApiTokens, i.GetPanelLocationByPanelId)                                 // get the location of a panel
+	g.GET("/panel/:id/schedule", middleware.AllowApiTokens, i.GetPanelScheduleByPanelId)                                 // get the time and date of a panel
+	g.GET("/panel/:id/tags", middleware.AllowApiTokens, i.GetPanelTagsByPanelId)                                         // get a list of tags associated with a panel
+	g.GET("/panel/:id/panelists", middleware.AllowApiTokens, i.GetPanelistsByPanelId)                                    // get the panelists of a panel
+	g.GET("/panels/ByPanelistEmail/:email", middleware.AllowApiTokens, i.GetPanelsByPanelistEmail)                       // get all panels a panelist is on
+	g.GET("/panel/:id/rating", middleware.AllowApiTokens, i.GetPanelRating)                                              // get the average rating of a panel
+	g.GET("/panels/all", middleware.AllowApiTokens, i.GetPanels)                                                         // get all panels
+	g.POST("/panel", middleware.RequirePrivilege("panels.manage"), i.CreatePanel)                                        // create a new panel event
+	g.POST("/panel/:id/location", middleware.RequirePrivilege("panels.manage"), i.SetPanelLocation)                      // set/update the location of a panel
+	g.POST("/panel/:id/schedule", middleware.RequirePrivilege("panels.manage"), i.SetPanelScheduledTimeById)             // set/update the time and date of a panel
+	g.POST("/panel/:id/approve", middleware.RequirePrivilege("panels.approve"), i.SetApprovalStatusPanelById)            // approve a panel
+	g.POST("/panel/:id/state", middleware.RequirePrivilege("panels.approve"), i.SetPanelStateById)                       // move a panel along its review lifecycle
+	g.POST("/panel/:id/comment", middleware.RequirePrivilege("panels.approve"), i.AddPanelReviewComment)                 // add a reviewer comment to a panel
+	g.GET("/panel/:id/proposal", middleware.RequirePrivilege("panels.manage"), i.GetPanelProposalByPanelId)              // get the contact details of whoever proposed a panel
+	g.GET("/panel/:id/review", middleware.RequirePrivilege("panels.approve"), i.GetPanelReviewLog)                       // get the state changes and reviewer comments of a panel
+	g.POST("/panel/:id/restricted", middleware.RequirePrivilege("panels.manage"), i.SetPanelAgeRestrictionById)          // set whether the panel is age restricted
+	g.POST("/panel/:id/assignTag", middleware.RequirePrivilege("panels.manage"), i.AssignTagToPanel)                     // assign a tag to a panel
+	g.PATCH("/panel/:id/unassignTag", middleware.RequirePrivilege("panels.manage"), i.UnassignTagFromPanel)              // unassign a tag to a panel
+	g.POST("/panel/:id/panelist", middleware.RequirePrivilege("panels.manage"), i.AddPanelistToPanel)                    // add a panelist to a panel
+	g.DELETE("/panel/:id/panelist/:panelistId", middleware.RequirePrivilege("panels.manage"), i.RemovePanelistFromPanel) // remove a panelist from a panel
+	g.POST("/panel/:id/rating", i.RatePanel)                                                                             // rate a panel
+	g.DELETE("/panel/:id", middleware.RequirePrivilege("panels.manage"), i.DeletePanelById)                              // delete a panel
 	// screening related routes
-	g.GET("/screenings")                  // get all screenings
-	g.GET("/screenings/ByLocationId/:id") // get all screenings by location ID
-	g.GET("/screening/:id")               // get screening details
-	g.GET("/screening/:id/location")      // get the location of a screening
-	g.GET("/screening/:id/schedule")      // get the time and date of a screening
-	g.GET("/screening/:id/tags")          // get a list of tags associated with a screening
-	g.POST("/screening")                  // create a new screening event
-	g.POST("/screening/:id/location")     // set/update the location of a screening
-	g.POST("/screening/:id/schedule")     // set/update the time and date of a screening
-	g.POST("/screening/:id/restricted")   // set/update whether a screening is age restricted
-	g.POST("/screening/:id/assignTag")    // assign a tag to a screening
-	g.PATCH("/screening/:id/unassignTag") // unassign a tag to a screening
-	g.DELETE("/screening/:id")            // delete a screening
+	g.GET("/screenings", middleware.AllowApiTokens, i.GetScreenings)                                                        // get all screenings
+	g.GET("/screenings/ByLocationId/:id", middleware.AllowApiTokens, i.GetScreeningsByLocationId)                           // get all screenings by location ID
+	g.GET("/screening/:id", middleware.AllowApiTokens, i.GetScreeningById)                                                  // get screening details
+	g.GET("/screening/:id/location", middleware.AllowApiTokens, i.GetScreeningLocationByScreeningId)                        // get the location of a screening
+	g.GET("/screening/:id/schedule", middleware.AllowApiTokens, i.GetScreeningScheduleByScreeningId)                        // get the time and date of a screening
+	g.GET("/screening/:id/tags", middleware.AllowApiTokens, i.GetScreeningTagsByScreeningId)                                // get a list of tags associated with a screening
+	g.GET("/screening/:id/rating", middleware.AllowApiTokens, i.GetScreeningRating)                                         // get the average rating of a screening
+	g.POST("/screening", middleware.RequirePrivilege("screenings.manage"), i.CreateScreening)                               // create a new screening event
+	g.POST("/screening/:id/location", middleware.RequirePrivilege("screenings.manage"), i.SetScreeningLocation)             // set/update the location of a screening
+	g.POST("/screening/:id/schedule", middleware.RequirePrivilege("screenings.manage"), i.SetScreeningScheduledTimeById)    // set/update the time and date of a screening
+	g.POST("/screening/:id/restricted", middleware.RequirePrivilege("screenings.manage"), i.SetScreeningAgeRestrictionById) // set/update whether a screening is age restricted
+	g.POST("/screening/:id/assignTag", middleware.RequirePrivilege("screenings.manage"), i.AssignTagToScreening)            // assign a tag to a screening
+	g.PATCH("/screening/:id/unassignTag", middleware.RequirePrivilege("screenings.manage"), i.UnassignTagFromScreening)     // unassign a tag to a screening
+	g.POST("/screening/:id/rating", i.RateScreening)                                                                        // rate a screening
+	g.DELETE("/screening/:id", middleware.RequirePrivilege("screenings.manage"), i.DeleteScreeningById)                     // delete a screening
+	// live event related routes
+	g.GET("/liveEvents", middleware.AllowApiTokens, i.GetApprovedLiveEvents)                                                // get all approved live events
+	g.GET("/liveEvents/all", middleware.AllowApiTokens, i.GetLiveEvents)                                                    // get all live events
+	g.GET("/liveEvent/:id", middleware.AllowApiTokens, i.GetLiveEventById)                                                  // get live event details
+	g.GET("/liveEvent/:id/tags", middleware.AllowApiTokens, i.GetLiveEventTagsByLiveEventId)                                // get a list of tags associated with a live event
+	g.GET("/liveEvent/:id/rating", middleware.AllowApiTokens, i.GetLiveEventRating)                                         // get the average rating of a live event
+	g.POST("/liveEvent", middleware.RequirePrivilege("liveevents.manage"), i.CreateLiveEvent)                               // create a new live event
+	g.PATCH("/liveEvent/:id", middleware.RequirePrivilege("liveevents.manage"), i.UpdateLiveEventById)                      // update a live event
+	g.POST("/liveEvent/:id/location", middleware.RequirePrivilege("liveevents.manage"), i.SetLiveEventLocation)             // set/update the location of a live event
+	g.POST("/liveEvent/:id/schedule", middleware.RequirePrivilege("liveevents.manage"), i.SetLiveEventScheduledTimeById)    // set/update the time and date of a live event
+	g.POST("/liveEvent/:id/approve", middleware.RequirePrivilege("liveevents.approve"), i.SetApprovalStatusLiveEventById)   // approve a live event
+	g.POST("/liveEvent/:id/restricted", middleware.RequirePrivilege("liveevents.manage"), i.SetLiveEventAgeRestrictionById) // set whether the live event is age restricted
+	g.POST("/liveEvent/:id/assignTag", middleware.RequirePrivilege("liveevents.manage"), i.AssignTagToLiveEvent)            // assign a tag to a live event
+	g.PATCH("/liveEvent/:id/unassignTag", middleware.RequirePrivilege("liveevents.manage"), i.UnassignTagFromLiveEvent)     // unassign a tag to a live event
+	g.POST("/liveEvent/:id/rating", i.RateLiveEvent)                                                                        // rate a live event
+	g.DELETE("/liveEvent/:id", middleware.RequirePrivilege("liveevents.manage"), i.DeleteLiveEventById)                     // delete a live event
+	// vendor related routes
+	g.GET("/vendors", middleware.RequirePrivilege("vendors.manage"), i.GetVendors)                              // get all vendors
+	g.GET("/vendor/:id", middleware.RequirePrivilege("vendors.manage"), i.GetVendorById)                        // get vendor details
+	g.POST("/vendor", middleware.RequirePrivilege("vendors.manage"), i.CreateVendor)                            // create a new vendor
+	g.PATCH("/vendor/:id", middleware.RequirePrivilege("vendors.manage"), i.UpdateVendorById)                   // update a vendor
+	g.POST("/vendor/:id/approve", middleware.RequirePrivilege("vendors.approve"), i.SetApprovalStateVendorById) // approve a vendor
+	g.POST("/vendor/:id/invoice", middleware.RequirePrivilege("vendors.manage"), i.SetVendorInvoiceState)       // mark a vendor as invoiced or fulfilled
+	g.POST("/vendor/:id/booth", middleware.RequirePrivilege("vendors.manage"), i.AssignVendorBooth)             // assign a booth to a vendor
+	g.DELETE("/vendor/:id/booth", middleware.RequirePrivilege("vendors.manage"), i.UnassignVendorBooth)         // free the booth of a vendor
+	g.DELETE("/vendor/:id", middleware.RequirePrivilege("vendors.manage"), i.DeleteVendorById)                  // delete a vendor
+	// artist related routes
+	g.GET("/artists", middleware.RequirePrivilege("vendors.manage"), i.GetArtists)                              // get all artists
+	g.GET("/artist/:id", middleware.RequirePrivilege("vendors.manage"), i.GetArtistById)                        // get artist details
+	g.POST("/artist", middleware.RequirePrivilege("vendors.manage"), i.CreateArtist)                            // create a new artist
+	g.PATCH("/artist/:id", middleware.RequirePrivilege("vendors.manage"), i.UpdateArtistById)                   // update an artist
+	g.POST("/artist/:id/approve", middleware.RequirePrivilege("vendors.approve"), i.SetApprovalStateArtistById) // approve an artist
+	g.POST("/artist/:id/invoice", middleware.RequirePrivilege("vendors.manage"), i.SetArtistInvoiceState)       // mark an artist as invoiced or fulfilled
+	g.POST("/artist/:id/booth", middleware.RequirePrivilege("vendors.manage"), i.AssignArtistBooth)             // assign a booth to an artist
+	g.DELETE("/artist/:id/booth", middleware.RequirePrivilege("vendors.manage"), i.UnassignArtistBooth)         // free the booth of an artist
+	g.DELETE("/artist/:id", middleware.RequirePrivilege("vendors.manage"), i.DeleteArtistById)                  // delete an artist
+	// exhibitor related routes
+	g.GET("/exhibitors", middleware.RequirePrivilege("vendors.manage"), i.GetExhibitors)                              // get all exhibitors
+	g.GET("/exhibitor/:id", middleware.RequirePrivilege("vendors.manage"), i.GetExhibitorById)                        // get exhibitor details
+	g.POST("/exhibitor", middleware.RequirePrivilege("vendors.manage"), i.CreateExhibitor)                            // create a new exhibitor
+	g.PATCH("/exhibitor/:id", middleware.RequirePrivilege("vendors.manage"), i.UpdateExhibitorById)                   // update an exhibitor
+	g.POST("/exhibitor/:id/approve", middleware.RequirePrivilege("vendors.approve"), i.SetApprovalStateExhibitorById) // approve an exhibitor
+	g.POST("/exhibitor/:id/invoice", middleware.RequirePrivilege("vendors.manage"), i.SetExhibitorInvoiceState)       // mark an exhibitor as invoiced or fulfilled
+	g.DELETE("/exhibitor/:id", middleware.RequirePrivilege("vendors.manage"), i.DeleteExhibitorById)                  // delete an exhibitor
+	// booth related routes
+	g.GET("/booths", middleware.RequirePrivilege("vendors.manage"), i.GetBooths)             // get all booths
+	g.GET("/booth/:id", middleware.RequirePrivilege("vendors.manage"), i.GetBoothById)       // get booth details
+	g.POST("/booth", middleware.RequirePrivilege("vendors.manage"), i.CreateBooth)           // create a new booth
+	g.PATCH("/booth/:id", middleware.RequirePrivilege("vendors.manage"), i.UpdateBoothById)  // update a booth
+	g.DELETE("/booth/:id", middleware.RequirePrivilege("vendors.manage"), i.DeleteBoothById) // delete a booth
+	// webhook related routes
+	g.GET("/webhooks", middleware.RequirePrivilege("webhooks.manage"), i.GetWebhooks)                        // get all webhooks
+	g.GET("/webhook/:id", middleware.RequirePrivilege("webhooks.manage"), i.GetWebhookById)                  // get webhook details
+	g.GET("/webhook/:id/deliveries", middleware.RequirePrivilege("webhooks.manage"), i.GetWebhookDeliveries) // get the delivery log of a webhook
+	g.POST("/webhook", middleware.RequirePrivilege("webhooks.manage"), i.CreateWebhook)                      // subscribe a URL to events
+	g.PATCH("/webhook/:id", middleware.RequirePrivilege("webhooks.manage"), i.UpdateWebhookById)             // update a webhook
+	g.DELETE("/webhook/:id", middleware.RequirePrivilege("webhooks.manage"), i.DeleteWebhookById)            // delete a webhook
 	// tag related routes
-	g.GET("/tags")       // get all tags
-	g.GET("/tag/:id")    // get tag details
-	g.POST("/tag")       // create a new tag
-	g.PATCH("/tag/:id")  // update a new tag
-	g.DELETE("/tag/:id") // delete a tag
+	g.GET("/tags", middleware.AllowApiTokens, i.GetTags)                              // get all tags
+	g.GET("/tag/:id", middleware.AllowApiTokens, i.GetTagById)                        // get tag details
+	g.POST("/tag", middleware.RequirePrivilege("tags.manage"), i.CreateTag)           // create a new tag
+	g.PATCH("/tag/:id", middleware.RequirePrivilege("tags.manage"), i.UpdateTagById)  // update a tag
+	g.DELETE("/tag/:id", middleware.RequirePrivilege("tags.manage"), i.DeleteTagById) // delete a tag
+	// role related routes
+	g.GET("/privileges", middleware.RequirePrivilege("users.admin"), i.GetPrivileges)                               // get all privileges
+	g.GET("/roles", middleware.RequirePrivilege("users.admin"), i.GetRoles)                                         // get all roles
+	g.GET("/role/:id", middleware.RequirePrivilege("users.admin"), i.GetRoleById)                                   // get role details
+	g.POST("/role", middleware.RequirePrivilege("users.admin"), i.CreateRole)                                       // create a new role
+	g.POST("/role/:id/assignPrivilege", middleware.RequirePrivilege("users.admin"), i.AssignPrivilegeToRole)        // grant a privilege to a role
+	g.PATCH("/role/:id", middleware.RequirePrivilege("users.admin"), i.UpdateRoleById)                              // update a role
+	g.PATCH("/role/:id/unassignPrivilege", middleware.RequirePrivilege("users.admin"), i.UnassignPrivilegeFromRole) // revoke a privilege from a role
+	g.DELETE("/role/:id", middleware.RequirePrivilege("users.admin"), i.DeleteRoleById)                             // delete a role
+	g.PATCH("/role/:id/twoFactor", middleware.RequirePrivilege("users.admin"), i.SetRoleTwoFactorPolicy)            // require two-factor authentication for a role
+	// API token related routes
+	g.GET("/tokens", middleware.AllowApiTokens, i.GetApiTokens)             // get the session user's API tokens
+	g.POST("/token", i.CreateApiToken)                                      // create an API token for the session user
+	g.DELETE("/token/:id", middleware.AllowApiTokens, i.DeleteApiTokenById) // revoke one of the session user's API tokens
+	// two-factor authentication routes
+	g.GET("/twoFactor", middleware.AllowApiTokens, i.GetTwoFactorStatus) // get the session user's two-factor status
+	g.POST("/twoFactor", i.BeginTwoFactorEnrollment)                     // start two-factor enrollment
+	g.POST("/twoFactor/confirm", i.ConfirmTwoFactorEnrollment)           // finish two-factor enrollment
+	g.POST("/twoFactor/recoveryCodes", i.RegenerateRecoveryCodes)        // replace the session user's recovery codes
+	g.POST("/twoFactor/disable", i.DisableTwoFactor)                     // turn off two-factor authentication
 	// user related routes
-	g.GET("/user/id/:id", i.GetUserById)            // get user by id
-	g.GET("/user/name/:name", i.GetUserByUserName)  // get user by username
-	g.GET("/user/:name/status", i.GetUserStatus)    // get whether a user is locked or not
-	g.GET("/users", i.GetUsers)                     // get users
-	g.POST("/user", i.CreateUser)                   // create new user
-	g.PATCH("/user/:name", i.ChangeAccountPassword) // update a user password
-	g.PATCH("/user/:name/status", i.SetUserStatus)  // lock a user
-	g.DELETE("/user/:name", i.DeleteUser)           // trash a user
+	g.GET("/user/id/:id", i.GetUserById)                                                                    // get user by id
+	g.GET("/user/name/:name", i.GetUserByUserName)                                                          // get user by username
+	g.GET("/user/:name/status", i.GetUserStatus)                                                            // get whether a user is locked or not
+	g.GET("/users", i.GetUsers)                                                                             // get users
+	g.GET("/user/:name/roles", middleware.RequirePrivilege("users.admin"), i.GetRolesByUserName)            // get the roles held by a user
+	g.POST("/user", middleware.RequirePrivilege("users.admin"), i.CreateUser)                               // create new user
+	g.PATCH("/user/:name", i.ChangeAccountPassword)                                                         // update a user password
+	g.POST("/user/:name/assignRole", middleware.RequirePrivilege("users.admin"), i.AssignRoleToUser)        // grant a role to a user
+	g.PATCH("/user/:name/status", middleware.RequirePrivilege("users.admin"), i.SetUserStatus)              // lock a user
+	g.PATCH("/user/:name/unassignRole", middleware.RequirePrivilege("users.admin"), i.UnassignRoleFromUser) // revoke a role from a user
+	g.DELETE("/user/:name", middleware.RequirePrivilege("users.admin"), i.DeleteUser)                       // trash a user
+	g.DELETE("/user/:name/twoFactor", middleware.RequirePrivilege("users.admin"), i.ResetUserTwoFactor)     // remove a user's two-factor authentication
 }